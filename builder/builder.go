@@ -0,0 +1,397 @@
+package builder
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	gojsoncore "github.com/rogonion/go-json/core"
+	"github.com/rogonion/go-json/path"
+	"github.com/rogonion/go-metadatamodel/core"
+	"github.com/rogonion/go-metadatamodel/validation"
+)
+
+/*
+Build derives properties of every field/group in the model, validates it using validation.Validate, and returns it as a gojsoncore.JsonObject.
+
+Can be called from any GroupBuilder or FieldBuilder in the chain. The whole model is always returned.
+
+Derived properties:
+  - core.FieldGroupJsonPathKey from the key of each field/group and its parent group.
+  - core.GroupReadOrderOfFields from the order in which fields/groups were added.
+  - core.FieldGroupName from the key of each field/group if not set.
+  - core.FieldUI from core.FieldDataType if not set.
+  - core.DatabaseTableCollectionUid, core.DatabaseTableCollectionName, and core.DatabaseJoinDepth from the nearest group with GroupBuilder.Table if not set.
+  - core.DatabaseFieldColumnName of each field in a table/collection from its key if not set.
+*/
+func (n *GroupBuilder) Build() (gojsoncore.JsonObject, error) {
+	model, err := n.Model()
+	if err != nil {
+		return nil, err
+	}
+	return model.JsonObject(), nil
+}
+
+/*
+Model is similar to Build but returns the model as a core.Model.
+
+The returned core.Model is a copy and can be modified without affecting the builder.
+*/
+func (n *GroupBuilder) Model() (*core.Model, error) {
+	const FunctionName = "Model"
+
+	root := n.root
+	if root.err != nil {
+		return nil, root.err
+	}
+
+	deriveGroupProperties(&root.model.Group, databaseTableCollection{})
+
+	metadataModel := root.model.JsonObject()
+	if err := validation.Validate(metadataModel).Err(); err != nil {
+		return nil, NewError().WithFunctionName(FunctionName).WithMessage("metadata model not valid").WithNestedError(err)
+	}
+
+	model, err := core.DecodeModel(metadataModel)
+	if err != nil {
+		return nil, NewError().WithFunctionName(FunctionName).WithMessage("copy metadata model failed").WithNestedError(err)
+	}
+	return model, nil
+}
+
+/*
+Must returns metadataModel or panics if err is not nil.
+
+It wraps a call to GroupBuilder.Build or FieldBuilder.Build for models that are known to be valid like package-level variables.
+
+	var userMetadataModel = builder.Must(builder.NewModel("User").Field("Name", core.FieldTypeText).Build())
+*/
+func Must(metadataModel gojsoncore.JsonObject, err error) gojsoncore.JsonObject {
+	if err != nil {
+		panic(err)
+	}
+	return metadataModel
+}
+
+// Field adds a field to the group and returns its FieldBuilder.
+//
+// keySuffix is the key of the field in core.GroupFields. fieldDataType is a field type like core.FieldTypeText.
+func (n *GroupBuilder) Field(keySuffix string, fieldDataType string) *FieldBuilder {
+	const FunctionName = "Field"
+
+	field := &core.Field{FieldDataType: fieldDataType}
+	n.addFieldGroup(FunctionName, keySuffix, field)
+	return &FieldBuilder{field: field, parent: n}
+}
+
+// Group adds a nested group to the group and returns its GroupBuilder.
+//
+// keySuffix is the key of the group in core.GroupFields. Use GroupBuilder.End to return to the current group.
+func (n *GroupBuilder) Group(keySuffix string) *GroupBuilder {
+	const FunctionName = "Group"
+
+	group := &core.Group{GroupReadOrderOfFields: make([]string, 0)}
+	n.addFieldGroup(FunctionName, keySuffix, group)
+	return &GroupBuilder{group: group, parent: n, root: n.root}
+}
+
+// End returns the GroupBuilder of the parent group. Returns the current GroupBuilder if it is the root of the model.
+func (n *GroupBuilder) End() *GroupBuilder {
+	if n.parent == nil {
+		return n
+	}
+	return n.parent
+}
+
+// Name sets core.FieldGroupName. Defaults to the key of the group.
+func (n *GroupBuilder) Name(value string) *GroupBuilder {
+	n.group.FieldGroupName = value
+	return n
+}
+
+// Description sets core.FieldGroupDescription.
+func (n *GroupBuilder) Description(value string) *GroupBuilder {
+	n.group.FieldGroupDescription = value
+	return n
+}
+
+// MaxEntries sets core.FieldGroupMaxEntries.
+func (n *GroupBuilder) MaxEntries(value int) *GroupBuilder {
+	n.group.FieldGroupMaxEntries = &value
+	return n
+}
+
+/*
+Table sets core.DatabaseTableCollectionName and core.DatabaseJoinDepth of the group.
+
+Fields and groups within the group inherit them, as well as core.DatabaseTableCollectionUid, unless they have their own.
+*/
+func (n *GroupBuilder) Table(name string, joinDepth int) *GroupBuilder {
+	n.group.DatabaseTableCollectionName = name
+	n.group.DatabaseJoinDepth = &joinDepth
+	return n
+}
+
+// TableUid sets core.DatabaseTableCollectionUid. Defaults to the name set by GroupBuilder.Table.
+func (n *GroupBuilder) TableUid(value string) *GroupBuilder {
+	n.group.DatabaseTableCollectionUid = value
+	return n
+}
+
+// Property sets a property of the group as it would appear in a gojsoncore.JsonObject metadata model. Use GroupBuilder.Field and GroupBuilder.Group to add fields/groups.
+func (n *GroupBuilder) Property(key string, value any) *GroupBuilder {
+	const FunctionName = "Property"
+
+	if err := setProperty(n.group, key, value); err != nil {
+		n.root.setError(NewError().WithFunctionName(FunctionName).WithMessage(fmt.Sprintf("set property '%s' failed", key)).WithData(gojsoncore.JsonObject{"Key": key, "Value": value}).WithNestedError(err))
+	}
+	return n
+}
+
+// Configure calls configure with the core.Group being built to set properties that have no dedicated method.
+func (n *GroupBuilder) Configure(configure func(group *core.Group)) *GroupBuilder {
+	configure(n.group)
+	return n
+}
+
+func (n *GroupBuilder) addFieldGroup(functionName string, keySuffix string, fieldGroup core.FieldOrGroup) {
+	if len(keySuffix) == 0 || strings.ContainsAny(keySuffix, path.JsonpathDotNotation+path.JsonpathLeftBracket+path.JsonpathRightBracket+path.JsonpathKeyRoot+" ") {
+		n.root.setError(NewError().WithFunctionName(functionName).WithMessage(fmt.Sprintf("key '%s' not valid", keySuffix)).WithNestedError(ErrFieldGroupKeySuffixInvalid))
+		return
+	}
+	if _, ok := n.group.GroupFields[keySuffix]; ok {
+		n.root.setError(NewError().WithFunctionName(functionName).WithMessage(fmt.Sprintf("key '%s' already exists", keySuffix)).WithData(gojsoncore.JsonObject{"FieldGroupName": n.group.FieldGroupName}).WithNestedError(ErrFieldGroupDuplicate))
+		return
+	}
+	n.group.AddFieldGroup(keySuffix, fieldGroup)
+}
+
+// setError keeps the first error encountered while building the model.
+func (n *GroupBuilder) setError(err error) {
+	if n.err == nil {
+		n.err = err
+	}
+}
+
+// Build calls GroupBuilder.Build.
+func (n *FieldBuilder) Build() (gojsoncore.JsonObject, error) {
+	return n.parent.Build()
+}
+
+// Model calls GroupBuilder.Model.
+func (n *FieldBuilder) Model() (*core.Model, error) {
+	return n.parent.Model()
+}
+
+// Field adds a sibling field to the group the field is in.
+func (n *FieldBuilder) Field(keySuffix string, fieldDataType string) *FieldBuilder {
+	return n.parent.Field(keySuffix, fieldDataType)
+}
+
+// Group adds a sibling group to the group the field is in.
+func (n *FieldBuilder) Group(keySuffix string) *GroupBuilder {
+	return n.parent.Group(keySuffix)
+}
+
+// End ends the group the field is in. Refer to GroupBuilder.End.
+func (n *FieldBuilder) End() *GroupBuilder {
+	return n.parent.End()
+}
+
+// Name sets core.FieldGroupName. Defaults to the key of the field.
+func (n *FieldBuilder) Name(value string) *FieldBuilder {
+	n.field.FieldGroupName = value
+	return n
+}
+
+// Description sets core.FieldGroupDescription.
+func (n *FieldBuilder) Description(value string) *FieldBuilder {
+	n.field.FieldGroupDescription = value
+	return n
+}
+
+// Ui sets core.FieldUI. Defaults to the field UI matching core.FieldDataType.
+func (n *FieldBuilder) Ui(value string) *FieldBuilder {
+	n.field.FieldUi = value
+	return n
+}
+
+// Column sets core.DatabaseFieldColumnName. Defaults to the key of the field.
+func (n *FieldBuilder) Column(value string) *FieldBuilder {
+	n.field.DatabaseFieldColumnName = value
+	return n
+}
+
+// PrimaryKey sets core.FieldGroupIsPrimaryKey to `true`.
+func (n *FieldBuilder) PrimaryKey() *FieldBuilder {
+	isPrimaryKey := true
+	n.field.FieldGroupIsPrimaryKey = &isPrimaryKey
+	return n
+}
+
+// MaxEntries sets core.FieldGroupMaxEntries.
+func (n *FieldBuilder) MaxEntries(value int) *FieldBuilder {
+	n.field.FieldGroupMaxEntries = &value
+	return n
+}
+
+// DatetimeFormat sets core.FieldDatetimeFormat e.g. core.FieldDatetimeFormatYYYYMMDD.
+func (n *FieldBuilder) DatetimeFormat(value string) *FieldBuilder {
+	n.field.FieldDatetimeFormat = value
+	return n
+}
+
+// SelectOption appends an option to core.FieldSelectOptions and sets core.FieldUI to core.FieldUiSelect.
+func (n *FieldBuilder) SelectOption(label string, value any) *FieldBuilder {
+	n.field.FieldUi = core.FieldUiSelect
+	n.field.FieldSelectOptions = append(n.field.FieldSelectOptions, core.FieldSelectOption{Label: label, Type: n.field.FieldDataType, Value: value})
+	return n
+}
+
+// DefaultValue sets core.FieldDefaultValue.
+func (n *FieldBuilder) DefaultValue(value any) *FieldBuilder {
+	n.field.FieldDefaultValue = value
+	return n
+}
+
+// FullTextSearch sets core.DatabaseFieldAddDataToFullTextSearchIndex to `true`.
+func (n *FieldBuilder) FullTextSearch() *FieldBuilder {
+	addToIndex := true
+	n.field.DatabaseFieldAddDataToFullTextSearchIndex = &addToIndex
+	return n
+}
+
+// Property sets a property of the field as it would appear in a gojsoncore.JsonObject metadata model.
+func (n *FieldBuilder) Property(key string, value any) *FieldBuilder {
+	const FunctionName = "Property"
+
+	if err := setProperty(n.field, key, value); err != nil {
+		n.parent.root.setError(NewError().WithFunctionName(FunctionName).WithMessage(fmt.Sprintf("set property '%s' failed", key)).WithData(gojsoncore.JsonObject{"Key": key, "Value": value}).WithNestedError(err))
+	}
+	return n
+}
+
+// Configure calls configure with the core.Field being built to set properties that have no dedicated method.
+func (n *FieldBuilder) Configure(configure func(field *core.Field)) *FieldBuilder {
+	configure(n.field)
+	return n
+}
+
+// setProperty sets key of fieldGroup to value by decoding it the same way as core.DecodeModel.
+func setProperty(fieldGroup core.FieldOrGroup, key string, value any) error {
+	propertyJson, err := json.Marshal(gojsoncore.JsonObject{key: value})
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(propertyJson, fieldGroup)
+}
+
+// databaseTableCollection holds the database properties inherited from the nearest group with a table/collection.
+type databaseTableCollection struct {
+	uid       string
+	name      string
+	joinDepth *int
+}
+
+// deriveGroupProperties sets properties of group and its descendants that were not set explicitly. Refer to GroupBuilder.Build.
+func deriveGroupProperties(group *core.Group, table databaseTableCollection) {
+	if len(group.DatabaseTableCollectionName) > 0 {
+		if len(group.DatabaseTableCollectionUid) == 0 {
+			group.DatabaseTableCollectionUid = group.DatabaseTableCollectionName
+		}
+		table = databaseTableCollection{uid: group.DatabaseTableCollectionUid, name: group.DatabaseTableCollectionName, joinDepth: group.DatabaseJoinDepth}
+	} else {
+		setDatabaseTableCollection(group.GetFieldGroupProperties(), table)
+	}
+
+	for _, keySuffix := range group.GroupReadOrderOfFields {
+		fieldGroup, ok := group.GroupFields[keySuffix]
+		if !ok {
+			continue
+		}
+
+		fieldGroupProperties := fieldGroup.GetFieldGroupProperties()
+		fieldGroupProperties.FieldGroupJsonPathKey = group.FieldGroupJsonPathKey + path.JSONPath(core.GroupJsonPathPrefix+keySuffix)
+		if len(fieldGroupProperties.FieldGroupName) == 0 {
+			fieldGroupProperties.FieldGroupName = keySuffix
+		}
+
+		switch fieldGroup := fieldGroup.(type) {
+		case *core.Group:
+			deriveGroupProperties(fieldGroup, table)
+		case *core.Field:
+			if len(fieldGroup.FieldUi) == 0 {
+				fieldGroup.FieldUi = core.DefaultFieldUi(fieldGroup.FieldDataType)
+			}
+			setDatabaseTableCollection(fieldGroupProperties, table)
+			if len(table.name) > 0 && len(fieldGroup.DatabaseFieldColumnName) == 0 {
+				fieldGroup.DatabaseFieldColumnName = keySuffix
+			}
+		}
+	}
+}
+
+// setDatabaseTableCollection sets database properties of fieldGroupProperties from table if not set.
+func setDatabaseTableCollection(fieldGroupProperties *core.FieldGroupProperties, table databaseTableCollection) {
+	if len(table.name) == 0 {
+		return
+	}
+	if len(fieldGroupProperties.DatabaseTableCollectionUid) == 0 {
+		fieldGroupProperties.DatabaseTableCollectionUid = table.uid
+	}
+	if len(fieldGroupProperties.DatabaseTableCollectionName) == 0 {
+		fieldGroupProperties.DatabaseTableCollectionName = table.name
+	}
+	if fieldGroupProperties.DatabaseJoinDepth == nil && table.joinDepth != nil {
+		joinDepth := *table.joinDepth
+		fieldGroupProperties.DatabaseJoinDepth = &joinDepth
+	}
+}
+
+/*
+NewModel creates a GroupBuilder for the root group of a new metadata model.
+
+Parameters:
+  - name - core.FieldGroupName of the root group.
+*/
+func NewModel(name string) *GroupBuilder {
+	n := new(GroupBuilder)
+	n.model = &core.Model{
+		Group: core.Group{
+			FieldGroupProperties: core.FieldGroupProperties{
+				FieldGroupJsonPathKey: path.JSONPath(path.JsonpathKeyRoot),
+				FieldGroupName:        name,
+			},
+			GroupReadOrderOfFields: make([]string, 0),
+		},
+	}
+	n.group = &n.model.Group
+	n.root = n
+	return n
+}
+
+/*
+GroupBuilder builds a group of a metadata model.
+
+Usage:
+ 1. Instantiate the root GroupBuilder using NewModel.
+ 2. Add fields and nested groups using GroupBuilder.Field and GroupBuilder.Group.
+ 3. Retrieve the metadata model using GroupBuilder.Build or GroupBuilder.Model.
+
+Errors encountered while building are returned by GroupBuilder.Build.
+*/
+type GroupBuilder struct {
+	group  *core.Group
+	parent *GroupBuilder
+	root   *GroupBuilder
+
+	// model and err are only set on the root GroupBuilder.
+	model *core.Model
+	err   error
+}
+
+// FieldBuilder builds a field of a metadata model. Instantiate using GroupBuilder.Field.
+type FieldBuilder struct {
+	field  *core.Field
+	parent *GroupBuilder
+}
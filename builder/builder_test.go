@@ -0,0 +1,181 @@
+package builder
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	gojsoncore "github.com/rogonion/go-json/core"
+	"github.com/rogonion/go-metadatamodel/core"
+	"github.com/rogonion/go-metadatamodel/internal"
+	"github.com/rogonion/go-metadatamodel/testdata"
+	"github.com/rogonion/go-metadatamodel/validation"
+)
+
+func TestBuilder_Build(t *testing.T) {
+	for testData := range buildTestData {
+		res, err := testData.Builder.Build()
+		if testData.ExpectedErr != nil {
+			if !errors.Is(err, testData.ExpectedErr) {
+				t.Error(testData.TestTitle, "\n", "expected error", testData.ExpectedErr, "got", err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Error(testData.TestTitle, "\n", "build failed:", err)
+			continue
+		}
+
+		if expected, actual := internal.AsJsonDecoded(t, testData.Expected), internal.AsJsonDecoded(t, res); !reflect.DeepEqual(expected, actual) {
+			t.Error(
+				testData.TestTitle, "\n",
+				"expected res to be equal to testData.Expected\n",
+				"Expected=", gojsoncore.JsonStringifyMust(expected), "\n",
+				"res=", gojsoncore.JsonStringifyMust(actual),
+			)
+		}
+	}
+}
+
+func TestBuilder_Must(t *testing.T) {
+	if metadataModel := Must(NewModel("Product").Field("Price", core.FieldTypeNumber).Build()); metadataModel == nil {
+		t.Error("expected metadata model")
+	}
+
+	defer func() {
+		if recovered := recover(); recovered == nil {
+			t.Error("expected Must to panic when the model is not valid")
+		}
+	}()
+	Must(NewModel("Product").Field("", core.FieldTypeNumber).Build())
+}
+
+type buildData struct {
+	internal.TestData
+	Builder interface {
+		Build() (gojsoncore.JsonObject, error)
+	}
+	Expected    gojsoncore.JsonObject
+	ExpectedErr error
+}
+
+func buildTestData(yield func(data *buildData) bool) {
+	testCaseIndex := 1
+	if !yield(
+		&buildData{
+			TestData: internal.TestData{
+				TestTitle: "User Profile Metadata Model",
+			},
+			Builder: NewModel("UserProfile").Table("UserProfile", 0).
+				Field("Name", core.FieldTypeText).PrimaryKey().
+				Field("Age", core.FieldTypeNumber).
+				Group("Address").
+				Field("Street", core.FieldTypeText).
+				Field("City", core.FieldTypeText).
+				Field("ZipCode", core.FieldTypeText).
+				End(),
+			Expected: testdata.UserProfileMetadataModel(nil),
+		},
+	) {
+		return
+	}
+
+	testCaseIndex++
+	if !yield(
+		&buildData{
+			TestData: internal.TestData{
+				TestTitle: "Company Metadata Model with nested table",
+			},
+			Builder: NewModel("Company").Table("Company", 0).
+				Field("Name", core.FieldTypeText).PrimaryKey().
+				Group("Employees").Name("User").Table("Employees", 1).
+				Field("ID", core.FieldTypeNumber).PrimaryKey().
+				Field("Name", core.FieldTypeText).
+				Field("Email", core.FieldTypeText).
+				End(),
+			Expected: testdata.CompanyMetadataModel(nil),
+		},
+	) {
+		return
+	}
+
+	testCaseIndex++
+	if !yield(
+		&buildData{
+			TestData: internal.TestData{
+				TestTitle: "Model without table with select options and custom property",
+			},
+			Builder: NewModel("Survey").
+				Field("Country", core.FieldTypeText).SelectOption("Kenya", "KE").Property("x-Source", "iso").
+				Field("Published", core.FieldTypeTimestamp).DatetimeFormat(core.FieldDatetimeFormatYYYYMMDD).MaxEntries(1),
+			Expected: gojsoncore.JsonObject{
+				core.FieldGroupJsonPathKey: "$",
+				core.FieldGroupName:        "Survey",
+				core.GroupFields: gojsoncore.JsonArray{
+					gojsoncore.JsonObject{
+						"Country": gojsoncore.JsonObject{
+							core.FieldGroupJsonPathKey: "$.GroupFields[*].Country",
+							core.FieldGroupName:        "Country",
+							core.FieldDataType:         core.FieldTypeText,
+							core.FieldUI:               core.FieldUiSelect,
+							core.FieldSelectOptions: gojsoncore.JsonArray{
+								gojsoncore.JsonObject{core.Label: "Kenya", core.Type: core.FieldTypeText, core.Value: "KE"},
+							},
+							"x-Source": "iso",
+						},
+						"Published": gojsoncore.JsonObject{
+							core.FieldGroupJsonPathKey: "$.GroupFields[*].Published",
+							core.FieldGroupName:        "Published",
+							core.FieldDataType:         core.FieldTypeTimestamp,
+							core.FieldUI:               core.FieldUiDatetime,
+							core.FieldDatetimeFormat:   core.FieldDatetimeFormatYYYYMMDD,
+							core.FieldGroupMaxEntries:  1,
+						},
+					},
+				},
+				core.GroupReadOrderOfFields: gojsoncore.JsonArray{"Country", "Published"},
+			},
+		},
+	) {
+		return
+	}
+
+	testCaseIndex++
+	if !yield(
+		&buildData{
+			TestData: internal.TestData{
+				TestTitle: "Duplicate field",
+			},
+			Builder:     NewModel("User").Field("ID", core.FieldTypeNumber).Field("ID", core.FieldTypeText),
+			ExpectedErr: ErrFieldGroupDuplicate,
+		},
+	) {
+		return
+	}
+
+	testCaseIndex++
+	if !yield(
+		&buildData{
+			TestData: internal.TestData{
+				TestTitle: "Field key with json path symbols",
+			},
+			Builder:     NewModel("User").Field("Address.City", core.FieldTypeText),
+			ExpectedErr: ErrFieldGroupKeySuffixInvalid,
+		},
+	) {
+		return
+	}
+
+	testCaseIndex++
+	if !yield(
+		&buildData{
+			TestData: internal.TestData{
+				TestTitle: "Duplicate column in table",
+			},
+			Builder:     NewModel("User").Table("User", 0).Field("ID", core.FieldTypeNumber).Field("UserID", core.FieldTypeNumber).Column("ID"),
+			ExpectedErr: validation.ErrDatabaseFieldColumnNameDuplicate,
+		},
+	) {
+		return
+	}
+}
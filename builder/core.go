@@ -0,0 +1,67 @@
+package builder
+
+import (
+	"errors"
+
+	"github.com/rogonion/go-metadatamodel/core"
+	"github.com/rogonion/go-metadatamodel/internal"
+)
+
+// DefaultStructTagKey is the struct tag read by FromStruct.
+const DefaultStructTagKey string = "mdm"
+
+// Keys in a struct tag read by FromStruct e.g. `mdm:"name=Price,type=Number,pk,column=price"`.
+const (
+	// StructTagSkip skips a struct field when used as the whole tag i.e. `mdm:"-"`.
+	StructTagSkip string = "-"
+	// StructTagName sets core.FieldGroupName.
+	StructTagName string = "name"
+	// StructTagDescription sets core.FieldGroupDescription.
+	StructTagDescription string = "description"
+	// StructTagType sets core.FieldDataType. Inferred from the struct field type if not set.
+	StructTagType string = "type"
+	// StructTagUi sets core.FieldUI.
+	StructTagUi string = "ui"
+	// StructTagPrimaryKey sets core.FieldGroupIsPrimaryKey to `true`. Takes no value.
+	StructTagPrimaryKey string = "pk"
+	// StructTagColumn sets core.DatabaseFieldColumnName.
+	StructTagColumn string = "column"
+	// StructTagMaxEntries sets core.FieldGroupMaxEntries. Defaults to `1` for struct fields that are not slices or arrays.
+	StructTagMaxEntries string = "maxentries"
+	// StructTagDatetimeFormat sets core.FieldDatetimeFormat.
+	StructTagDatetimeFormat string = "format"
+	// StructTagFullTextSearch sets core.DatabaseFieldAddDataToFullTextSearchIndex to `true`. Takes no value.
+	StructTagFullTextSearch string = "fts"
+	// StructTagTable sets core.DatabaseTableCollectionName of a group.
+	StructTagTable string = "table"
+	// StructTagTableUid sets core.DatabaseTableCollectionUid of a group.
+	StructTagTableUid string = "tableuid"
+	// StructTagJoinDepth sets core.DatabaseJoinDepth of a group with StructTagTable. Defaults to the join depth of the parent group + 1.
+	StructTagJoinDepth string = "joindepth"
+)
+
+var (
+	// ErrBuilderError default error for builder module.
+	ErrBuilderError = errors.New("metadata model builder encountered an error")
+
+	// ErrFieldGroupKeySuffixInvalid for when the key of a field/group in core.GroupFields is empty or contains json path symbols.
+	ErrFieldGroupKeySuffixInvalid = errors.New("field/group key suffix invalid")
+
+	// ErrFieldGroupDuplicate for when a field/group is added to a group that already contains a field/group with the same key.
+	ErrFieldGroupDuplicate = errors.New("field/group already exists in group")
+
+	// ErrStructTypeInvalid for when FromStruct is called with a type that is not a struct or pointer to a struct.
+	ErrStructTypeInvalid = errors.New("type is not a struct")
+
+	// ErrStructTypeRecursive for when FromStruct encounters a struct type that contains itself.
+	ErrStructTypeRecursive = internal.ErrStructTypeRecursive
+
+	// ErrStructTagInvalid for when a struct tag read by FromStruct cannot be parsed.
+	ErrStructTagInvalid = errors.New("struct tag invalid")
+)
+
+// NewError creates a new core.Error with the default builder error base.
+func NewError() *core.Error {
+	n := core.NewError().WithDefaultBaseError(ErrBuilderError)
+	return n
+}
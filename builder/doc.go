@@ -0,0 +1,63 @@
+/*
+Package builder provides a fluent API for constructing metadata models in Go.
+
+Properties that are repeated across handwritten metadata models are derived when the model is built:
+  - core.FieldGroupJsonPathKey from the key of each field/group and its parent group.
+  - core.GroupReadOrderOfFields from the order in which fields/groups are added.
+  - core.FieldGroupName and core.DatabaseFieldColumnName from the key of each field/group.
+  - core.FieldUI from core.FieldDataType.
+  - core.DatabaseTableCollectionUid, core.DatabaseTableCollectionName, and core.DatabaseJoinDepth from the nearest group with a table/collection.
+
+Properties set explicitly are never overwritten. The built model is checked using validation.Validate.
+
+# Usage
+
+	import (
+		"github.com/rogonion/go-metadatamodel/builder"
+		"github.com/rogonion/go-metadatamodel/core"
+	)
+
+## Building a Metadata Model
+
+	metadataModel, err := builder.NewModel("User").Table("User", 0).
+		Field("ID", core.FieldTypeNumber).PrimaryKey().
+		Field("Name", core.FieldTypeText).
+		Group("Address").Table("Address", 1).
+			Field("City", core.FieldTypeText).
+			Field("Country", core.FieldTypeText).SelectOption("Kenya", "KE").SelectOption("Uganda", "UG").
+		End().
+		Field("Email", core.FieldTypeText).Column("email_address").
+		Build()
+
+Use Must for models that are known to be valid like package-level variables. It panics if the model is not valid.
+
+	var productMetadataModel = builder.Must(builder.NewModel("Product").Field("Price", core.FieldTypeNumber).Build())
+
+## Setting Other Properties
+
+Properties without a dedicated method can be set using FieldBuilder.Property/GroupBuilder.Property or FieldBuilder.Configure/GroupBuilder.Configure.
+
+	builder.NewModel("User").
+		Field("Bio", core.FieldTypeText).Property(core.FieldGroupViewDisable, true).
+		Configure(func(field *core.Field) {
+			field.FieldInputPlaceholder = "Tell us about yourself"
+		})
+
+## Generating from Go Structs
+
+FromStruct generates the metadata model and the matching schema.DynamicSchemaNode from a struct type. Struct tags control field/group properties.
+
+	type Product struct {
+		ID    []int     `mdm:"pk"`
+		Name  []string  `mdm:"fts"`
+		Price []float64 `mdm:"name=Price,type=Number,column=price"`
+		Notes []string  `mdm:"-"`
+	}
+
+	metadataModel, productSchema, err := builder.FromStruct(reflect.TypeOf(Product{}), &builder.FromStructOptions{Table: "Product"})
+
+## Typed Model
+
+Use GroupBuilder.Model to retrieve the model as a core.Model instead of a gojsoncore.JsonObject.
+*/
+package builder
@@ -0,0 +1,267 @@
+package builder
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	gojsoncore "github.com/rogonion/go-json/core"
+	"github.com/rogonion/go-json/schema"
+	"github.com/rogonion/go-metadatamodel/core"
+	"github.com/rogonion/go-metadatamodel/internal"
+)
+
+/*
+FromStruct generates a metadata model and the matching schema.DynamicSchemaNode from structType.
+
+Each exported struct field becomes a field with its name as the key in core.GroupFields. Struct fields whose type, or element type if it is a slice or array, is a struct (other than time.Time) become groups.
+
+core.FieldDataType is inferred from the struct field type:
+  - string -> core.FieldTypeText.
+  - int, uint, and float types -> core.FieldTypeNumber.
+  - bool -> core.FieldTypeBoolean.
+  - time.Time -> core.FieldTypeTimestamp.
+  - Others -> core.FieldTypeAny.
+
+Properties can be set using struct tags e.g. `mdm:"name=Price,type=Number,pk,column=price"`. Refer to the StructTag constants for the supported keys.
+
+Remaining properties are derived the same way as GroupBuilder.Build.
+
+Parameters:
+  - structType - struct or pointer to struct.
+  - options - Optional. Refer to FromStructOptions.
+
+Returns the metadata model and the schema of structType.
+*/
+func FromStruct(structType reflect.Type, options *FromStructOptions) (gojsoncore.JsonObject, *schema.DynamicSchemaNode, error) {
+	const FunctionName = "FromStruct"
+
+	if options == nil {
+		options = new(FromStructOptions)
+	}
+	tagKey := options.TagKey
+	if len(tagKey) == 0 {
+		tagKey = DefaultStructTagKey
+	}
+
+	if structType == nil {
+		return nil, nil, NewError().WithFunctionName(FunctionName).WithMessage("structType is nil").WithNestedError(ErrStructTypeInvalid)
+	}
+	baseType := structType
+	for baseType.Kind() == reflect.Pointer {
+		baseType = baseType.Elem()
+	}
+	if baseType.Kind() != reflect.Struct {
+		return nil, nil, NewError().WithFunctionName(FunctionName).WithMessage(fmt.Sprintf("'%s' is not a struct", structType)).WithNestedError(ErrStructTypeInvalid)
+	}
+
+	dynamicSchema, err := internal.SchemaFromType(structType)
+	if err != nil {
+		return nil, nil, NewError().WithFunctionName(FunctionName).WithMessage("generate schema failed").WithNestedError(err)
+	}
+
+	name := options.Name
+	if len(name) == 0 {
+		name = baseType.Name()
+	}
+	root := NewModel(name)
+	if len(options.Table) > 0 {
+		root.Table(options.Table, options.JoinDepth)
+	}
+
+	if err := addStructFields(root, baseType, tagKey, options.JoinDepth, map[reflect.Type]bool{baseType: true}); err != nil {
+		return nil, nil, NewError().WithFunctionName(FunctionName).WithMessage(fmt.Sprintf("generate metadata model from '%s' failed", structType)).WithNestedError(err)
+	}
+
+	metadataModel, err := root.Build()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return metadataModel, dynamicSchema, nil
+}
+
+// addStructFields adds a field/group to group for each exported field in structType.
+func addStructFields(group *GroupBuilder, structType reflect.Type, tagKey string, joinDepth int, visiting map[reflect.Type]bool) error {
+	for i := 0; i < structType.NumField(); i++ {
+		structField := structType.Field(i)
+		if !structField.IsExported() {
+			continue
+		}
+
+		tag, ok := structField.Tag.Lookup(tagKey)
+		if ok && tag == StructTagSkip {
+			continue
+		}
+		tagProperties, err := parseStructTag(tag)
+		if err != nil {
+			return fmt.Errorf("struct field '%s': %w", structField.Name, err)
+		}
+
+		elementType, isCollection := structFieldElementType(structField.Type)
+
+		if elementType.Kind() == reflect.Struct && elementType != reflect.TypeOf(time.Time{}) {
+			if visiting[elementType] {
+				return fmt.Errorf("struct field '%s' of type '%s': %w", structField.Name, elementType, ErrStructTypeRecursive)
+			}
+
+			childGroup := group.Group(structField.Name)
+			childJoinDepth := joinDepth
+			for key, value := range tagProperties {
+				switch key {
+				case StructTagName:
+					childGroup.Name(value)
+				case StructTagDescription:
+					childGroup.Description(value)
+				case StructTagMaxEntries:
+					maxEntries, err := strconv.Atoi(value)
+					if err != nil {
+						return fmt.Errorf("struct field '%s' tag '%s': %w", structField.Name, key, ErrStructTagInvalid)
+					}
+					childGroup.MaxEntries(maxEntries)
+				case StructTagTable, StructTagTableUid, StructTagJoinDepth:
+				default:
+					return fmt.Errorf("struct field '%s' tag '%s' not supported on groups: %w", structField.Name, key, ErrStructTagInvalid)
+				}
+			}
+			if table, ok := tagProperties[StructTagTable]; ok {
+				childJoinDepth = joinDepth + 1
+				if value, ok := tagProperties[StructTagJoinDepth]; ok {
+					if childJoinDepth, err = strconv.Atoi(value); err != nil {
+						return fmt.Errorf("struct field '%s' tag '%s': %w", structField.Name, StructTagJoinDepth, ErrStructTagInvalid)
+					}
+				}
+				childGroup.Table(table, childJoinDepth)
+				if tableUid, ok := tagProperties[StructTagTableUid]; ok {
+					childGroup.TableUid(tableUid)
+				}
+			}
+			if !isCollection {
+				if _, ok := tagProperties[StructTagMaxEntries]; !ok {
+					childGroup.MaxEntries(1)
+				}
+			}
+
+			visiting[elementType] = true
+			err := addStructFields(childGroup, elementType, tagKey, childJoinDepth, visiting)
+			delete(visiting, elementType)
+			if err != nil {
+				return err
+			}
+			continue
+		}
+
+		fieldDataType, ok := tagProperties[StructTagType]
+		if !ok {
+			fieldDataType = fieldDataTypeFromType(elementType)
+		}
+		field := group.Field(structField.Name, fieldDataType)
+		for key, value := range tagProperties {
+			switch key {
+			case StructTagType:
+			case StructTagName:
+				field.Name(value)
+			case StructTagDescription:
+				field.Description(value)
+			case StructTagUi:
+				field.Ui(value)
+			case StructTagPrimaryKey:
+				field.PrimaryKey()
+			case StructTagColumn:
+				field.Column(value)
+			case StructTagMaxEntries:
+				maxEntries, err := strconv.Atoi(value)
+				if err != nil {
+					return fmt.Errorf("struct field '%s' tag '%s': %w", structField.Name, key, ErrStructTagInvalid)
+				}
+				field.MaxEntries(maxEntries)
+			case StructTagDatetimeFormat:
+				field.DatetimeFormat(value)
+			case StructTagFullTextSearch:
+				field.FullTextSearch()
+			default:
+				return fmt.Errorf("struct field '%s' tag '%s' not supported on fields: %w", structField.Name, key, ErrStructTagInvalid)
+			}
+		}
+		if !isCollection {
+			if _, ok := tagProperties[StructTagMaxEntries]; !ok {
+				field.MaxEntries(1)
+			}
+		}
+	}
+	return nil
+}
+
+// parseStructTag splits tag into key-value pairs. Keys without a value like StructTagPrimaryKey have an empty value.
+func parseStructTag(tag string) (map[string]string, error) {
+	tagProperties := make(map[string]string)
+	if len(strings.TrimSpace(tag)) == 0 {
+		return tagProperties, nil
+	}
+	for _, tagProperty := range strings.Split(tag, ",") {
+		key, value, _ := strings.Cut(tagProperty, "=")
+		key = strings.TrimSpace(key)
+		if len(key) == 0 {
+			return nil, fmt.Errorf("empty key in '%s': %w", tag, ErrStructTagInvalid)
+		}
+		if _, ok := tagProperties[key]; ok {
+			return nil, fmt.Errorf("duplicate key '%s' in '%s': %w", key, tag, ErrStructTagInvalid)
+		}
+		tagProperties[key] = strings.TrimSpace(value)
+	}
+	return tagProperties, nil
+}
+
+// structFieldElementType removes pointers and one level of slice/array from fieldType. Returns `true` if fieldType is a slice or array.
+func structFieldElementType(fieldType reflect.Type) (reflect.Type, bool) {
+	for fieldType.Kind() == reflect.Pointer {
+		fieldType = fieldType.Elem()
+	}
+	isCollection := false
+	if fieldType.Kind() == reflect.Slice || fieldType.Kind() == reflect.Array {
+		isCollection = true
+		fieldType = fieldType.Elem()
+		for fieldType.Kind() == reflect.Pointer {
+			fieldType = fieldType.Elem()
+		}
+	}
+	return fieldType, isCollection
+}
+
+// fieldDataTypeFromType returns the core.FieldDataType matching elementType.
+func fieldDataTypeFromType(elementType reflect.Type) string {
+	if elementType == reflect.TypeOf(time.Time{}) {
+		return core.FieldTypeTimestamp
+	}
+	switch elementType.Kind() {
+	case reflect.String:
+		return core.FieldTypeText
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return core.FieldTypeNumber
+	case reflect.Bool:
+		return core.FieldTypeBoolean
+	default:
+		return core.FieldTypeAny
+	}
+}
+
+/*
+FromStructOptions for FromStruct.
+*/
+type FromStructOptions struct {
+	// Name sets core.FieldGroupName of the root group. Defaults to the name of the struct type.
+	Name string
+
+	// Table sets core.DatabaseTableCollectionName of the root group. Database properties are not set if empty.
+	Table string
+
+	// JoinDepth sets core.DatabaseJoinDepth of the root group.
+	JoinDepth int
+
+	// TagKey of struct tags to read. Defaults to DefaultStructTagKey.
+	TagKey string
+}
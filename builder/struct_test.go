@@ -0,0 +1,165 @@
+package builder
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	gojsoncore "github.com/rogonion/go-json/core"
+	"github.com/rogonion/go-json/schema"
+	"github.com/rogonion/go-metadatamodel/core"
+	"github.com/rogonion/go-metadatamodel/internal"
+	"github.com/rogonion/go-metadatamodel/testdata"
+)
+
+func TestBuilder_FromStruct(t *testing.T) {
+	for testData := range fromStructTestData {
+		metadataModel, dynamicSchema, err := FromStruct(testData.StructType, testData.Options)
+		if testData.ExpectedErr != nil {
+			if !errors.Is(err, testData.ExpectedErr) {
+				t.Error(testData.TestTitle, "\n", "expected error", testData.ExpectedErr, "got", err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Error(testData.TestTitle, "\n", "FromStruct failed:", err)
+			continue
+		}
+
+		if testData.ExpectedMetadataModel != nil {
+			if expected, actual := internal.AsJsonDecoded(t, testData.ExpectedMetadataModel), internal.AsJsonDecoded(t, metadataModel); !reflect.DeepEqual(expected, actual) {
+				t.Error(
+					testData.TestTitle, "\n",
+					"expected metadataModel to be equal to testData.ExpectedMetadataModel\n",
+					"ExpectedMetadataModel=", gojsoncore.JsonStringifyMust(expected), "\n",
+					"metadataModel=", gojsoncore.JsonStringifyMust(actual),
+				)
+			}
+		}
+
+		if testData.ExpectedSchema != nil && !reflect.DeepEqual(dynamicSchema, testData.ExpectedSchema) {
+			t.Error(
+				testData.TestTitle, "\n",
+				"expected dynamicSchema to be equal to testData.ExpectedSchema\n",
+				"ExpectedSchema=", testData.ExpectedSchema, "\n",
+				"dynamicSchema=", dynamicSchema,
+			)
+		}
+	}
+}
+
+type fromStructData struct {
+	internal.TestData
+	StructType            reflect.Type
+	Options               *FromStructOptions
+	ExpectedMetadataModel gojsoncore.JsonObject
+	ExpectedSchema        *schema.DynamicSchemaNode
+	ExpectedErr           error
+}
+
+type taggedAddress struct {
+	Street  []string
+	City    []string
+	ZipCode []*string
+}
+
+type taggedUserProfile struct {
+	Name    []string `mdm:"pk"`
+	Age     []int
+	Address []taggedAddress `mdm:"table=UserProfile,joindepth=1"`
+}
+
+type taggedEmployee struct {
+	ID      []int                `mdm:"pk"`
+	Profile []*taggedUserProfile `mdm:"name=UserProfile,table=Profile"`
+	Skills  []string             `mdm:"maxentries=0"`
+	notes   []string
+}
+
+type taggedOrder struct {
+	Reference string    `mdm:"name=Order Reference,column=reference,fts"`
+	Price     []float64 `mdm:"type=Number,pk,column=price"`
+	Placed    time.Time `mdm:"format=yyyy-mm-dd"`
+	Internal  []string  `mdm:"-"`
+	Metadata  map[string]any
+}
+
+type recursiveNode struct {
+	Name     []string
+	Children []recursiveNode
+}
+
+func fromStructTestData(yield func(data *fromStructData) bool) {
+	testCaseIndex := 1
+	if !yield(
+		&fromStructData{
+			TestData: internal.TestData{
+				TestTitle: "Employee with nested table",
+			},
+			StructType:            reflect.TypeOf(taggedEmployee{}),
+			Options:               &FromStructOptions{Name: "Employee", Table: "Employee"},
+			ExpectedMetadataModel: testdata.EmployeeMetadataModel(nil),
+		},
+	) {
+		return
+	}
+
+	testCaseIndex++
+	if !yield(
+		&fromStructData{
+			TestData: internal.TestData{
+				TestTitle: "Employee schema",
+			},
+			StructType:     reflect.TypeOf(testdata.Employee{}),
+			ExpectedSchema: testdata.EmployeeSchema(),
+		},
+	) {
+		return
+	}
+
+	testCaseIndex++
+	if !yield(
+		&fromStructData{
+			TestData: internal.TestData{
+				TestTitle: "Order with tags and non-collection fields",
+			},
+			StructType: reflect.TypeOf(&taggedOrder{}),
+			Options:    &FromStructOptions{Table: "orders"},
+			ExpectedMetadataModel: Must(NewModel("taggedOrder").Table("orders", 0).
+				Field("Reference", core.FieldTypeText).Name("Order Reference").Column("reference").FullTextSearch().MaxEntries(1).
+				Field("Price", core.FieldTypeNumber).PrimaryKey().Column("price").
+				Field("Placed", core.FieldTypeTimestamp).DatetimeFormat(core.FieldDatetimeFormatYYYYMMDD).MaxEntries(1).
+				Field("Metadata", core.FieldTypeAny).MaxEntries(1).
+				Build()),
+		},
+	) {
+		return
+	}
+
+	testCaseIndex++
+	if !yield(
+		&fromStructData{
+			TestData: internal.TestData{
+				TestTitle: "Recursive struct",
+			},
+			StructType:  reflect.TypeOf(recursiveNode{}),
+			ExpectedErr: ErrStructTypeRecursive,
+		},
+	) {
+		return
+	}
+
+	testCaseIndex++
+	if !yield(
+		&fromStructData{
+			TestData: internal.TestData{
+				TestTitle: "Not a struct",
+			},
+			StructType:  reflect.TypeOf([]string{}),
+			ExpectedErr: ErrStructTypeInvalid,
+		},
+	) {
+		return
+	}
+}
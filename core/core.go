@@ -43,4 +43,7 @@ var (
 
 	// ErrPathContainsIndexPlaceholders For when preparing JSONPath from metadata-model to value in object still contains ARRAY_PATH_PLACEHOLDER.
 	ErrPathContainsIndexPlaceholders = errors.New("path contains index placeholders")
+
+	// ErrFieldMultipleValuesJoinSymbolInvalid For when FieldMultipleValuesJoinSymbol contains a backslash which is reserved for escaping values. Refer to JoinFieldValues.
+	ErrFieldMultipleValuesJoinSymbolInvalid = errors.New("field multiple values join symbol not valid")
 )
@@ -86,6 +86,20 @@ Example:
 	// Delete value for column `Price`
 	noOfModifications, err = fieldValue.Delete("Price", "", nil)
 
+## Model
+
+Typed representation of a metadata model using Model, Group, and Field.
+
+Properties not defined in the structs are kept in FieldGroupProperties.CustomProperties so that converting a metadata model to Model and back keeps every property. Number properties come back as float64, the same as in metadata models decoded from JSON.
+
+	model, err := core.DecodeModel(metadataModel)
+
+	price := model.FieldGroupByJsonPathKey("$.GroupFields[*].Price").(*core.Field)
+	price.FieldGroupDescription = "Price in KES"
+
+	// Back to gojsoncore.JsonObject for use with other modules.
+	metadataModel = model.JsonObject()
+
 ## Utils
 
 Shared utility functions for manipulating and inspecting metadata models.
@@ -0,0 +1,632 @@
+package core
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+
+	gojsoncore "github.com/rogonion/go-json/core"
+	"github.com/rogonion/go-json/path"
+	"github.com/rogonion/go-json/schema"
+)
+
+/*
+DecodeModel converts a metadata model in its gojsoncore.JsonObject (or any JSON marshallable) form into a Model.
+
+Properties that are not defined in Field, Group, or FieldGroupProperties are kept in FieldGroupProperties.CustomProperties so that Model.JsonObject or json.Marshal keeps every property. Number properties come back as float64, the same as in metadata models decoded from JSON.
+
+Number properties that are not JSON numbers, like the string `"1"`, are converted using schema.Conversion.
+
+A field/group is decoded as a Group if it contains GroupFields or GroupReadOrderOfFields, otherwise as a Field.
+*/
+func DecodeModel(metadataModel any) (*Model, error) {
+	metadataModelJson, err := json.Marshal(metadataModel)
+	if err != nil {
+		return nil, fmt.Errorf("marshal metadata model failed: %w", err)
+	}
+
+	model := new(Model)
+	if err := json.Unmarshal(metadataModelJson, model); err != nil {
+		return nil, fmt.Errorf("unmarshal metadata model failed: %w", err)
+	}
+	return model, nil
+}
+
+/*
+FieldOrGroup is implemented by *Field and *Group.
+*/
+type FieldOrGroup interface {
+	// GetFieldGroupProperties returns properties shared by fields and groups.
+	GetFieldGroupProperties() *FieldGroupProperties
+
+	// JsonObject returns the field/group in its gojsoncore.JsonObject form.
+	JsonObject() gojsoncore.JsonObject
+
+	// IsGroup returns `true` for *Group.
+	IsGroup() bool
+}
+
+// GetFieldGroupProperties returns properties shared by fields and groups.
+func (n *FieldGroupProperties) GetFieldGroupProperties() *FieldGroupProperties {
+	return n
+}
+
+// IsGroup returns `false`.
+func (n *Field) IsGroup() bool {
+	return false
+}
+
+// JsonObject returns the field in its gojsoncore.JsonObject form.
+func (n *Field) JsonObject() gojsoncore.JsonObject {
+	return modelStructToJsonObject(reflect.ValueOf(n).Elem())
+}
+
+// MarshalJSON implements json.Marshaler.
+func (n *Field) MarshalJSON() ([]byte, error) {
+	return json.Marshal(n.JsonObject())
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (n *Field) UnmarshalJSON(data []byte) error {
+	return modelStructFromJson(data, reflect.ValueOf(n).Elem())
+}
+
+// IsGroup returns `true`.
+func (n *Group) IsGroup() bool {
+	return true
+}
+
+// JsonObject returns the group in its gojsoncore.JsonObject form.
+func (n *Group) JsonObject() gojsoncore.JsonObject {
+	return modelStructToJsonObject(reflect.ValueOf(n).Elem())
+}
+
+// MarshalJSON implements json.Marshaler.
+func (n *Group) MarshalJSON() ([]byte, error) {
+	return json.Marshal(n.JsonObject())
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (n *Group) UnmarshalJSON(data []byte) error {
+	return modelStructFromJson(data, reflect.ValueOf(n).Elem())
+}
+
+/*
+ReadOrderOfFieldGroups returns the fields/groups in GroupFields following GroupReadOrderOfFields.
+
+Entries in GroupReadOrderOfFields not found in GroupFields are ignored.
+*/
+func (n *Group) ReadOrderOfFieldGroups() []FieldOrGroup {
+	fieldGroups := make([]FieldOrGroup, 0, len(n.GroupReadOrderOfFields))
+	for _, fieldGroupKeySuffix := range n.GroupReadOrderOfFields {
+		if fieldGroup, ok := n.GroupFields[fieldGroupKeySuffix]; ok && fieldGroup != nil {
+			fieldGroups = append(fieldGroups, fieldGroup)
+		}
+	}
+	return fieldGroups
+}
+
+/*
+AddFieldGroup adds fieldGroup to GroupFields with fieldGroupKeySuffix and appends fieldGroupKeySuffix to GroupReadOrderOfFields.
+
+If fieldGroupKeySuffix already exists, fieldGroup replaces it without changing the read order.
+*/
+func (n *Group) AddFieldGroup(fieldGroupKeySuffix string, fieldGroup FieldOrGroup) {
+	if n.GroupFields == nil {
+		n.GroupFields = make(map[string]FieldOrGroup)
+	}
+	if !slices.Contains(n.GroupReadOrderOfFields, fieldGroupKeySuffix) {
+		n.GroupReadOrderOfFields = append(n.GroupReadOrderOfFields, fieldGroupKeySuffix)
+	}
+	n.GroupFields[fieldGroupKeySuffix] = fieldGroup
+}
+
+/*
+FieldGroupByJsonPathKey returns the field/group in the model whose FieldGroupJsonPathKey is fieldGroupJsonPathKey.
+
+Returns nil if not found.
+*/
+func (n *Model) FieldGroupByJsonPathKey(fieldGroupJsonPathKey path.JSONPath) FieldOrGroup {
+	if n.FieldGroupJsonPathKey == fieldGroupJsonPathKey {
+		return &n.Group
+	}
+	return findFieldGroupByJsonPathKey(&n.Group, fieldGroupJsonPathKey)
+}
+
+func findFieldGroupByJsonPathKey(group *Group, fieldGroupJsonPathKey path.JSONPath) FieldOrGroup {
+	for _, fieldGroup := range group.ReadOrderOfFieldGroups() {
+		if fieldGroup.GetFieldGroupProperties().FieldGroupJsonPathKey == fieldGroupJsonPathKey {
+			return fieldGroup
+		}
+		if childGroup, ok := fieldGroup.(*Group); ok {
+			if found := findFieldGroupByJsonPathKey(childGroup, fieldGroupJsonPathKey); found != nil {
+				return found
+			}
+		}
+	}
+	return nil
+}
+
+// JsonObject returns the model in its gojsoncore.JsonObject form which can be used by the rest of the modules.
+func (n *Model) JsonObject() gojsoncore.JsonObject {
+	return n.Group.JsonObject()
+}
+
+// modelStructToJsonObject converts Field, Group, or a nested property struct into a gojsoncore.JsonObject.
+//
+// Properties with a zero value like an empty string or a nil pointer are omitted unless they were present when value was decoded.
+func modelStructToJsonObject(value reflect.Value) gojsoncore.JsonObject {
+	jsonObject := make(gojsoncore.JsonObject)
+
+	presence, _ := modelStructPropertyPresence(value)
+
+	for _, structField := range modelStructFields(value) {
+		present, decoded := false, false
+		if presence != nil {
+			present, decoded = presence.isPropertyPresent(structField.Key)
+		}
+
+		switch structField.Key {
+		case "":
+			// CustomProperties
+			if customProperties, ok := structField.Value.Interface().(gojsoncore.JsonObject); ok {
+				for key, customProperty := range customProperties {
+					jsonObject[key] = customProperty
+				}
+			}
+			continue
+		case GroupFields:
+			if structField.Value.IsNil() {
+				if present || !decoded {
+					jsonObject[GroupFields] = gojsoncore.JsonArray{}
+				}
+				continue
+			}
+			groupFields := make(gojsoncore.JsonObject)
+			for fieldGroupKeySuffix, fieldGroup := range structField.Value.Interface().(map[string]FieldOrGroup) {
+				if fieldGroup != nil {
+					groupFields[fieldGroupKeySuffix] = fieldGroup.JsonObject()
+				}
+			}
+			jsonObject[GroupFields] = gojsoncore.JsonArray{groupFields}
+			continue
+		case GroupReadOrderOfFields:
+			if structField.Value.IsNil() && decoded && !present {
+				continue
+			}
+			groupReadOrderOfFields := make(gojsoncore.JsonArray, 0)
+			for _, fieldGroupKeySuffix := range structField.Value.Interface().([]string) {
+				groupReadOrderOfFields = append(groupReadOrderOfFields, fieldGroupKeySuffix)
+			}
+			jsonObject[GroupReadOrderOfFields] = groupReadOrderOfFields
+			continue
+		}
+
+		if jsonValue, ok := modelValueToJsonValue(structField.Value); ok || present {
+			jsonObject[structField.Key] = jsonValue
+		}
+	}
+
+	return jsonObject
+}
+
+// modelValueToJsonValue converts a property value into its json-like form. Returns `false` if value is a zero value that should be omitted like an empty string or a nil pointer.
+func modelValueToJsonValue(value reflect.Value) (any, bool) {
+	switch value.Kind() {
+	case reflect.Pointer:
+		if value.IsNil() {
+			return nil, false
+		}
+		return modelValueToJsonValue(value.Elem())
+	case reflect.Interface:
+		if value.IsNil() {
+			return nil, false
+		}
+		return value.Interface(), true
+	case reflect.String:
+		return value.String(), value.Len() > 0
+	case reflect.Bool:
+		return value.Bool(), true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		// Numbers are stored as float64 to match metadata models decoded from JSON.
+		return float64(value.Int()), true
+	case reflect.Float32, reflect.Float64:
+		return value.Float(), true
+	case reflect.Struct:
+		return modelStructToJsonObject(value), true
+	case reflect.Map:
+		if value.IsNil() {
+			return nil, false
+		}
+		return value.Interface(), true
+	case reflect.Slice:
+		if value.IsNil() {
+			return nil, false
+		}
+		jsonArray := make(gojsoncore.JsonArray, 0, value.Len())
+		for i := 0; i < value.Len(); i++ {
+			jsonValue, _ := modelValueToJsonValue(value.Index(i))
+			jsonArray = append(jsonArray, jsonValue)
+		}
+		return jsonArray, true
+	default:
+		return value.Interface(), true
+	}
+}
+
+// modelStructFromJson decodes data into Field, Group, or a nested property struct keeping properties not defined in the struct in its CustomProperties.
+func modelStructFromJson(data []byte, value reflect.Value) error {
+	properties := make(map[string]json.RawMessage)
+	if err := json.Unmarshal(data, &properties); err != nil {
+		return err
+	}
+
+	presence, _ := modelStructPropertyPresence(value)
+	if presence != nil {
+		presence.setDecoded()
+	}
+
+	structFields := make(map[string]reflect.Value)
+	var customProperties reflect.Value
+	for _, structField := range modelStructFields(value) {
+		if structField.Key == "" {
+			customProperties = structField.Value
+			continue
+		}
+		structFields[structField.Key] = structField.Value
+	}
+
+	keys := make([]string, 0, len(properties))
+	for key := range properties {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		property := properties[key]
+
+		structField, ok := structFields[key]
+		if !ok {
+			var customProperty any
+			if err := json.Unmarshal(property, &customProperty); err != nil {
+				return fmt.Errorf("property '%s': %w", key, err)
+			}
+			if customProperties.IsValid() {
+				if customProperties.IsNil() {
+					customProperties.Set(reflect.ValueOf(make(gojsoncore.JsonObject)))
+				}
+				customProperties.SetMapIndex(reflect.ValueOf(key), reflect.ValueOf(&customProperty).Elem())
+			}
+			continue
+		}
+
+		if presence != nil {
+			presence.setPropertyPresent(key)
+		}
+
+		if key == GroupFields {
+			groupFieldsArray := make([]map[string]json.RawMessage, 0)
+			if err := json.Unmarshal(property, &groupFieldsArray); err != nil {
+				return fmt.Errorf("property '%s': %w", key, err)
+			}
+			var groupFields map[string]FieldOrGroup
+			if len(groupFieldsArray) > 0 {
+				groupFields = make(map[string]FieldOrGroup)
+			}
+			for _, groupFieldsEntry := range groupFieldsArray {
+				for fieldGroupKeySuffix, fieldGroupJson := range groupFieldsEntry {
+					fieldGroup, err := decodeFieldGroup(fieldGroupJson)
+					if err != nil {
+						return fmt.Errorf("property '%s' field/group '%s': %w", key, fieldGroupKeySuffix, err)
+					}
+					groupFields[fieldGroupKeySuffix] = fieldGroup
+				}
+			}
+			structField.Set(reflect.ValueOf(groupFields))
+			continue
+		}
+
+		if err := json.Unmarshal(property, structField.Addr().Interface()); err != nil {
+			if convertErr := modelPropertyConvert(property, structField); convertErr != nil {
+				return fmt.Errorf("property '%s': %w", key, errors.Join(err, convertErr))
+			}
+		}
+	}
+
+	return nil
+}
+
+// modelPropertyConvert decodes property into a number pointer structField using schema.Conversion for values like the string `"1"`.
+func modelPropertyConvert(property json.RawMessage, structField reflect.Value) error {
+	if structField.Kind() != reflect.Pointer {
+		return fmt.Errorf("%s is not a pointer", structField.Type())
+	}
+
+	elemType := structField.Type().Elem()
+	switch elemType.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64, reflect.Float32, reflect.Float64:
+	default:
+		return fmt.Errorf("%s is not a number", elemType)
+	}
+
+	var value any
+	if err := json.Unmarshal(property, &value); err != nil {
+		return err
+	}
+
+	converted := reflect.New(elemType)
+	if err := schema.NewConversion().Convert(value, &schema.DynamicSchemaNode{Type: elemType, Kind: elemType.Kind()}, converted.Interface()); err != nil {
+		return err
+	}
+	structField.Set(converted)
+	return nil
+}
+
+// modelStructPropertyPresence returns the propertyPresence of value if it is addressable.
+func modelStructPropertyPresence(value reflect.Value) (modelPropertyPresence, bool) {
+	if !value.CanAddr() {
+		return nil, false
+	}
+	presence, ok := value.Addr().Interface().(modelPropertyPresence)
+	return presence, ok
+}
+
+// modelPropertyPresence is implemented by structs embedding propertyPresence.
+type modelPropertyPresence interface {
+	setDecoded()
+	setPropertyPresent(key string)
+	isPropertyPresent(key string) (present bool, decoded bool)
+}
+
+/*
+propertyPresence records the properties found when a struct was decoded.
+
+It allows properties that were present with a zero value, like an empty string, to be told apart from absent ones when converting back.
+*/
+type propertyPresence struct {
+	properties map[string]bool
+}
+
+func (n *propertyPresence) setDecoded() {
+	n.properties = make(map[string]bool)
+}
+
+func (n *propertyPresence) setPropertyPresent(key string) {
+	if n.properties == nil {
+		n.setDecoded()
+	}
+	n.properties[key] = true
+}
+
+// isPropertyPresent returns whether key was found. decoded is `false` if the struct was not decoded.
+func (n *propertyPresence) isPropertyPresent(key string) (present bool, decoded bool) {
+	return n.properties[key], n.properties != nil
+}
+
+// decodeFieldGroup decodes data into *Group if it contains GroupFields or GroupReadOrderOfFields, otherwise into *Field.
+func decodeFieldGroup(data []byte) (FieldOrGroup, error) {
+	properties := make(map[string]json.RawMessage)
+	if err := json.Unmarshal(data, &properties); err != nil {
+		return nil, err
+	}
+
+	_, hasGroupFields := properties[GroupFields]
+	_, hasGroupReadOrderOfFields := properties[GroupReadOrderOfFields]
+	if hasGroupFields || hasGroupReadOrderOfFields {
+		group := new(Group)
+		if err := json.Unmarshal(data, group); err != nil {
+			return nil, err
+		}
+		return group, nil
+	}
+
+	field := new(Field)
+	if err := json.Unmarshal(data, field); err != nil {
+		return nil, err
+	}
+	return field, nil
+}
+
+type modelStructField struct {
+	// Property name. Empty for FieldGroupProperties.CustomProperties.
+	Key   string
+	Value reflect.Value
+}
+
+// modelStructFields returns the properties of Field, Group, or a nested property struct including those of embedded structs.
+func modelStructFields(value reflect.Value) []modelStructField {
+	structFields := make([]modelStructField, 0)
+	for i := 0; i < value.NumField(); i++ {
+		structField := value.Type().Field(i)
+		if !structField.IsExported() {
+			continue
+		}
+		if structField.Anonymous && structField.Type.Kind() == reflect.Struct {
+			structFields = append(structFields, modelStructFields(value.Field(i))...)
+			continue
+		}
+
+		key := structField.Name
+		if tag, ok := structField.Tag.Lookup("json"); ok {
+			tagName, _, _ := strings.Cut(tag, ",")
+			if tagName == "-" {
+				key = ""
+			} else if len(tagName) > 0 {
+				key = tagName
+			}
+		}
+		structFields = append(structFields, modelStructField{Key: key, Value: value.Field(i)})
+	}
+	return structFields
+}
+
+/*
+FieldColumnPositionProperty is the typed form of the FieldColumnPosition property.
+*/
+type FieldColumnPositionProperty struct {
+	propertyPresence
+
+	FieldGroupJsonPathKey                       path.JSONPath `json:"FieldGroupJsonPathKey,omitempty"`
+	FieldGroupPositionBefore                    *bool         `json:"FieldGroupPositionBefore,omitempty"`
+	FieldViewValuesInSeparateColumnsHeaderIndex *int          `json:"FieldViewValuesInSeparateColumnsHeaderIndex,omitempty"`
+
+	// CustomProperties holds properties that are not defined in FieldColumnPositionProperty.
+	CustomProperties gojsoncore.JsonObject `json:"-"`
+}
+
+// MarshalJSON implements json.Marshaler.
+func (n *FieldColumnPositionProperty) MarshalJSON() ([]byte, error) {
+	return json.Marshal(modelStructToJsonObject(reflect.ValueOf(n).Elem()))
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (n *FieldColumnPositionProperty) UnmarshalJSON(data []byte) error {
+	return modelStructFromJson(data, reflect.ValueOf(n).Elem())
+}
+
+/*
+FieldTypedValue is the typed form of properties like FieldCheckboxValueIfTrue.
+*/
+type FieldTypedValue struct {
+	propertyPresence
+
+	// Type is a field type like FieldTypeText.
+	Type  string `json:"Type,omitempty"`
+	Value any    `json:"Value,omitempty"`
+
+	// CustomProperties holds properties that are not defined in FieldTypedValue.
+	CustomProperties gojsoncore.JsonObject `json:"-"`
+}
+
+// MarshalJSON implements json.Marshaler.
+func (n *FieldTypedValue) MarshalJSON() ([]byte, error) {
+	return json.Marshal(modelStructToJsonObject(reflect.ValueOf(n).Elem()))
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (n *FieldTypedValue) UnmarshalJSON(data []byte) error {
+	return modelStructFromJson(data, reflect.ValueOf(n).Elem())
+}
+
+/*
+FieldSelectOption is the typed form of an entry in the FieldSelectOptions property.
+*/
+type FieldSelectOption struct {
+	propertyPresence
+
+	Label string `json:"Label,omitempty"`
+	// Type is a field type like FieldTypeText.
+	Type  string `json:"Type,omitempty"`
+	Value any    `json:"Value,omitempty"`
+
+	// CustomProperties holds properties that are not defined in FieldSelectOption.
+	CustomProperties gojsoncore.JsonObject `json:"-"`
+}
+
+// MarshalJSON implements json.Marshaler.
+func (n *FieldSelectOption) MarshalJSON() ([]byte, error) {
+	return json.Marshal(modelStructToJsonObject(reflect.ValueOf(n).Elem()))
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (n *FieldSelectOption) UnmarshalJSON(data []byte) error {
+	return modelStructFromJson(data, reflect.ValueOf(n).Elem())
+}
+
+/*
+FieldGroupProperties are properties shared by Field and Group.
+
+Optional bool and number properties are pointers so that the absence of a property can be distinguished from its zero value. Empty strings are omitted unless they were present in the decoded metadata model.
+*/
+type FieldGroupProperties struct {
+	propertyPresence
+
+	FieldGroupJsonPathKey                        path.JSONPath                `json:"FieldGroupJsonPathKey,omitempty"`
+	FieldGroupName                               string                       `json:"FieldGroupName,omitempty"`
+	FieldGroupDescription                        string                       `json:"FieldGroupDescription,omitempty"`
+	FieldGroupViewTableLockColumn                *bool                        `json:"FieldGroupViewTableLockColumn,omitempty"`
+	FieldGroupIsPrimaryKey                       *bool                        `json:"FieldGroupIsPrimaryKey,omitempty"`
+	FieldGroupViewValuesInSeparateColumns        *bool                        `json:"FieldGroupViewValuesInSeparateColumns,omitempty"`
+	FieldGroupViewMaxNoOfValuesInSeparateColumns *int                         `json:"FieldGroupViewMaxNoOfValuesInSeparateColumns,omitempty"`
+	FieldGroupInputDisable                       *bool                        `json:"FieldGroupInputDisable,omitempty"`
+	FieldGroupDisablePropertiesEdit              *bool                        `json:"FieldGroupDisablePropertiesEdit,omitempty"`
+	FieldGroupViewDisable                        *bool                        `json:"FieldGroupViewDisable,omitempty"`
+	FieldGroupQueryConditionsEditDisable         *bool                        `json:"FieldGroupQueryConditionsEditDisable,omitempty"`
+	FieldGroupMaxEntries                         *int                         `json:"FieldGroupMaxEntries,omitempty"`
+	FieldColumnPosition                          *FieldColumnPositionProperty `json:"FieldColumnPosition,omitempty"`
+
+	DatabaseSkipDataExtraction  *bool  `json:"DatabaseSkipDataExtraction,omitempty"`
+	DatabaseTableCollectionUid  string `json:"DatabaseTableCollectionUid,omitempty"`
+	DatabaseTableCollectionName string `json:"DatabaseTableCollectionName,omitempty"`
+	DatabaseFieldColumnName     string `json:"DatabaseFieldColumnName,omitempty"`
+	DatabaseJoinDepth           *int   `json:"DatabaseJoinDepth,omitempty"`
+	DatabaseDistinct            *bool  `json:"DatabaseDistinct,omitempty"`
+	DatabaseSortByAsc           *bool  `json:"DatabaseSortByAsc,omitempty"`
+	DatabaseLimit               *int   `json:"DatabaseLimit,omitempty"`
+	DatabaseOffset              *int   `json:"DatabaseOffset,omitempty"`
+
+	// CustomProperties holds properties that are not defined in Field, Group, or FieldGroupProperties.
+	CustomProperties gojsoncore.JsonObject `json:"-"`
+}
+
+/*
+Field is the typed form of a metadata model field.
+*/
+type Field struct {
+	FieldGroupProperties
+
+	// FieldDataType is a field type like FieldTypeText.
+	FieldDataType string `json:"FieldDataType,omitempty"`
+	// FieldUi is a field UI like FieldUiText.
+	FieldUi string `json:"FieldUi,omitempty"`
+
+	FieldViewValuesInSeparateColumnsHeaderFormat string `json:"FieldViewValuesInSeparateColumnsHeaderFormat,omitempty"`
+	FieldViewValuesInSeparateColumnsHeaderIndex  *int   `json:"FieldViewValuesInSeparateColumnsHeaderIndex,omitempty"`
+	FieldMultipleValuesJoinSymbol                string `json:"FieldMultipleValuesJoinSymbol,omitempty"`
+
+	FieldGroupTypeAny               gojsoncore.JsonObject `json:"FieldGroupTypeAny,omitempty"`
+	FieldCheckboxValueIfTrue        *FieldTypedValue      `json:"FieldCheckboxValueIfTrue,omitempty"`
+	FieldCheckboxValueIfFalse       *FieldTypedValue      `json:"FieldCheckboxValueIfFalse,omitempty"`
+	FieldCheckboxValuesUseInView    *bool                 `json:"FieldCheckboxValuesUseInView,omitempty"`
+	FieldCheckboxValuesUseInStorage *bool                 `json:"FieldCheckboxValuesUseInStorage,omitempty"`
+	FieldInputPlaceholder           string                `json:"FieldInputPlaceholder,omitempty"`
+	// FieldDatetimeFormat is a format like FieldDatetimeFormatYYYYMMDD.
+	FieldDatetimeFormat string              `json:"FieldDatetimeFormat,omitempty"`
+	FieldSelectOptions  []FieldSelectOption `json:"FieldSelectOptions,omitempty"`
+	FieldPlaceholder    any                 `json:"FieldPlaceholder,omitempty"`
+	FieldDefaultValue   any                 `json:"FieldDefaultValue,omitempty"`
+
+	DatabaseFieldAddDataToFullTextSearchIndex *bool `json:"DatabaseFieldAddDataToFullTextSearchIndex,omitempty"`
+
+	DatumInputView any `json:"DatumInputView,omitempty"`
+}
+
+/*
+Group is the typed form of a metadata model group.
+*/
+type Group struct {
+	FieldGroupProperties
+
+	GroupViewTableIn2D             *bool `json:"GroupViewTableIn2D,omitempty"`
+	GroupQueryAddFullTextSearchBox *bool `json:"GroupQueryAddFullTextSearchBox,omitempty"`
+	GroupExtractAsSingleField      *bool `json:"GroupExtractAsSingleField,omitempty"`
+
+	// GroupReadOrderOfFields is the order of keys in GroupFields.
+	GroupReadOrderOfFields []string `json:"GroupReadOrderOfFields"`
+
+	// GroupFields contains child fields/groups keyed by the suffix of their FieldGroupJsonPathKey. Each value is either *Field or *Group.
+	GroupFields map[string]FieldOrGroup `json:"GroupFields"`
+}
+
+/*
+Model is the typed form of a metadata model i.e. its root group.
+
+Use DecodeModel to create one from a gojsoncore.JsonObject and Model.JsonObject to convert it back.
+*/
+type Model struct {
+	Group
+}
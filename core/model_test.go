@@ -0,0 +1,225 @@
+package core
+
+import (
+	"encoding/json"
+	"os"
+	"reflect"
+	"testing"
+
+	gojsoncore "github.com/rogonion/go-json/core"
+	"github.com/rogonion/go-metadatamodel/internal"
+)
+
+func TestCore_ModelRoundTrip(t *testing.T) {
+	for testData := range modelRoundTripTestData {
+		model, err := DecodeModel(testData.MetadataModel)
+		if err != nil {
+			t.Error(testData.TestTitle, "\n", "decode model failed:", err)
+			continue
+		}
+
+		expectedMetadataModel := testData.MetadataModel
+		if testData.ExpectedMetadataModel != nil {
+			expectedMetadataModel = testData.ExpectedMetadataModel
+		}
+
+		for _, res := range []any{model.JsonObject(), model} {
+			if expected, actual := modelAsJsonDecoded(t, expectedMetadataModel), modelAsJsonDecoded(t, res); !reflect.DeepEqual(expected, actual) {
+				t.Error(
+					testData.TestTitle, "\n",
+					"expected round trip to be equal to MetadataModel\n",
+					"MetadataModel=", gojsoncore.JsonStringifyMust(expected), "\n",
+					"res=", gojsoncore.JsonStringifyMust(actual),
+				)
+			}
+		}
+
+		if testData.Check != nil {
+			testData.Check(t, model)
+		}
+	}
+}
+
+type modelRoundTripData struct {
+	internal.TestData
+	MetadataModel any
+	// ExpectedMetadataModel is the result of the round trip if it is not MetadataModel.
+	ExpectedMetadataModel any
+	Check                 func(t *testing.T, model *Model)
+}
+
+func modelRoundTripTestData(yield func(data *modelRoundTripData) bool) {
+	testCaseIndex := 1
+	testMetadataModel := make(gojsoncore.JsonObject)
+	if data, err := os.ReadFile("../testdata/test_metadatamodel.json"); err == nil {
+		_ = json.Unmarshal(data, &testMetadataModel)
+	}
+	if !yield(
+		&modelRoundTripData{
+			TestData: internal.TestData{
+				TestTitle: "Test Metadata Model file",
+			},
+			MetadataModel: testMetadataModel,
+		},
+	) {
+		return
+	}
+
+	testCaseIndex++
+	if !yield(
+		&modelRoundTripData{
+			TestData: internal.TestData{
+				TestTitle: "Metadata Model with zero values and custom properties",
+			},
+			MetadataModel: gojsoncore.JsonObject{
+				FieldGroupJsonPathKey: "$",
+				FieldGroupName:        "Order",
+				FieldGroupMaxEntries:  0,
+				DatabaseJoinDepth:     0,
+				"x-Owner":             gojsoncore.JsonObject{"Team": "Sales"},
+				GroupFields: gojsoncore.JsonArray{
+					gojsoncore.JsonObject{
+						"Total": gojsoncore.JsonObject{
+							FieldGroupJsonPathKey:  "$.GroupFields[*].Total",
+							FieldDataType:          FieldTypeNumber,
+							FieldUI:                FieldUiNumber,
+							FieldGroupIsPrimaryKey: false,
+							FieldDefaultValue:      0,
+							FieldColumnPosition: gojsoncore.JsonObject{
+								FieldGroupJsonPathKey:                       "$.GroupFields[*].Items.GroupFields[*].Price",
+								FieldGroupPositionBefore:                    true,
+								FieldViewValuesInSeparateColumnsHeaderIndex: 0,
+							},
+							"x-Precision": 2,
+						},
+						"Items": gojsoncore.JsonObject{
+							FieldGroupJsonPathKey: "$.GroupFields[*].Items",
+							DatabaseSortByAsc:     false,
+							GroupFields: gojsoncore.JsonArray{
+								gojsoncore.JsonObject{
+									"Price": gojsoncore.JsonObject{
+										FieldGroupJsonPathKey: "$.GroupFields[*].Items.GroupFields[*].Price",
+										FieldDataType:         FieldTypeNumber,
+										FieldUI:               FieldUiSelect,
+										FieldSelectOptions: gojsoncore.JsonArray{
+											gojsoncore.JsonObject{Label: "Ten", Type: FieldTypeNumber, Value: 10},
+										},
+									},
+								},
+							},
+							GroupReadOrderOfFields: gojsoncore.JsonArray{"Price"},
+						},
+						"Tags": gojsoncore.JsonObject{
+							FieldGroupJsonPathKey:  "$.GroupFields[*].Tags",
+							GroupFields:            gojsoncore.JsonArray{},
+							GroupReadOrderOfFields: gojsoncore.JsonArray{},
+						},
+					},
+				},
+				GroupReadOrderOfFields: gojsoncore.JsonArray{"Items", "Total", "Tags"},
+			},
+			Check: func(t *testing.T, model *Model) {
+				readOrder := make([]string, 0)
+				for _, fieldGroup := range model.ReadOrderOfFieldGroups() {
+					readOrder = append(readOrder, string(fieldGroup.GetFieldGroupProperties().FieldGroupJsonPathKey))
+				}
+				if !reflect.DeepEqual(readOrder, []string{"$.GroupFields[*].Items", "$.GroupFields[*].Total", "$.GroupFields[*].Tags"}) {
+					t.Error("expected ReadOrderOfFieldGroups to follow GroupReadOrderOfFields\n", "res=", readOrder)
+				}
+
+				price, ok := model.FieldGroupByJsonPathKey("$.GroupFields[*].Items.GroupFields[*].Price").(*Field)
+				if !ok || len(price.FieldSelectOptions) != 1 || price.FieldSelectOptions[0].Label != "Ten" {
+					t.Error("expected Price field with FieldSelectOptions\n", "res=", gojsoncore.JsonStringifyMust(price))
+				}
+
+				total, ok := model.FieldGroupByJsonPathKey("$.GroupFields[*].Total").(*Field)
+				if !ok || total.FieldGroupIsPrimaryKey == nil || *total.FieldGroupIsPrimaryKey || total.CustomProperties["x-Precision"] != float64(2) {
+					t.Error("expected Total field with FieldGroupIsPrimaryKey false and x-Precision custom property\n", "res=", gojsoncore.JsonStringifyMust(total))
+				}
+
+				if model.FieldGroupMaxEntries == nil || *model.FieldGroupMaxEntries != 0 {
+					t.Error("expected FieldGroupMaxEntries to be 0")
+				}
+			},
+		},
+	) {
+		return
+	}
+	testCaseIndex++
+	if !yield(
+		&modelRoundTripData{
+			TestData: internal.TestData{
+				TestTitle: "Metadata Model with empty strings, custom select option properties, and no read order",
+			},
+			MetadataModel: gojsoncore.JsonObject{
+				FieldGroupJsonPathKey: "$",
+				GroupFields: gojsoncore.JsonArray{
+					gojsoncore.JsonObject{
+						"Tags": gojsoncore.JsonObject{
+							FieldGroupJsonPathKey:         "$.GroupFields[*].Tags",
+							FieldGroupDescription:         "",
+							FieldDataType:                 FieldTypeText,
+							FieldUI:                       FieldUiSelect,
+							FieldMultipleValuesJoinSymbol: "",
+							FieldSelectOptions: gojsoncore.JsonArray{
+								gojsoncore.JsonObject{Label: "", Type: FieldTypeText, Value: "", "x-Color": "red"},
+							},
+							FieldCheckboxValueIfTrue: gojsoncore.JsonObject{Type: FieldTypeText, Value: "yes", "x-Icon": "tick"},
+						},
+					},
+				},
+			},
+			Check: func(t *testing.T, model *Model) {
+				tags, ok := model.GroupFields["Tags"].(*Field)
+				if !ok || len(tags.FieldSelectOptions) != 1 || tags.FieldSelectOptions[0].CustomProperties["x-Color"] != "red" {
+					t.Error("expected Tags field with x-Color select option custom property\n", "res=", gojsoncore.JsonStringifyMust(tags))
+				}
+			},
+		},
+	) {
+		return
+	}
+
+	testCaseIndex++
+	if !yield(
+		&modelRoundTripData{
+			TestData: internal.TestData{
+				TestTitle: "Metadata Model with number properties as strings",
+			},
+			MetadataModel: gojsoncore.JsonObject{
+				FieldGroupJsonPathKey:  "$",
+				DatabaseJoinDepth:      "1",
+				FieldGroupMaxEntries:   "5",
+				GroupFields:            gojsoncore.JsonArray{},
+				GroupReadOrderOfFields: gojsoncore.JsonArray{},
+			},
+			ExpectedMetadataModel: gojsoncore.JsonObject{
+				FieldGroupJsonPathKey:  "$",
+				DatabaseJoinDepth:      1,
+				FieldGroupMaxEntries:   5,
+				GroupFields:            gojsoncore.JsonArray{},
+				GroupReadOrderOfFields: gojsoncore.JsonArray{},
+			},
+			Check: func(t *testing.T, model *Model) {
+				if model.DatabaseJoinDepth == nil || *model.DatabaseJoinDepth != 1 {
+					t.Error("expected DatabaseJoinDepth to be 1")
+				}
+			},
+		},
+	) {
+		return
+	}
+}
+
+// modelAsJsonDecoded returns metadataModel as it would be after decoding it from JSON.
+func modelAsJsonDecoded(t *testing.T, metadataModel any) any {
+	data, err := json.Marshal(metadataModel)
+	if err != nil {
+		t.Fatal("marshal metadata model failed:", err)
+	}
+	var res any
+	if err := json.Unmarshal(data, &res); err != nil {
+		t.Fatal("unmarshal metadata model failed:", err)
+	}
+	return res
+}
@@ -179,6 +179,9 @@ func GetGroupReadOrderOfFields(fg any) (MetadataModelGroupReadOrderOfFields, err
 func GetGroupFields(fg any) (gojsoncore.JsonObject, error) {
 	if fgProperty, err := AsJsonObject(fg); err == nil {
 		if gFields, err := AsJsonArray(fgProperty[GroupFields]); err == nil {
+			if len(gFields) == 0 {
+				return nil, fmt.Errorf("gFields is empty: %w", ErrArgumentInvalid)
+			}
 			if gFieldsMap, err := AsJsonObject(gFields[0]); err == nil {
 				return gFieldsMap, nil
 			} else {
@@ -225,3 +228,148 @@ func GetFieldGroupJsonPathKeySuffix(fg any) string {
 	}
 	return ""
 }
+
+// FieldTypes returns a list of supported FieldDataType values.
+func FieldTypes() []string {
+	return []string{FieldTypeText, FieldTypeNumber, FieldTypeBoolean, FieldTypeTimestamp, FieldTypeAny}
+}
+
+// FieldUis returns a list of supported FieldUI values.
+func FieldUis() []string {
+	return []string{FieldUiText, FieldUiTextArea, FieldUiNumber, FieldUiCheckbox, FieldUiSelect, FieldUiDatetime}
+}
+
+// DefaultFieldUi returns the FieldUI value matching a FieldDataType value. Returns an empty string for FieldTypeAny or unknown values.
+func DefaultFieldUi(fieldDataType string) string {
+	switch fieldDataType {
+	case FieldTypeText:
+		return FieldUiText
+	case FieldTypeNumber:
+		return FieldUiNumber
+	case FieldTypeBoolean:
+		return FieldUiCheckbox
+	case FieldTypeTimestamp:
+		return FieldUiDatetime
+	default:
+		return ""
+	}
+}
+
+// FieldDatetimeFormatLayout returns the time.Layout equivalent of a FieldDatetimeFormat value like FieldDatetimeFormatYYYYMMDD.
+func FieldDatetimeFormatLayout(fieldDatetimeFormat string) (string, bool) {
+	switch fieldDatetimeFormat {
+	case FieldDatetimeFormatYYYYMMDDHHMM:
+		return "2006-01-02 15:04", true
+	case FieldDatetimeFormatYYYYMMDD:
+		return "2006-01-02", true
+	case FieldDatetimeFormatYYYYMM:
+		return "2006-01", true
+	case FieldDatetimeFormatYYYY:
+		return "2006", true
+	case FieldDatetimeFormatMM:
+		return "01", true
+	case FieldDatetimeFormatHHMM:
+		return "15:04", true
+	default:
+		return "", false
+	}
+}
+
+/*
+JoinFieldValues joins values into a single string with FieldMultipleValuesJoinSymbol of field or a comma by default.
+
+Backslashes and join symbols within each value are escaped with a backslash so that SplitFieldValues returns the same values. A single empty value is written as a lone backslash so that it is not mistaken for no values. Values of fields with FieldGroupMaxEntries set to 1 are not escaped.
+
+Returns ErrFieldMultipleValuesJoinSymbolInvalid if the join symbol contains a backslash.
+*/
+func JoinFieldValues(field gojsoncore.JsonObject, values []string) (string, error) {
+	if isFieldSingleValued(field) {
+		return strings.Join(values, ""), nil
+	}
+
+	joinSymbol, err := fieldMultipleValuesJoinSymbol(field)
+	if err != nil {
+		return "", err
+	}
+
+	if len(values) == 1 && len(values[0]) == 0 {
+		return `\`, nil
+	}
+
+	escaper := strings.NewReplacer(`\`, `\\`, joinSymbol, `\`+joinSymbol)
+	escapedValues := make([]string, 0, len(values))
+	for _, value := range values {
+		escapedValues = append(escapedValues, escaper.Replace(value))
+	}
+	return strings.Join(escapedValues, joinSymbol), nil
+}
+
+/*
+SplitFieldValues splits value on FieldMultipleValuesJoinSymbol of field or a comma by default. It is the inverse of JoinFieldValues.
+
+Join symbols and backslashes escaped with a backslash are unescaped. Other backslashes are kept as is.
+
+A lone backslash is a single empty value. value is not split if field has FieldGroupMaxEntries set to 1 since it can only hold a single value.
+
+Returns ErrFieldMultipleValuesJoinSymbolInvalid if the join symbol contains a backslash.
+*/
+func SplitFieldValues(field gojsoncore.JsonObject, value string) ([]string, error) {
+	if isFieldSingleValued(field) {
+		return []string{value}, nil
+	}
+
+	joinSymbol, err := fieldMultipleValuesJoinSymbol(field)
+	if err != nil {
+		return nil, err
+	}
+
+	if value == `\` {
+		return []string{""}, nil
+	}
+
+	values := make([]string, 0)
+	var current strings.Builder
+	for i := 0; i < len(value); {
+		switch {
+		case strings.HasPrefix(value[i:], `\\`):
+			current.WriteByte('\\')
+			i += 2
+		case strings.HasPrefix(value[i:], `\`+joinSymbol):
+			current.WriteString(joinSymbol)
+			i += 1 + len(joinSymbol)
+		case strings.HasPrefix(value[i:], joinSymbol):
+			values = append(values, current.String())
+			current.Reset()
+			i += len(joinSymbol)
+		default:
+			current.WriteByte(value[i])
+			i++
+		}
+	}
+	return append(values, current.String()), nil
+}
+
+// fieldMultipleValuesJoinSymbol returns FieldMultipleValuesJoinSymbol of field or a comma by default. Returns ErrFieldMultipleValuesJoinSymbolInvalid if it contains a backslash.
+func fieldMultipleValuesJoinSymbol(field gojsoncore.JsonObject) (string, error) {
+	value, ok := field[FieldMultipleValuesJoinSymbol].(string)
+	if !ok || len(value) == 0 {
+		return ",", nil
+	}
+	if strings.Contains(value, `\`) {
+		return "", fmt.Errorf("'%s': %w", value, ErrFieldMultipleValuesJoinSymbolInvalid)
+	}
+	return value, nil
+}
+
+// isFieldSingleValued returns `true` if FieldGroupMaxEntries of field is 1.
+func isFieldSingleValued(field gojsoncore.JsonObject) bool {
+	value, ok := field[FieldGroupMaxEntries]
+	if !ok {
+		return false
+	}
+	var maxEntries int
+	if err := schema.NewConversion().Convert(value, &schema.DynamicSchemaNode{Type: reflect.TypeOf(0), Kind: reflect.Int}, &maxEntries); err != nil {
+		return false
+	}
+	return maxEntries == 1
+}
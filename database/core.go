@@ -15,6 +15,12 @@ var (
 
 	//ErrDatabaseFieldValueError for when FieldValue methods fails.
 	ErrDatabaseFieldValueError = errors.New("database manipulate field value error")
+
+	//ErrDatabaseGetTableCollectionsError for when GetTableCollections.Get fails.
+	ErrDatabaseGetTableCollectionsError = errors.New("database get table collections error")
+
+	//ErrDatabaseScanRowsError for when ScanRows fails.
+	ErrDatabaseScanRowsError = errors.New("database scan rows error")
 )
 
 // NewError creates a new core.Error with the default database error base.
@@ -51,6 +51,19 @@ Example:
 
 	columnFields, err := gcf.Get(metadataModel)
 
+## GetTableCollections
+
+Module can be used to discover every table collection in a metadata model together with its parent, children, join depth, core.FieldGroupJsonPathKey, ColumnFields, and primary keys. Refer to TableCollection.
+
+Example:
+
+	tableCollections, err := database.NewGetTableCollections().Get(metadataModel)
+
+	root := tableCollections[0]
+	for _, child := range root.Children {
+		// child.Name, child.JoinDepth, child.ColumnFields, child.PrimaryKeys()...
+	}
+
 ## FieldValue
 
 Module can be used to FieldValue.Get, FieldValue.Set, and FieldValue.Delete value(s) in an sourceData using metadata model and its database properties.
@@ -104,5 +117,39 @@ Example:
 
 	// Delete value for column `Price`
 	noOfModifications, err = fieldValue.Delete("Price", "", nil)
+
+Whole records can be retrieved or set using FieldValue.GetRecord and FieldValue.SetRecord. FieldValue.ForEachRecord loops through every instance of the table collection in sourceData, for example every `Profile` of every `Employee`. GetAs converts a value found into a specific type using schema.Conversion:
+
+	var price float64
+	price, err = database.GetAs[float64](fieldValue, "Price", "", nil)
+
+	err = fieldValue.ForEachRecord(func(arrayIndexes []int, record map[string]any) bool {
+		// Return true to stop
+		return false
+	})
+
+Changes made using FieldValue.Set and FieldValue.Delete are recorded if change tracking is enabled using FieldValue.WithTrackChanges. Use FieldValue.Changes to retrieve them, FieldValue.Commit to clear them, and FieldValue.Rollback to restore the old values:
+
+	fieldValue.SetTrackChanges(true)
+
+	noOfModifications, err = fieldValue.Set("Name", "Oreos", "", nil)
+
+	changedColumns := fieldValue.ChangedColumns()
+
+	err = fieldValue.Rollback()
+
+## ScanRows
+
+ScanRows appends one record per row in a database/sql query result to sourceData. Result columns are matched using core.DatabaseFieldColumnName and values are converted using core.FieldDataType before being set using FieldValue.Set.
+
+Example:
+
+	rows, err := db.Query(`SELECT "ID", "Name", "Price" FROM "Product"`)
+
+	// Source must be a slice. For typed slices, set a schema for the slice.
+	sourceData := object.NewObject().WithSourceInterface(gojsoncore.JsonArray{})
+
+	var noOfRecords uint64
+	noOfRecords, err = database.ScanRows(rows, columnFields, sourceData)
 */
 package database
@@ -59,10 +59,12 @@ func (n *FieldValue) Set(columnFieldName string, valueToSet any, suffixJsonPath
 		return 0, err
 	}
 
-	if reflect.ValueOf(valueToSet).Kind() == reflect.Array || reflect.ValueOf(valueToSet).Kind() == reflect.Slice {
-		return n.sourceData.Set(jsonPathKey, valueToSet)
+	if reflect.ValueOf(valueToSet).Kind() != reflect.Array && reflect.ValueOf(valueToSet).Kind() != reflect.Slice {
+		valueToSet = []any{valueToSet}
 	}
-	return n.sourceData.Set(jsonPathKey, []any{valueToSet})
+	return n.trackChange(columnFieldName, arrayIndexes, jsonPathKey, func() (uint64, error) {
+		return n.sourceData.Set(jsonPathKey, valueToSet)
+	})
 }
 
 /*
@@ -81,7 +83,9 @@ func (n *FieldValue) Delete(columnFieldName string, suffixJsonPath path.JSONPath
 		return 0, err
 	}
 
-	return n.sourceData.Delete(jsonPathKey)
+	return n.trackChange(columnFieldName, arrayIndexes, jsonPathKey, func() (uint64, error) {
+		return n.sourceData.Delete(jsonPathKey)
+	})
 }
 
 func (n *FieldValue) getJsonPathToValue(columnFieldName string, suffixJsonPath path.JSONPath, arrayIndexes []int) (path.JSONPath, error) {
@@ -106,10 +110,10 @@ func (n *FieldValue) getJsonPathToValue(columnFieldName string, suffixJsonPath p
 	}
 
 	if len(suffixJsonPath) > 0 {
-
+		jsonPathKey += path.JSONPath(path.JsonpathDotNotation) + suffixJsonPath
 	}
 
-	jsonPathToValue, err := core.NewJsonPathToValue().WithSourceOfValueIsAnArray(n.objectSourceIsAnArray).WithRemoveGroupFields(true).Get(jsonPathKey+path.JSONPath(path.JsonpathDotNotation)+suffixJsonPath, arrayIndexes)
+	jsonPathToValue, err := core.NewJsonPathToValue().WithSourceOfValueIsAnArray(n.objectSourceIsAnArray).WithRemoveGroupFields(true).Get(jsonPathKey, arrayIndexes)
 	if err != nil {
 		return "", NewError().WithFunctionName(FunctionName).WithMessage("Get JsonPathToValue failed").WithNestedError(err)
 	}
@@ -128,6 +132,17 @@ func (n *FieldValue) SetColumnFields(value *ColumnFields) {
 	n.columnFields = value
 }
 
+// WithTrackChanges enables recording changes made by FieldValue.Set and FieldValue.Delete. Refer to FieldValue.Changes.
+func (n *FieldValue) WithTrackChanges(value bool) *FieldValue {
+	n.SetTrackChanges(value)
+	return n
+}
+
+// SetTrackChanges enables recording changes made by FieldValue.Set and FieldValue.Delete. Refer to FieldValue.Changes.
+func (n *FieldValue) SetTrackChanges(value bool) {
+	n.trackChanges = value
+}
+
 // WithSourceData sets the source data object.
 func (n *FieldValue) WithSourceData(value *object.Object) *FieldValue {
 	n.SetSourceData(value)
@@ -183,4 +198,8 @@ type FieldValue struct {
 
 	// Extracted database fields. Refer to GetColumnFields.
 	columnFields *ColumnFields
+
+	// Record changes made by FieldValue.Set and FieldValue.Delete. Refer to FieldValue.Changes.
+	trackChanges bool
+	changes      []Change
 }
@@ -0,0 +1,123 @@
+package database
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+
+	"github.com/brunoga/deep"
+	gojsoncore "github.com/rogonion/go-json/core"
+	"github.com/rogonion/go-json/path"
+)
+
+/*
+Change is a modification of a column value made by FieldValue.Set or FieldValue.Delete while change tracking is enabled. Refer to FieldValue.WithTrackChanges.
+*/
+type Change struct {
+	// ColumnFieldName is the core.DatabaseFieldColumnName of the column.
+	ColumnFieldName string
+	// ArrayIndexes passed to FieldValue.Set or FieldValue.Delete.
+	ArrayIndexes []int
+	// JsonPath to the value in the source data.
+	JsonPath path.JSONPath
+	// OldValueFound is false if the value did not exist before the change.
+	OldValueFound bool
+	// OldValue before the change.
+	OldValue any
+	// NewValue after the change. nil if the value was deleted.
+	NewValue any
+}
+
+/*
+Changes returns the changes recorded since change tracking was enabled or since the last FieldValue.Commit or FieldValue.Rollback, oldest first.
+
+A FieldValue.Set or FieldValue.Delete that leaves the value as it was is not recorded.
+*/
+func (n *FieldValue) Changes() []Change {
+	return slices.Clone(n.changes)
+}
+
+// ChangedColumns returns the core.DatabaseFieldColumnName of the columns in FieldValue.Changes in the order they were first changed.
+func (n *FieldValue) ChangedColumns() []string {
+	columnFieldNames := make([]string, 0)
+	for _, change := range n.changes {
+		if !slices.Contains(columnFieldNames, change.ColumnFieldName) {
+			columnFieldNames = append(columnFieldNames, change.ColumnFieldName)
+		}
+	}
+	return columnFieldNames
+}
+
+// Commit accepts the changes in the source data and clears FieldValue.Changes.
+func (n *FieldValue) Commit() {
+	n.changes = nil
+}
+
+/*
+Rollback restores the old values in FieldValue.Changes in the source data, newest first, and clears FieldValue.Changes.
+
+Values that did not exist before a change are deleted.
+*/
+func (n *FieldValue) Rollback() error {
+	const FunctionName = "Rollback"
+
+	for i := len(n.changes) - 1; i >= 0; i-- {
+		change := n.changes[i]
+
+		var err error
+		if change.OldValueFound {
+			_, err = n.sourceData.Set(change.JsonPath, change.OldValue)
+		} else {
+			_, err = n.sourceData.Delete(change.JsonPath)
+		}
+		if err != nil {
+			n.changes = n.changes[:i+1]
+			return NewError().WithFunctionName(FunctionName).WithMessage(fmt.Sprintf("restore value of column '%s' at '%s' failed", change.ColumnFieldName, change.JsonPath)).WithNestedError(err)
+		}
+	}
+
+	n.changes = nil
+	return nil
+}
+
+// trackChange calls modify and, if FieldValue.trackChanges is true, records the Change it made to the value at jsonPath.
+func (n *FieldValue) trackChange(columnFieldName string, arrayIndexes []int, jsonPath path.JSONPath, modify func() (uint64, error)) (uint64, error) {
+	if !n.trackChanges {
+		return modify()
+	}
+
+	oldValue, oldValueFound := n.valueAt(jsonPath)
+	noOfModifications, err := modify()
+	if noOfModifications == 0 {
+		return noOfModifications, err
+	}
+
+	newValue, _ := n.valueAt(jsonPath)
+	if oldValueFound && reflect.DeepEqual(oldValue, newValue) {
+		return noOfModifications, err
+	}
+
+	n.changes = append(n.changes, Change{
+		ColumnFieldName: columnFieldName,
+		ArrayIndexes:    slices.Clone(arrayIndexes),
+		JsonPath:        jsonPath,
+		OldValueFound:   oldValueFound,
+		OldValue:        oldValue,
+		NewValue:        newValue,
+	})
+	return noOfModifications, err
+}
+
+// valueAt returns a copy of the value at jsonPath in the source data and whether it was found.
+func (n *FieldValue) valueAt(jsonPath path.JSONPath) (any, bool) {
+	noOfResults, err := n.sourceData.Get(jsonPath)
+	if err != nil || noOfResults == 0 || gojsoncore.IsNilOrInvalid(n.sourceData.GetValueFoundReflected()) {
+		return nil, false
+	}
+
+	value := n.sourceData.GetValueFoundInterface()
+	if valueCopy, err := deep.Copy(value); err == nil {
+		return valueCopy, true
+	}
+	return value, true
+}
@@ -0,0 +1,113 @@
+package database
+
+import (
+	"reflect"
+	"testing"
+
+	gojsoncore "github.com/rogonion/go-json/core"
+	"github.com/rogonion/go-json/object"
+	"github.com/rogonion/go-metadatamodel/testdata"
+)
+
+// TestDatabase_FieldValueChanges tests recording, committing, and rolling back changes on the Product struct.
+func TestDatabase_FieldValueChanges(t *testing.T) {
+	product := &testdata.Product{
+		ID:    []int{1},
+		Name:  []string{"Twinkies"},
+		Price: []float64{2.5},
+	}
+
+	obj := object.NewObject().WithSourceInterface(product).WithSchema(testdata.ProductSchema())
+	columnFields, err := NewGetColumnFields().WithJoinDepth(0).WithTableCollectionName("Product").Get(testdata.ProductMetadataModel(nil))
+	if err != nil {
+		t.Fatal("Get Column Fields failed:", err)
+	}
+
+	fieldValue := NewFieldValue(obj, columnFields)
+	if _, err := fieldValue.Set("Name", "Oreos", "", nil); err != nil {
+		t.Fatal("Set Name failed:", err)
+	}
+	if len(fieldValue.Changes()) != 0 {
+		t.Fatal("changes recorded without change tracking, changes=", gojsoncore.JsonStringifyMust(fieldValue.Changes()))
+	}
+
+	fieldValue.SetTrackChanges(true)
+	if _, err := fieldValue.Set("Name", "Twinkies", "", nil); err != nil {
+		t.Fatal("Set Name failed:", err)
+	}
+	if _, err := fieldValue.Set("Price", 2.5, "", nil); err != nil {
+		t.Fatal("Set Price failed:", err)
+	}
+	if _, err := fieldValue.Delete("ID", "", nil); err != nil {
+		t.Fatal("Delete ID failed:", err)
+	}
+
+	expectedChanges := []Change{
+		{ColumnFieldName: "Name", JsonPath: "$.Name", OldValueFound: true, OldValue: []string{"Oreos"}, NewValue: []string{"Twinkies"}},
+		{ColumnFieldName: "ID", JsonPath: "$.ID", OldValueFound: true, OldValue: []int{1}},
+	}
+	if !reflect.DeepEqual(fieldValue.Changes(), expectedChanges) {
+		t.Fatal(
+			"Changes not equal to expected\n",
+			"res=", gojsoncore.JsonStringifyMust(fieldValue.Changes()), "\n",
+			"expected=", gojsoncore.JsonStringifyMust(expectedChanges),
+		)
+	}
+	if expected := []string{"Name", "ID"}; !reflect.DeepEqual(fieldValue.ChangedColumns(), expected) {
+		t.Fatal("ChangedColumns not equal to expected, res=", fieldValue.ChangedColumns(), "expected=", expected)
+	}
+
+	if err := fieldValue.Rollback(); err != nil {
+		t.Fatal("Rollback failed:", err)
+	}
+	expectedProduct := &testdata.Product{
+		ID:    []int{1},
+		Name:  []string{"Oreos"},
+		Price: []float64{2.5},
+	}
+	if !reflect.DeepEqual(product, expectedProduct) || len(fieldValue.Changes()) != 0 {
+		t.Fatal(
+			"Rollback did not restore product\n",
+			"res=", gojsoncore.JsonStringifyMust(product), "\n",
+			"expected=", gojsoncore.JsonStringifyMust(expectedProduct),
+		)
+	}
+
+	if _, err := fieldValue.Set("Price", 3.0, "", nil); err != nil {
+		t.Fatal("Set Price failed:", err)
+	}
+	fieldValue.Commit()
+	if len(fieldValue.Changes()) != 0 || !reflect.DeepEqual(product.Price, []float64{3}) {
+		t.Fatal("Commit did not keep changes and clear the change log, product=", gojsoncore.JsonStringifyMust(product))
+	}
+}
+
+// TestDatabase_FieldValueChangesRollbackNewValue tests that Rollback removes values that did not exist before a change.
+func TestDatabase_FieldValueChangesRollbackNewValue(t *testing.T) {
+	obj := object.NewObject().WithSourceInterface(gojsoncore.JsonObject{
+		"ID": gojsoncore.JsonArray{1},
+	})
+	columnFields, err := NewGetColumnFields().WithJoinDepth(0).WithTableCollectionName("Product").Get(testdata.ProductMetadataModel(nil))
+	if err != nil {
+		t.Fatal("Get Column Fields failed:", err)
+	}
+
+	fieldValue := NewFieldValue(obj, columnFields).WithTrackChanges(true)
+	if _, err := fieldValue.SetRecord(map[string]any{"Name": "Oreos", "ID": 2}, nil); err != nil {
+		t.Fatal("SetRecord failed:", err)
+	}
+	if len(fieldValue.Changes()) != 2 || fieldValue.Changes()[1].OldValueFound {
+		t.Fatal("Changes not recorded as expected, changes=", gojsoncore.JsonStringifyMust(fieldValue.Changes()))
+	}
+
+	if err := fieldValue.Rollback(); err != nil {
+		t.Fatal("Rollback failed:", err)
+	}
+	if expected := (gojsoncore.JsonObject{"ID": gojsoncore.JsonArray{1}}); !reflect.DeepEqual(obj.GetSourceInterface(), expected) {
+		t.Fatal(
+			"Rollback did not restore source\n",
+			"res=", gojsoncore.JsonStringifyMust(obj.GetSourceInterface()), "\n",
+			"expected=", gojsoncore.JsonStringifyMust(expected),
+		)
+	}
+}
@@ -0,0 +1,225 @@
+package database
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	gojsoncore "github.com/rogonion/go-json/core"
+	"github.com/rogonion/go-json/object"
+	"github.com/rogonion/go-json/path"
+	"github.com/rogonion/go-json/schema"
+	"github.com/rogonion/go-metadatamodel/core"
+	"github.com/rogonion/go-metadatamodel/internal"
+)
+
+/*
+GetRecord retrieves the value of every column in FieldValue.columnFields.
+
+Parameters:
+  - arrayIndexes - Replace core.ArrayPathPlaceholder in path.JSONPath from core.FieldGroupJsonPathKey with a specific set of array indexes. Columns in nested groups that need more indexes use `0`.
+
+Returns a map of core.DatabaseFieldColumnName and value found. Columns whose value is not found are not added.
+*/
+func (n *FieldValue) GetRecord(arrayIndexes []int) (map[string]any, error) {
+	const FunctionName = "GetRecord"
+
+	if n.columnFields == nil {
+		return nil, NewError().WithFunctionName(FunctionName).WithMessage("column fields is nil").WithNestedError(ErrDatabaseFieldValueError)
+	}
+
+	record := make(map[string]any)
+	for _, columnName := range n.columnFields.ColumnFieldsReadOrder {
+		noOfResults, err := n.Get(columnName, "", n.columnArrayIndexes(columnName, arrayIndexes))
+		if err != nil || noOfResults == 0 || gojsoncore.IsNilOrInvalid(n.GetValueFoundReflected()) {
+			continue
+		}
+		record[columnName] = n.GetValueFoundInterface()
+	}
+	return record, nil
+}
+
+/*
+SetRecord inserts or updates the value of every column in record.
+
+Parameters:
+  - record - Map of core.DatabaseFieldColumnName and value to set. Refer to FieldValue.Set.
+  - arrayIndexes - Replace core.ArrayPathPlaceholder in path.JSONPath from core.FieldGroupJsonPathKey with a specific set of array indexes. Columns in nested groups that need more indexes use `0`.
+
+Columns are set in read order. Returns the total number of modifications.
+*/
+func (n *FieldValue) SetRecord(record map[string]any, arrayIndexes []int) (uint64, error) {
+	const FunctionName = "SetRecord"
+
+	if n.columnFields == nil {
+		return 0, NewError().WithFunctionName(FunctionName).WithMessage("column fields is nil").WithNestedError(ErrDatabaseFieldValueError)
+	}
+
+	for columnName := range record {
+		if _, ok := n.columnFields.Fields[columnName]; !ok {
+			return 0, NewError().WithFunctionName(FunctionName).WithMessage(fmt.Sprintf("Field with %s '%s' not found", core.DatabaseFieldColumnName, columnName)).WithNestedError(ErrDatabaseFieldValueError)
+		}
+	}
+
+	noOfModifications := uint64(0)
+	for _, columnName := range n.columnFields.ColumnFieldsReadOrder {
+		value, ok := record[columnName]
+		if !ok {
+			continue
+		}
+		columnNoOfModifications, err := n.Set(columnName, value, "", n.columnArrayIndexes(columnName, arrayIndexes))
+		noOfModifications += columnNoOfModifications
+		if err != nil {
+			return noOfModifications, NewError().WithFunctionName(FunctionName).WithMessage(fmt.Sprintf("set column '%s' failed", columnName)).WithNestedError(err)
+		}
+	}
+	return noOfModifications, nil
+}
+
+/*
+ForEachRecordCallback is called by FieldValue.ForEachRecord with the array indexes of a record and its values. Refer to FieldValue.GetRecord.
+
+Return `true` to terminate the loop.
+*/
+type ForEachRecordCallback func(arrayIndexes []int, record map[string]any) bool
+
+/*
+ForEachRecord loops through every instance of the table collection in FieldValue.sourceData.
+
+For example, every `Profile` of every `Employee` in a slice of employees.
+
+The array indexes passed to forEachRecord can be used with FieldValue.Get, FieldValue.Set, FieldValue.GetRecord, and FieldValue.SetRecord.
+*/
+func (n *FieldValue) ForEachRecord(forEachRecord ForEachRecordCallback) error {
+	const FunctionName = "ForEachRecord"
+
+	if n.columnFields == nil {
+		return NewError().WithFunctionName(FunctionName).WithMessage("column fields is nil").WithNestedError(ErrDatabaseFieldValueError)
+	}
+
+	recordsJsonPath, err := n.recordsJsonPath()
+	if err != nil {
+		return NewError().WithFunctionName(FunctionName).WithMessage("get path to records failed").WithNestedError(err)
+	}
+	if len(recordsJsonPath) == 0 {
+		return nil
+	}
+
+	if !strings.Contains(string(recordsJsonPath), core.ArrayPathPlaceholder) {
+		if gojsoncore.IsNilOrInvalid(n.sourceData.GetSourceReflected()) {
+			return nil
+		}
+		record, err := n.GetRecord(nil)
+		if err != nil {
+			return err
+		}
+		forEachRecord(make([]int, 0), record)
+		return nil
+	}
+
+	// object.Object keeps the state of ForEach so records are read using a separate object.Object with the same source.
+	recordFieldValue := NewFieldValue(object.NewObject().WithSourceReflected(n.sourceData.GetSourceReflected()), n.columnFields)
+
+	var forEachErr error
+	n.sourceData.ForEach(recordsJsonPath, func(jsonPath path.RecursiveDescentSegment, value reflect.Value) bool {
+		if gojsoncore.IsNilOrInvalid(value) {
+			return false
+		}
+
+		arrayIndexes := make([]int, 0)
+		for _, segment := range jsonPath {
+			if segment.IsIndex {
+				arrayIndexes = append(arrayIndexes, segment.Index)
+			}
+		}
+
+		record, err := recordFieldValue.GetRecord(arrayIndexes)
+		if err != nil {
+			forEachErr = err
+			return true
+		}
+		return forEachRecord(arrayIndexes, record)
+	})
+	return forEachErr
+}
+
+/*
+recordsJsonPath returns the path.JSONPath, with core.ArrayPathPlaceholder, to every record of the table collection.
+
+Derived from the column with the least number of core.ArrayPathPlaceholder which belongs to the group that starts the table collection. Returns an empty path.JSONPath if there are no columns.
+*/
+func (n *FieldValue) recordsJsonPath() (path.JSONPath, error) {
+	var recordsJsonPath path.JSONPath
+	for _, columnName := range n.columnFields.ColumnFieldsReadOrder {
+		jsonPathKey, err := core.AsJSONPath(n.columnFields.Fields[columnName][core.FieldGroupJsonPathKey])
+		if err != nil {
+			return "", err
+		}
+		jsonPathToValue, err := core.NewJsonPathToValue().WithReplaceArrayPathPlaceholderWithActualIndexes(false).WithSourceOfValueIsAnArray(n.objectSourceIsAnArray).WithRemoveGroupFields(true).Get(jsonPathKey, nil)
+		if err != nil {
+			return "", err
+		}
+		lastDotIndex := strings.LastIndex(string(jsonPathToValue), path.JsonpathDotNotation)
+		if lastDotIndex < 0 {
+			continue
+		}
+		columnRecordsJsonPath := jsonPathToValue[:lastDotIndex]
+		if len(recordsJsonPath) == 0 || strings.Count(string(columnRecordsJsonPath), core.ArrayPathPlaceholder) < strings.Count(string(recordsJsonPath), core.ArrayPathPlaceholder) {
+			recordsJsonPath = columnRecordsJsonPath
+		}
+	}
+	return recordsJsonPath, nil
+}
+
+// columnArrayIndexes returns arrayIndexes followed by `0` for every other core.ArrayPathPlaceholder in core.FieldGroupJsonPathKey of columnName.
+func (n *FieldValue) columnArrayIndexes(columnName string, arrayIndexes []int) []int {
+	jsonPathKey, _ := core.AsJSONPath(n.columnFields.Fields[columnName][core.FieldGroupJsonPathKey])
+	noOfPlaceholders := strings.Count(string(jsonPathKey), core.ArrayPathPlaceholder)
+	if len(arrayIndexes) >= noOfPlaceholders {
+		return arrayIndexes
+	}
+	columnArrayIndexes := make([]int, noOfPlaceholders)
+	copy(columnArrayIndexes, arrayIndexes)
+	return columnArrayIndexes
+}
+
+/*
+GetAs retrieves the value of columnFieldName and converts it to T using schema.Conversion.
+
+Parameters are the same as FieldValue.Get.
+
+If T is not a slice or array and the value found is a slice or array with one element, the element is converted instead. For example, `GetAs[int]` on a column whose value is `[]any{1}`.
+*/
+func GetAs[T any](fieldValue *FieldValue, columnFieldName string, suffixJsonPath path.JSONPath, arrayIndexes []int) (T, error) {
+	const FunctionName = "GetAs"
+
+	var result T
+	noOfResults, err := fieldValue.Get(columnFieldName, suffixJsonPath, arrayIndexes)
+	if err != nil {
+		return result, err
+	}
+	if noOfResults == 0 || gojsoncore.IsNilOrInvalid(fieldValue.GetValueFoundReflected()) {
+		return result, NewError().WithFunctionName(FunctionName).WithMessage(fmt.Sprintf("value of column '%s' not found", columnFieldName)).WithNestedError(ErrDatabaseFieldValueError)
+	}
+
+	resultType := reflect.TypeOf(&result).Elem()
+	resultSchema, err := internal.SchemaFromType(resultType)
+	if err != nil {
+		return result, NewError().WithFunctionName(FunctionName).WithMessage(fmt.Sprintf("generate schema of '%s' failed", resultType)).WithNestedError(err)
+	}
+
+	valueFound := fieldValue.GetValueFoundReflected()
+	for valueFound.Kind() == reflect.Interface && !valueFound.IsNil() {
+		valueFound = valueFound.Elem()
+	}
+	if resultType.Kind() != reflect.Slice && resultType.Kind() != reflect.Array && resultType.Kind() != reflect.Interface {
+		if (valueFound.Kind() == reflect.Slice || valueFound.Kind() == reflect.Array) && valueFound.Len() == 1 {
+			valueFound = valueFound.Index(0)
+		}
+	}
+
+	if err := schema.NewConversion().Convert(valueFound.Interface(), resultSchema, &result); err != nil {
+		return result, NewError().WithFunctionName(FunctionName).WithMessage(fmt.Sprintf("convert value of column '%s' to '%s' failed", columnFieldName, resultType)).WithNestedError(err)
+	}
+	return result, nil
+}
@@ -0,0 +1,121 @@
+package database
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	gojsoncore "github.com/rogonion/go-json/core"
+	"github.com/rogonion/go-json/object"
+	"github.com/rogonion/go-metadatamodel/testdata"
+)
+
+// TestDatabase_FieldValueForEachRecord tests looping through every Profile of every Employee in a JsonArray.
+func TestDatabase_FieldValueForEachRecord(t *testing.T) {
+	employees := gojsoncore.JsonArray{
+		gojsoncore.JsonObject{
+			"ID": gojsoncore.JsonArray{1},
+			"Profile": gojsoncore.JsonArray{
+				gojsoncore.JsonObject{"Name": gojsoncore.JsonArray{"Alice"}, "Age": gojsoncore.JsonArray{30}},
+				gojsoncore.JsonObject{"Name": gojsoncore.JsonArray{"Bob"}},
+			},
+		},
+		gojsoncore.JsonObject{
+			"ID": gojsoncore.JsonArray{2},
+		},
+		gojsoncore.JsonObject{
+			"ID": gojsoncore.JsonArray{3},
+			"Profile": gojsoncore.JsonArray{
+				gojsoncore.JsonObject{"Name": gojsoncore.JsonArray{"Carol"}, "Age": gojsoncore.JsonArray{41}},
+			},
+		},
+	}
+
+	columnFields, err := NewGetColumnFields().WithJoinDepth(1).WithTableCollectionName("Profile").Get(testdata.EmployeeMetadataModel(nil))
+	if err != nil {
+		t.Fatal("Get Column Fields failed:", err)
+	}
+
+	type forEachRecordResult struct {
+		ArrayIndexes []int
+		Record       map[string]any
+	}
+	res := make([]forEachRecordResult, 0)
+	fieldValue := NewFieldValue(object.NewObject().WithSourceInterface(employees), columnFields)
+	err = fieldValue.ForEachRecord(func(arrayIndexes []int, record map[string]any) bool {
+		res = append(res, forEachRecordResult{ArrayIndexes: arrayIndexes, Record: record})
+		return false
+	})
+	if err != nil {
+		t.Fatal("ForEachRecord failed:", err)
+	}
+
+	expected := []forEachRecordResult{
+		{ArrayIndexes: []int{0, 0}, Record: map[string]any{"Name": gojsoncore.JsonArray{"Alice"}, "Age": gojsoncore.JsonArray{30}}},
+		{ArrayIndexes: []int{0, 1}, Record: map[string]any{"Name": gojsoncore.JsonArray{"Bob"}}},
+		{ArrayIndexes: []int{2, 0}, Record: map[string]any{"Name": gojsoncore.JsonArray{"Carol"}, "Age": gojsoncore.JsonArray{41}}},
+	}
+	if !reflect.DeepEqual(res, expected) {
+		t.Fatal(
+			"ForEachRecord results not equal to expected\n",
+			"res=", gojsoncore.JsonStringifyMust(res), "\n",
+			"expected=", gojsoncore.JsonStringifyMust(expected),
+		)
+	}
+
+	noOfRecords := 0
+	_ = fieldValue.ForEachRecord(func(arrayIndexes []int, record map[string]any) bool {
+		noOfRecords++
+		return true
+	})
+	if noOfRecords != 1 {
+		t.Fatal("ForEachRecord did not terminate after first record, noOfRecords=", noOfRecords)
+	}
+}
+
+// TestDatabase_FieldValueRecord tests SetRecord, GetRecord, and GetAs on the Profile of an Employee struct.
+func TestDatabase_FieldValueRecord(t *testing.T) {
+	employee := &testdata.Employee{
+		ID: []int{1},
+	}
+
+	columnFields, err := NewGetColumnFields().WithJoinDepth(1).WithTableCollectionName("Profile").Get(testdata.EmployeeMetadataModel(nil))
+	if err != nil {
+		t.Fatal("Get Column Fields failed:", err)
+	}
+
+	fieldValue := NewFieldValue(object.NewObject().WithSourceInterface(employee).WithSchema(testdata.EmployeeSchema()), columnFields)
+	noOfModifications, err := fieldValue.SetRecord(map[string]any{"Name": "Alice", "Age": 30}, []int{1})
+	if err != nil || noOfModifications != 2 {
+		t.Fatal("SetRecord failed, noOfModifications=", noOfModifications, "err=", err)
+	}
+	if len(employee.Profile) != 2 || !reflect.DeepEqual(employee.Profile[1].Name, []string{"Alice"}) || !reflect.DeepEqual(employee.Profile[1].Age, []int{30}) {
+		t.Fatal("SetRecord did not set Profile at index 1, employee=", gojsoncore.JsonStringifyMust(employee))
+	}
+
+	if _, err := fieldValue.SetRecord(map[string]any{"Unknown": 1}, nil); !errors.Is(err, ErrDatabaseFieldValueError) {
+		t.Fatal("SetRecord with unknown column expected", ErrDatabaseFieldValueError, "got", err)
+	}
+
+	record, err := fieldValue.GetRecord([]int{1})
+	if err != nil {
+		t.Fatal("GetRecord failed:", err)
+	}
+	if expected := map[string]any{"Name": []string{"Alice"}, "Age": []int{30}}; !reflect.DeepEqual(record, expected) {
+		t.Fatal("GetRecord result not equal to expected\n", "res=", record, "\n", "expected=", expected)
+	}
+
+	age, err := GetAs[float64](fieldValue, "Age", "", []int{1})
+	if err != nil || age != 30 {
+		t.Fatal("GetAs[float64] failed, age=", age, "err=", err)
+	}
+
+	names, err := GetAs[[]any](fieldValue, "Name", "", []int{1})
+	if err != nil || !reflect.DeepEqual(names, []any{"Alice"}) {
+		t.Fatal("GetAs[[]any] failed, names=", names, "err=", err)
+	}
+
+	if _, err := GetAs[int](fieldValue, "Age", "", []int{5}); !errors.Is(err, ErrDatabaseFieldValueError) {
+		t.Fatal("GetAs on missing record expected", ErrDatabaseFieldValueError, "got", err)
+	}
+}
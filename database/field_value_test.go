@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/rogonion/go-json/object"
+	"github.com/rogonion/go-json/path"
 	"github.com/rogonion/go-metadatamodel/testdata"
 )
 
@@ -101,3 +102,62 @@ func TestDatabase_FieldValueOnProduct(t *testing.T) {
 		t.Fatal("Delete Price on Product does not have expected number of modifications")
 	}
 }
+
+// TestDatabase_FieldValueSuffixJsonPath tests that suffixJsonPath is appended to core.FieldGroupJsonPathKey only when it is not empty.
+func TestDatabase_FieldValueSuffixJsonPath(t *testing.T) {
+	product := &testdata.Product{
+		ID:   []int{1},
+		Name: []string{"Twinkies", "Oreos"},
+	}
+
+	obj := object.NewObject().WithSourceInterface(product).WithSchema(testdata.ProductSchema())
+	metadataModel := testdata.ProductMetadataModel(nil)
+	columnFields, err := NewGetColumnFields().WithJoinDepth(0).WithTableCollectionName("Product").Get(metadataModel)
+	if err != nil {
+		t.Fatal("Get Column Fields failed:", err)
+	}
+
+	fieldValue := NewFieldValue(obj, columnFields)
+
+	for suffixJsonPath, expectedJsonPath := range map[path.JSONPath]path.JSONPath{
+		"":    "$.Name",
+		"[1]": "$.Name.[1]",
+	} {
+		jsonPathToValue, err := fieldValue.getJsonPathToValue("Name", suffixJsonPath, nil)
+		if err != nil {
+			t.Fatal("getJsonPathToValue failed:", err)
+		}
+		if jsonPathToValue != expectedJsonPath {
+			t.Fatal(
+				"getJsonPathToValue not equal to expected\n",
+				"suffixJsonPath=", suffixJsonPath, "\n",
+				"res=", jsonPathToValue, "\n",
+				"expected=", expectedJsonPath,
+			)
+		}
+	}
+
+	noOfResults, err := fieldValue.Get("Name", "[1]", nil)
+	if noOfResults != 1 {
+		t.Fatal("Get Name[1] on Product does not have expected number of results", noOfResults, "\n", "err=", err)
+	}
+	if valueFound := fieldValue.GetValueFoundInterface(); !reflect.DeepEqual(valueFound, "Oreos") {
+		t.Fatal(
+			"Value retrieved using Get not equal to res\n",
+			"res=", valueFound, "\n",
+			"expected=", "Oreos",
+		)
+	}
+
+	noOfModifications, err := fieldValue.Delete("Name", "[1]", nil)
+	if noOfModifications != 1 {
+		t.Fatal("Delete Name[1] on Product does not have expected number of modifications", noOfModifications, "\n", "err=", err)
+	}
+	if !reflect.DeepEqual(product.Name, []string{"Twinkies"}) {
+		t.Fatal(
+			"Value deleted using Delete not equal to res\n",
+			"res=", product.Name, "\n",
+			"expected=", []string{"Twinkies"},
+		)
+	}
+}
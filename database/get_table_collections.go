@@ -0,0 +1,189 @@
+package database
+
+import (
+	"fmt"
+	"reflect"
+
+	gojsoncore "github.com/rogonion/go-json/core"
+	"github.com/rogonion/go-json/path"
+	"github.com/rogonion/go-json/schema"
+	"github.com/rogonion/go-metadatamodel/core"
+)
+
+/*
+Get discovers every table collection in metadataModel.
+
+A group starts a new table collection if its core.DatabaseTableCollectionUid, or core.DatabaseTableCollectionName and core.DatabaseJoinDepth, differ from the table collection of its parent group. The root group must have core.DatabaseTableCollectionUid or core.DatabaseTableCollectionName.
+
+Returns the table collections in read order with parents before their children. The first entry is the root of the join tree. Refer to TableCollection.
+*/
+func (n *GetTableCollections) Get(metadataModel any) ([]*TableCollection, error) {
+	const FunctionName = "Get"
+
+	root, err := core.AsJsonObject(metadataModel)
+	if err != nil {
+		return nil, NewError().WithFunctionName(FunctionName).WithMessage("metadata model is not a JsonObject").WithNestedError(ErrDatabaseGetTableCollectionsError)
+	}
+
+	n.tableCollections = make([]*TableCollection, 0)
+	if err := n.walk(root, nil); err != nil {
+		return nil, NewError().WithFunctionName(FunctionName).WithMessage("discover table collections failed").WithData(gojsoncore.JsonObject{"MetadataModel": metadataModel}).WithNestedError(err)
+	}
+
+	return n.tableCollections, nil
+}
+
+func (n *GetTableCollections) walk(group gojsoncore.JsonObject, current *TableCollection) error {
+	uid, _ := group[core.DatabaseTableCollectionUid].(string)
+	name, _ := group[core.DatabaseTableCollectionName].(string)
+	var joinDepth int64
+	if value, ok := group[core.DatabaseJoinDepth]; ok {
+		if err := n.defaultConverter.Convert(value, &schema.DynamicSchemaNode{Type: reflect.TypeOf(int64(0)), Kind: reflect.Int64}, &joinDepth); err != nil {
+			return fmt.Errorf("convert '%s' of '%s' to int64 failed: %w", core.DatabaseJoinDepth, core.GetFieldGroupName(group, ""), err)
+		}
+	}
+
+	if len(uid) > 0 || len(name) > 0 {
+		if current == nil || !current.isSame(uid, name, joinDepth) {
+			gcf := NewGetColumnFields().WithDefaultConverter(n.defaultConverter)
+			if len(uid) > 0 {
+				gcf.SetTableCollectionUID(uid)
+			} else {
+				gcf.SetTableCollectionName(name)
+				gcf.SetJoinDepth(joinDepth)
+			}
+			columnFields, err := gcf.Get(group)
+			if err != nil {
+				return err
+			}
+			if len(name) == 0 {
+				name = uid
+			}
+
+			fieldGroupJsonPathKey, _ := core.AsJSONPath(group[core.FieldGroupJsonPathKey])
+			tableCollection := &TableCollection{
+				UID:                   uid,
+				Name:                  name,
+				JoinDepth:             joinDepth,
+				FieldGroupJsonPathKey: fieldGroupJsonPathKey,
+				Group:                 group,
+				Parent:                current,
+				Children:              make([]*TableCollection, 0),
+				ColumnFields:          columnFields,
+			}
+			if current != nil {
+				current.Children = append(current.Children, tableCollection)
+			}
+			n.tableCollections = append(n.tableCollections, tableCollection)
+			current = tableCollection
+		}
+	} else if current == nil {
+		return fmt.Errorf("root group has no '%s' or '%s': %w", core.DatabaseTableCollectionUid, core.DatabaseTableCollectionName, ErrDatabaseGetTableCollectionsError)
+	}
+
+	groupReadOrderOfFields, err := core.GetGroupReadOrderOfFields(group)
+	if err != nil {
+		return err
+	}
+	groupFields, err := core.GetGroupFields(group)
+	if err != nil {
+		return err
+	}
+	for _, fieldGroupKeySuffix := range groupReadOrderOfFields {
+		if fieldGroup, err := core.AsJsonObject(groupFields[fieldGroupKeySuffix]); err == nil && core.IsFieldAGroup(fieldGroup) {
+			if err := n.walk(fieldGroup, current); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// WithDefaultConverter sets the default converter.
+func (n *GetTableCollections) WithDefaultConverter(value schema.DefaultConverter) *GetTableCollections {
+	n.SetDefaultConverter(value)
+	return n
+}
+
+// SetDefaultConverter sets the default converter.
+func (n *GetTableCollections) SetDefaultConverter(value schema.DefaultConverter) {
+	n.defaultConverter = value
+}
+
+// NewGetTableCollections creates a new GetTableCollections instance with default settings.
+func NewGetTableCollections() *GetTableCollections {
+	n := new(GetTableCollections)
+	n.defaultConverter = schema.NewConversion()
+	return n
+}
+
+/*
+GetTableCollections discovers the join tree of table collections in a metadata model.
+
+Usage:
+ 1. Instantiate using NewGetTableCollections.
+ 2. Discover table collections using GetTableCollections.Get.
+
+Example:
+
+	// Set metadata model
+	var metadataModel gojsoncore.JsonObject
+
+	tableCollections, err := NewGetTableCollections().Get(metadataModel)
+
+	for _, tableCollection := range tableCollections {
+		// tableCollection.ColumnFields, tableCollection.PrimaryKeys(), tableCollection.Parent...
+	}
+*/
+type GetTableCollections struct {
+	tableCollections []*TableCollection
+
+	defaultConverter schema.DefaultConverter
+}
+
+/*
+TableCollection is a group in a metadata model that starts a new table collection, together with its position in the join tree.
+
+The same core.DatabaseTableCollectionName can appear more than once, for example under different core.DatabaseTableCollectionUid.
+*/
+type TableCollection struct {
+	// UID is core.DatabaseTableCollectionUid of the group. Can be empty.
+	UID string
+	// Name is core.DatabaseTableCollectionName of the group. Defaults to UID.
+	Name string
+	// JoinDepth is core.DatabaseJoinDepth of the group.
+	JoinDepth int64
+
+	// FieldGroupJsonPathKey is core.FieldGroupJsonPathKey of the group.
+	FieldGroupJsonPathKey path.JSONPath
+
+	// Group that starts the table collection.
+	Group gojsoncore.JsonObject
+
+	// Parent is the table collection of the nearest parent group with a different table collection. nil for the root.
+	Parent *TableCollection
+	// Children are the table collections whose Parent is this table collection in read order.
+	Children []*TableCollection
+
+	// ColumnFields of the table collection. Refer to GetColumnFields.
+	ColumnFields *ColumnFields
+}
+
+// PrimaryKeys returns core.DatabaseFieldColumnName of fields with core.FieldGroupIsPrimaryKey in read order.
+func (n *TableCollection) PrimaryKeys() []string {
+	primaryKeys := make([]string, 0)
+	for _, columnName := range n.ColumnFields.ColumnFieldsReadOrder {
+		if isPrimaryKey, ok := n.ColumnFields.Fields[columnName][core.FieldGroupIsPrimaryKey].(bool); ok && isPrimaryKey {
+			primaryKeys = append(primaryKeys, columnName)
+		}
+	}
+	return primaryKeys
+}
+
+// isSame checks if a group with uid, name, and joinDepth belongs to n.
+func (n *TableCollection) isSame(uid string, name string, joinDepth int64) bool {
+	if len(uid) > 0 {
+		return uid == n.UID
+	}
+	return name == n.Name && joinDepth == n.JoinDepth
+}
@@ -0,0 +1,146 @@
+package database
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	gojsoncore "github.com/rogonion/go-json/core"
+	"github.com/rogonion/go-json/path"
+	"github.com/rogonion/go-metadatamodel/builder"
+	"github.com/rogonion/go-metadatamodel/core"
+	"github.com/rogonion/go-metadatamodel/internal"
+	"github.com/rogonion/go-metadatamodel/testdata"
+)
+
+func TestDatabase_GetTableCollections(t *testing.T) {
+	for testData := range getTableCollectionsTestData {
+		tableCollections, err := NewGetTableCollections().Get(testData.MetadataModel)
+		if testData.ExpectedErr != nil {
+			if !errors.Is(err, testData.ExpectedErr) {
+				t.Error(testData.TestTitle, "\n", "expected error", testData.ExpectedErr, "got", err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Error(testData.TestTitle, "\n", "Get failed:", err)
+			continue
+		}
+
+		res := make([]tableCollectionSummary, 0, len(tableCollections))
+		for _, tableCollection := range tableCollections {
+			summary := tableCollectionSummary{
+				UID:                   tableCollection.UID,
+				Name:                  tableCollection.Name,
+				JoinDepth:             tableCollection.JoinDepth,
+				FieldGroupJsonPathKey: tableCollection.FieldGroupJsonPathKey,
+				Columns:               tableCollection.ColumnFields.ColumnFieldsReadOrder,
+				PrimaryKeys:           tableCollection.PrimaryKeys(),
+				Children:              make([]string, 0),
+			}
+			if tableCollection.Parent != nil {
+				summary.Parent = tableCollection.Parent.UID
+			}
+			for _, child := range tableCollection.Children {
+				summary.Children = append(summary.Children, child.UID)
+			}
+			res = append(res, summary)
+		}
+
+		if !reflect.DeepEqual(res, testData.Expected) {
+			t.Error(
+				testData.TestTitle, "\n",
+				"expected table collections to be equal to testData.Expected\n",
+				"Expected=", gojsoncore.JsonStringifyMust(testData.Expected), "\n",
+				"res=", gojsoncore.JsonStringifyMust(res),
+			)
+		}
+	}
+}
+
+// tableCollectionSummary is TableCollection with Parent and Children reduced to their UID.
+type tableCollectionSummary struct {
+	UID                   string
+	Name                  string
+	JoinDepth             int64
+	FieldGroupJsonPathKey path.JSONPath
+	Parent                string
+	Children              []string
+	Columns               core.MetadataModelGroupReadOrderOfFields
+	PrimaryKeys           []string
+}
+
+type getTableCollectionsData struct {
+	internal.TestData
+	MetadataModel any
+	Expected      []tableCollectionSummary
+	ExpectedErr   error
+}
+
+func getTableCollectionsTestData(yield func(data *getTableCollectionsData) bool) {
+	testCaseIndex := 1
+	if !yield(&getTableCollectionsData{
+		TestData: internal.TestData{
+			TestTitle: "Employee with nested tables",
+		},
+		MetadataModel: testdata.EmployeeMetadataModel(nil),
+		Expected: []tableCollectionSummary{
+			{UID: "Employee", Name: "Employee", JoinDepth: 0, FieldGroupJsonPathKey: "$", Children: []string{"Profile"}, Columns: core.MetadataModelGroupReadOrderOfFields{"ID", "Skills"}, PrimaryKeys: []string{"ID"}},
+			{UID: "Profile", Name: "Profile", JoinDepth: 1, FieldGroupJsonPathKey: "$.GroupFields[*].Profile", Parent: "Employee", Children: []string{"UserProfile"}, Columns: core.MetadataModelGroupReadOrderOfFields{"Name", "Age"}, PrimaryKeys: []string{"Name"}},
+			{UID: "UserProfile", Name: "UserProfile", JoinDepth: 1, FieldGroupJsonPathKey: "$.GroupFields[*].Profile.GroupFields[*].Address", Parent: "Profile", Children: []string{}, Columns: core.MetadataModelGroupReadOrderOfFields{"Street", "City", "ZipCode"}, PrimaryKeys: []string{}},
+		},
+	}) {
+		return
+	}
+
+	testCaseIndex++
+	orderMetadataModel, _ := builder.NewModel("Order").Table("Order", 0).
+		Field("ID", core.FieldTypeNumber).PrimaryKey().
+		Group("Billing").Table("Address", 1).TableUid("BillingAddress").
+		Field("City", core.FieldTypeText).
+		End().
+		Group("Shipping").Table("Address", 1).TableUid("ShippingAddress").
+		Field("City", core.FieldTypeText).
+		End().
+		Build()
+	if !yield(&getTableCollectionsData{
+		TestData: internal.TestData{
+			TestTitle: "Same table under different UIDs",
+		},
+		MetadataModel: orderMetadataModel,
+		Expected: []tableCollectionSummary{
+			{UID: "Order", Name: "Order", JoinDepth: 0, FieldGroupJsonPathKey: "$", Children: []string{"BillingAddress", "ShippingAddress"}, Columns: core.MetadataModelGroupReadOrderOfFields{"ID"}, PrimaryKeys: []string{"ID"}},
+			{UID: "BillingAddress", Name: "Address", JoinDepth: 1, FieldGroupJsonPathKey: "$.GroupFields[*].Billing", Parent: "Order", Children: []string{}, Columns: core.MetadataModelGroupReadOrderOfFields{"City"}, PrimaryKeys: []string{}},
+			{UID: "ShippingAddress", Name: "Address", JoinDepth: 1, FieldGroupJsonPathKey: "$.GroupFields[*].Shipping", Parent: "Order", Children: []string{}, Columns: core.MetadataModelGroupReadOrderOfFields{"City"}, PrimaryKeys: []string{}},
+		},
+	}) {
+		return
+	}
+
+	testCaseIndex++
+	if !yield(&getTableCollectionsData{
+		TestData: internal.TestData{
+			TestTitle: "Root group without table collection fails",
+		},
+		MetadataModel: gojsoncore.JsonObject{
+			core.FieldGroupJsonPathKey:  "$",
+			core.GroupFields:            gojsoncore.JsonArray{gojsoncore.JsonObject{}},
+			core.GroupReadOrderOfFields: gojsoncore.JsonArray{},
+			core.FieldGroupName:         "NoTable",
+		},
+		ExpectedErr: ErrDatabaseGetTableCollectionsError,
+	}) {
+		return
+	}
+
+	testCaseIndex++
+	if !yield(&getTableCollectionsData{
+		TestData: internal.TestData{
+			TestTitle: "Metadata model that is not a JsonObject fails",
+		},
+		MetadataModel: "not a metadata model",
+		ExpectedErr:   ErrDatabaseGetTableCollectionsError,
+	}) {
+		return
+	}
+}
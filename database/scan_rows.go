@@ -0,0 +1,156 @@
+package database
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	gojsoncore "github.com/rogonion/go-json/core"
+	"github.com/rogonion/go-json/object"
+	"github.com/rogonion/go-json/schema"
+	"github.com/rogonion/go-metadatamodel/core"
+)
+
+/*
+ScanRows appends one record to sourceData for each row in rows.
+
+Parameters:
+  - rows - Result of a database/sql query. Result columns are matched to fields using core.DatabaseFieldColumnName. Columns without a matching field are ignored.
+  - columnFields - Obtain using GetColumnFields.
+  - sourceData - Refer to object.Object. Source must be a slice, for example gojsoncore.JsonArray or []*Employee with a matching schema.
+
+Values are converted into the Go type of core.FieldDataType before being set using FieldValue.Set. Values of core.FieldTypeAny are decoded if they are valid JSON. NULL values are skipped.
+
+Every core.ArrayPathPlaceholder in core.FieldGroupJsonPathKey is replaced with the index of the new record followed by `0` for the nested groups.
+
+Returns number of records appended.
+*/
+func ScanRows(rows *sql.Rows, columnFields *ColumnFields, sourceData *object.Object) (uint64, error) {
+	const FunctionName = "ScanRows"
+
+	if rows == nil {
+		return 0, NewError().WithFunctionName(FunctionName).WithMessage("rows is nil").WithNestedError(ErrDatabaseScanRowsError)
+	}
+
+	if columnFields == nil {
+		return 0, NewError().WithFunctionName(FunctionName).WithMessage("column fields is nil").WithNestedError(ErrDatabaseScanRowsError)
+	}
+
+	if sourceData == nil || sourceData.GetSourceReflected().Kind() != reflect.Slice {
+		return 0, NewError().WithFunctionName(FunctionName).WithMessage("source data must be a slice").WithNestedError(ErrDatabaseScanRowsError)
+	}
+
+	columnNames, err := rows.Columns()
+	if err != nil {
+		return 0, NewError().WithFunctionName(FunctionName).WithMessage("get columns failed").WithNestedError(err)
+	}
+
+	defaultConverter := schema.NewConversion()
+	noOfRecords := uint64(0)
+	for rows.Next() {
+		values := make([]any, len(columnNames))
+		valuesPointers := make([]any, len(columnNames))
+		for i := range values {
+			valuesPointers[i] = &values[i]
+		}
+		if err := rows.Scan(valuesPointers...); err != nil {
+			return noOfRecords, NewError().WithFunctionName(FunctionName).WithMessage("scan row failed").WithNestedError(err)
+		}
+
+		source := sourceData.GetSourceReflected()
+		recordIndex := source.Len()
+		sourceData.SetSourceReflected(reflect.Append(source, newScanRowsRecord(source.Type().Elem())))
+
+		fieldValue := NewFieldValue(sourceData, columnFields)
+		for i, columnName := range columnNames {
+			columnField, ok := columnFields.Fields[columnName]
+			if !ok || values[i] == nil {
+				continue
+			}
+
+			value, err := scanRowsValue(defaultConverter, values[i], columnField)
+			if err != nil {
+				return noOfRecords, NewError().WithFunctionName(FunctionName).WithMessage(fmt.Sprintf("convert value of column '%s' in row %d failed", columnName, recordIndex)).WithNestedError(err)
+			}
+
+			if _, err := fieldValue.Set(columnName, value, "", scanRowsArrayIndexes(columnField, recordIndex)); err != nil {
+				return noOfRecords, NewError().WithFunctionName(FunctionName).WithMessage(fmt.Sprintf("set value of column '%s' in row %d failed", columnName, recordIndex)).WithNestedError(err)
+			}
+		}
+		noOfRecords++
+	}
+
+	if err := rows.Err(); err != nil {
+		return noOfRecords, NewError().WithFunctionName(FunctionName).WithMessage("iterate rows failed").WithNestedError(err)
+	}
+
+	return noOfRecords, nil
+}
+
+// newScanRowsRecord returns an empty record of recordType to append to the source data.
+func newScanRowsRecord(recordType reflect.Type) reflect.Value {
+	switch recordType.Kind() {
+	case reflect.Interface:
+		return reflect.ValueOf(gojsoncore.JsonObject{})
+	case reflect.Pointer:
+		return reflect.New(recordType.Elem())
+	default:
+		return reflect.New(recordType).Elem()
+	}
+}
+
+// scanRowsArrayIndexes returns recordIndex followed by `0` for every other core.ArrayPathPlaceholder in core.FieldGroupJsonPathKey of columnField.
+func scanRowsArrayIndexes(columnField gojsoncore.JsonObject, recordIndex int) []int {
+	jsonPathKey, _ := core.AsJSONPath(columnField[core.FieldGroupJsonPathKey])
+	noOfPlaceholders := strings.Count(string(jsonPathKey), core.ArrayPathPlaceholder)
+	if noOfPlaceholders == 0 {
+		return []int{recordIndex}
+	}
+	arrayIndexes := make([]int, noOfPlaceholders)
+	arrayIndexes[0] = recordIndex
+	return arrayIndexes
+}
+
+// scanRowsValue converts value returned by a database/sql/driver into the Go type of core.FieldDataType of columnField.
+func scanRowsValue(defaultConverter schema.DefaultConverter, value any, columnField gojsoncore.JsonObject) (any, error) {
+	if valueBytes, ok := value.([]byte); ok {
+		value = string(valueBytes)
+	}
+
+	var valueType reflect.Type
+	switch columnField[core.FieldDataType] {
+	case core.FieldTypeText:
+		valueType = reflect.TypeOf("")
+	case core.FieldTypeNumber:
+		valueType = reflect.TypeOf(float64(0))
+	case core.FieldTypeBoolean:
+		// Drivers such as SQLite return booleans as integers.
+		switch valueTyped := value.(type) {
+		case int64:
+			return valueTyped != 0, nil
+		case string:
+			if valueTyped == "0" || valueTyped == "1" {
+				return valueTyped == "1", nil
+			}
+		}
+		valueType = reflect.TypeOf(false)
+	case core.FieldTypeAny:
+		if valueString, ok := value.(string); ok && json.Valid([]byte(valueString)) {
+			var valueDecoded any
+			if err := json.Unmarshal([]byte(valueString), &valueDecoded); err == nil {
+				return valueDecoded, nil
+			}
+		}
+		return value, nil
+	default:
+		return value, nil
+	}
+
+	destination := reflect.New(valueType)
+	if err := defaultConverter.Convert(value, &schema.DynamicSchemaNode{Type: valueType, Kind: valueType.Kind()}, destination.Interface()); err != nil {
+		return nil, err
+	}
+	return destination.Elem().Interface(), nil
+}
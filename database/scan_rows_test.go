@@ -0,0 +1,298 @@
+package database
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"reflect"
+	"testing"
+
+	gojsoncore "github.com/rogonion/go-json/core"
+	"github.com/rogonion/go-json/object"
+	"github.com/rogonion/go-json/schema"
+	"github.com/rogonion/go-metadatamodel/builder"
+	"github.com/rogonion/go-metadatamodel/core"
+	"github.com/rogonion/go-metadatamodel/internal"
+	"github.com/rogonion/go-metadatamodel/testdata"
+)
+
+func TestDatabase_ScanRows(t *testing.T) {
+	for testData := range scanRowsTestData {
+		db, err := sql.Open(scanRowsDriverName, "")
+		if err != nil {
+			t.Fatal("Open fake database failed:", err)
+		}
+		db.Driver().(*scanRowsDriver).rows = testData.Rows
+
+		rows, err := db.Query("SELECT")
+		if err != nil {
+			t.Fatal("Query fake database failed:", err)
+		}
+
+		noOfRecords, err := ScanRows(rows, testData.ColumnFields, testData.SourceData)
+		_ = rows.Close()
+		_ = db.Close()
+
+		if testData.ExpectedOk && err != nil {
+			t.Error(
+				"expected ok=", testData.ExpectedOk, "got error=", err, "\n",
+				"Test Title:", testData.TestTitle, "\n",
+			)
+			var databaseError *core.Error
+			if errors.As(err, &databaseError) {
+				t.Error(
+					"-----Error Details-----", "\n",
+					databaseError.String(), "\n",
+					"-----------------------",
+				)
+			}
+			continue
+		}
+
+		if !testData.ExpectedOk {
+			if err == nil {
+				t.Error(
+					"expected ok=", testData.ExpectedOk, "got error=nil", "\n",
+					"Test Title:", testData.TestTitle, "\n",
+				)
+			}
+			continue
+		}
+
+		if noOfRecords != testData.ExpectedNoOfRecords || !reflect.DeepEqual(testData.SourceData.GetSourceInterface(), testData.Expected) {
+			t.Error(
+				"expected source data to be equal to testData.Expected\n",
+				"Test Title:", testData.TestTitle, "\n",
+				"noOfRecords=", noOfRecords, "\n",
+				"expectedNoOfRecords=", testData.ExpectedNoOfRecords, "\n",
+				"res=", gojsoncore.JsonStringifyMust(testData.SourceData.GetSourceInterface()), "\n",
+				"testData.Expected=", gojsoncore.JsonStringifyMust(testData.Expected),
+			)
+		}
+	}
+}
+
+type scanRowsData struct {
+	internal.TestData
+	Rows                *scanRowsResult
+	ColumnFields        *ColumnFields
+	SourceData          *object.Object
+	ExpectedOk          bool
+	ExpectedNoOfRecords uint64
+	Expected            any
+}
+
+func scanRowsTestData(yield func(data *scanRowsData) bool) {
+	testCaseIndex := 1
+	productColumnFields, _ := NewGetColumnFields().WithTableCollectionUID("Product").Get(testdata.ProductMetadataModel(nil))
+	if !yield(&scanRowsData{
+		TestData: internal.TestData{
+			TestTitle: "Scan Product rows into []*testdata.Product",
+		},
+		Rows: &scanRowsResult{
+			columns: []string{"ID", "Name", "Price", "Unknown"},
+			values: [][]driver.Value{
+				{int64(1), []byte("Twinkies"), float64(2.5), "ignored"},
+				{int64(2), "Oreos", nil, "ignored"},
+			},
+		},
+		ColumnFields: productColumnFields,
+		SourceData: object.NewObject().WithSourceInterface([]*testdata.Product{}).WithSchema(&schema.DynamicSchemaNode{
+			Kind: reflect.Slice,
+			Type: reflect.TypeOf([]*testdata.Product{}),
+			ChildNodesLinearCollectionElementsSchema: &schema.DynamicSchemaNode{
+				Kind:                    reflect.Pointer,
+				Type:                    reflect.TypeOf(&testdata.Product{}),
+				ChildNodesPointerSchema: testdata.ProductSchema(),
+			},
+		}),
+		ExpectedOk:          true,
+		ExpectedNoOfRecords: 2,
+		Expected: []*testdata.Product{
+			{ID: []int{1}, Name: []string{"Twinkies"}, Price: []float64{2.5}},
+			{ID: []int{2}, Name: []string{"Oreos"}},
+		},
+	}) {
+		return
+	}
+
+	testCaseIndex++
+	userProfileColumnFields, _ := NewGetColumnFields().WithTableCollectionUID("UserProfile").Get(testdata.UserProfileMetadataModel(nil))
+	if !yield(&scanRowsData{
+		TestData: internal.TestData{
+			TestTitle: "Scan UserProfile rows with nested Address group into existing JsonArray",
+		},
+		Rows: &scanRowsResult{
+			columns: []string{"Name", "Age", "City"},
+			values: [][]driver.Value{
+				{"Bob", int64(30), "Nairobi"},
+				{nil, nil, nil},
+			},
+		},
+		ColumnFields: userProfileColumnFields,
+		SourceData: object.NewObject().WithSourceInterface(gojsoncore.JsonArray{
+			gojsoncore.JsonObject{"Name": gojsoncore.JsonArray{"Alice"}},
+		}),
+		ExpectedOk:          true,
+		ExpectedNoOfRecords: 2,
+		Expected: gojsoncore.JsonArray{
+			gojsoncore.JsonObject{"Name": gojsoncore.JsonArray{"Alice"}},
+			gojsoncore.JsonObject{
+				"Name":    []any{"Bob"},
+				"Age":     []any{float64(30)},
+				"Address": []any{map[string]any{"City": []any{"Nairobi"}}},
+			},
+			gojsoncore.JsonObject{},
+		},
+	}) {
+		return
+	}
+
+	testCaseIndex++
+	settingsMetadataModel, _ := builder.NewModel("Setting").Table("Setting", 0).
+		Field("ID", core.FieldTypeNumber).PrimaryKey().
+		Field("Enabled", core.FieldTypeBoolean).
+		Field("Value", core.FieldTypeAny).
+		Field("UpdatedOn", core.FieldTypeTimestamp).
+		Build()
+	settingsColumnFields, _ := NewGetColumnFields().WithTableCollectionUID("Setting").Get(settingsMetadataModel)
+	if !yield(&scanRowsData{
+		TestData: internal.TestData{
+			TestTitle: "Scan SQLite style Boolean, Any, and Timestamp values",
+		},
+		Rows: &scanRowsResult{
+			columns: []string{"ID", "Enabled", "Value", "UpdatedOn"},
+			values: [][]driver.Value{
+				{"7", int64(1), []byte(`{"Theme":"dark"}`), "2024-01-02"},
+				{int64(8), int64(0), "plain text", nil},
+			},
+		},
+		ColumnFields:        settingsColumnFields,
+		SourceData:          object.NewObject().WithSourceInterface(gojsoncore.JsonArray{}),
+		ExpectedOk:          true,
+		ExpectedNoOfRecords: 2,
+		Expected: gojsoncore.JsonArray{
+			gojsoncore.JsonObject{
+				"ID":        []any{float64(7)},
+				"Enabled":   []any{true},
+				"Value":     []any{map[string]any{"Theme": "dark"}},
+				"UpdatedOn": []any{"2024-01-02"},
+			},
+			gojsoncore.JsonObject{
+				"ID":      []any{float64(8)},
+				"Enabled": []any{false},
+				"Value":   []any{"plain text"},
+			},
+		},
+	}) {
+		return
+	}
+
+	testCaseIndex++
+	if !yield(&scanRowsData{
+		TestData: internal.TestData{
+			TestTitle: "Source data that is not a slice fails",
+		},
+		Rows: &scanRowsResult{
+			columns: []string{"ID"},
+			values:  [][]driver.Value{{int64(1)}},
+		},
+		ColumnFields: productColumnFields,
+		SourceData:   object.NewObject().WithSourceInterface(&testdata.Product{}).WithSchema(testdata.ProductSchema()),
+		ExpectedOk:   false,
+	}) {
+		return
+	}
+
+	testCaseIndex++
+	if !yield(&scanRowsData{
+		TestData: internal.TestData{
+			TestTitle: "Value that cannot be converted fails",
+		},
+		Rows: &scanRowsResult{
+			columns: []string{"ID"},
+			values:  [][]driver.Value{{"not a number"}},
+		},
+		ColumnFields: productColumnFields,
+		SourceData:   object.NewObject().WithSourceInterface(gojsoncore.JsonArray{}),
+		ExpectedOk:   false,
+	}) {
+		return
+	}
+}
+
+const scanRowsDriverName = "metadatamodel-scan-rows"
+
+func init() {
+	sql.Register(scanRowsDriverName, &scanRowsDriver{})
+}
+
+// scanRowsDriver is a database/sql/driver that returns scanRowsDriver.rows for every query.
+type scanRowsDriver struct {
+	rows *scanRowsResult
+}
+
+func (n *scanRowsDriver) Open(string) (driver.Conn, error) {
+	return &scanRowsConn{driver: n}, nil
+}
+
+type scanRowsConn struct {
+	driver *scanRowsDriver
+}
+
+func (n *scanRowsConn) Prepare(string) (driver.Stmt, error) {
+	return &scanRowsStmt{conn: n}, nil
+}
+
+func (n *scanRowsConn) Close() error {
+	return nil
+}
+
+func (n *scanRowsConn) Begin() (driver.Tx, error) {
+	return nil, errors.New("transactions not supported")
+}
+
+type scanRowsStmt struct {
+	conn *scanRowsConn
+}
+
+func (n *scanRowsStmt) Close() error {
+	return nil
+}
+
+func (n *scanRowsStmt) NumInput() int {
+	return -1
+}
+
+func (n *scanRowsStmt) Exec([]driver.Value) (driver.Result, error) {
+	return nil, errors.New("exec not supported")
+}
+
+func (n *scanRowsStmt) Query([]driver.Value) (driver.Rows, error) {
+	return &scanRowsResult{columns: n.conn.driver.rows.columns, values: n.conn.driver.rows.values}, nil
+}
+
+// scanRowsResult implements driver.Rows.
+type scanRowsResult struct {
+	columns []string
+	values  [][]driver.Value
+	index   int
+}
+
+func (n *scanRowsResult) Columns() []string {
+	return n.columns
+}
+
+func (n *scanRowsResult) Close() error {
+	return nil
+}
+
+func (n *scanRowsResult) Next(dest []driver.Value) error {
+	if n.index >= len(n.values) {
+		return io.EOF
+	}
+	copy(dest, n.values[n.index])
+	n.index++
+	return nil
+}
@@ -0,0 +1,151 @@
+package diff
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/rogonion/go-json/path"
+	"github.com/rogonion/go-metadatamodel/core"
+)
+
+// ChangeType classifies a Change.
+type ChangeType string
+
+const (
+	// ChangeTypeAdded for a field/group only present in the new metadata model.
+	ChangeTypeAdded ChangeType = "Added"
+	// ChangeTypeRemoved for a field/group only present in the old metadata model.
+	ChangeTypeRemoved ChangeType = "Removed"
+	// ChangeTypeMoved for a field/group whose core.FieldGroupJsonPathKey changed e.g. it was moved to a different group.
+	ChangeTypeMoved ChangeType = "Moved"
+	// ChangeTypeReordered for a group whose fields/groups present in both metadata models appear in a different core.GroupReadOrderOfFields order.
+	ChangeTypeReordered ChangeType = "Reordered"
+	// ChangeTypeKindChanged for a field that became a group or vice versa.
+	ChangeTypeKindChanged ChangeType = "KindChanged"
+	// ChangeTypePropertyChanged for a field/group property whose value was added, removed, or modified e.g. core.FieldDataType.
+	ChangeTypePropertyChanged ChangeType = "PropertyChanged"
+)
+
+/*
+Change represents a single difference between two metadata models.
+*/
+type Change struct {
+	Type ChangeType
+
+	// FieldGroupJsonPathKey of the field/group in the new metadata model. Empty for ChangeTypeRemoved.
+	FieldGroupJsonPathKey path.JSONPath
+
+	// OldFieldGroupJsonPathKey of the field/group in the old metadata model. Empty for ChangeTypeAdded.
+	//
+	// Differs from FieldGroupJsonPathKey only if the field/group or one of its parent groups was moved.
+	OldFieldGroupJsonPathKey path.JSONPath
+
+	// Property that changed for ChangeTypePropertyChanged.
+	Property string
+
+	/*
+		OldValue and NewValue depend on Type:
+		  - ChangeTypeAdded - NewValue is the field/group.
+		  - ChangeTypeRemoved - OldValue is the field/group.
+		  - ChangeTypeKindChanged - OldValue and NewValue are the field/group.
+		  - ChangeTypeReordered - OldValue and NewValue are the core.GroupReadOrderOfFields.
+		  - ChangeTypePropertyChanged - OldValue and NewValue are the values of Property. nil if Property is not set.
+	*/
+	OldValue any
+	NewValue any
+
+	// Breaking indicates if source data stored using the old metadata model needs to be migrated to remain valid under the new one.
+	Breaking bool
+}
+
+// String returns a human-readable representation of the Change.
+func (n Change) String() string {
+	breaking := ""
+	if n.Breaking {
+		breaking = " (breaking)"
+	}
+
+	switch n.Type {
+	case ChangeTypeAdded:
+		return fmt.Sprintf("%s: added%s", n.FieldGroupJsonPathKey, breaking)
+	case ChangeTypeRemoved:
+		return fmt.Sprintf("%s: removed%s", n.OldFieldGroupJsonPathKey, breaking)
+	case ChangeTypeMoved:
+		return fmt.Sprintf("%s: moved from %s%s", n.FieldGroupJsonPathKey, n.OldFieldGroupJsonPathKey, breaking)
+	case ChangeTypeReordered:
+		return fmt.Sprintf("%s: reordered from %v to %v%s", n.FieldGroupJsonPathKey, n.OldValue, n.NewValue, breaking)
+	case ChangeTypeKindChanged:
+		if core.IsFieldAGroup(n.NewValue) {
+			return fmt.Sprintf("%s: field became a group%s", n.FieldGroupJsonPathKey, breaking)
+		}
+		return fmt.Sprintf("%s: group became a field%s", n.FieldGroupJsonPathKey, breaking)
+	default:
+		return fmt.Sprintf("%s: '%s' changed from %v to %v%s", n.FieldGroupJsonPathKey, n.Property, n.OldValue, n.NewValue, breaking)
+	}
+}
+
+// Changes is a list of Change in new metadata model read order followed by removed fields/groups in old metadata model read order.
+type Changes []Change
+
+// Filter returns changes whose Change.Type is one of changeTypes.
+func (n Changes) Filter(changeTypes ...ChangeType) Changes {
+	changes := make(Changes, 0)
+	for _, change := range n {
+		for _, changeType := range changeTypes {
+			if change.Type == changeType {
+				changes = append(changes, change)
+				break
+			}
+		}
+	}
+	return changes
+}
+
+// Breaking returns changes whose Change.Breaking is true.
+func (n Changes) Breaking() Changes {
+	changes := make(Changes, 0)
+	for _, change := range n {
+		if change.Breaking {
+			changes = append(changes, change)
+		}
+	}
+	return changes
+}
+
+// fieldGroupEntry is a field/group in a metadataModelIndex.
+type fieldGroupEntry struct {
+	fieldGroup            any
+	fieldGroupJsonPathKey path.JSONPath
+	fieldGroupKeySuffix   string
+	depth                 int
+
+	// parent is core.FieldGroupJsonPathKey of the group containing the field/group. Empty for the root group.
+	parent path.JSONPath
+
+	isGroup bool
+
+	// readOrderOfFields contains core.FieldGroupJsonPathKey of each field/group in core.GroupReadOrderOfFields if isGroup.
+	readOrderOfFields []path.JSONPath
+}
+
+// metadataModelIndex contains every field/group in a metadata model by core.FieldGroupJsonPathKey.
+type metadataModelIndex struct {
+	entries map[path.JSONPath]*fieldGroupEntry
+
+	// readOrder contains core.FieldGroupJsonPathKey of each field/group in depth-first read order.
+	readOrder []path.JSONPath
+}
+
+var (
+	// ErrDiffError default error for diff module.
+	ErrDiffError = errors.New("diff encountered an error")
+
+	// ErrMetadataModelInvalid for when a metadata model is not valid structure-wise.
+	ErrMetadataModelInvalid = errors.New("metadata model not valid")
+)
+
+// NewError creates a new core.Error with the default diff error base.
+func NewError() *core.Error {
+	n := core.NewError().WithDefaultBaseError(ErrDiffError)
+	return n
+}
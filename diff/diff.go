@@ -0,0 +1,391 @@
+package diff
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+
+	gojsoncore "github.com/rogonion/go-json/core"
+	"github.com/rogonion/go-json/path"
+	"github.com/rogonion/go-json/schema"
+	"github.com/rogonion/go-metadatamodel/core"
+)
+
+/*
+Diff compares two versions of a metadata model and reports how newMetadataModel differs from oldMetadataModel.
+
+Fields/groups are matched by core.FieldGroupJsonPathKey. A field/group removed from one location and added in another is reported as ChangeTypeMoved if both have the same key suffix and kind (field or group), and no other removed or added field/group has that key suffix and kind. Fields/groups in a moved group are matched relative to it.
+
+For each matched pair, the following are reported:
+  - ChangeTypeMoved if core.FieldGroupJsonPathKey changed.
+  - ChangeTypeKindChanged if a field became a group or vice versa.
+  - ChangeTypePropertyChanged for each property that differs, in alphabetical order.
+  - ChangeTypeReordered if the pair are groups and the fields/groups they share are in a different order.
+
+Fields/groups that were not matched are reported as ChangeTypeAdded or ChangeTypeRemoved. Fields/groups in an added or removed group are not reported separately.
+
+A change is breaking if source data stored using oldMetadataModel no longer fits newMetadataModel:
+  - ChangeTypeRemoved, ChangeTypeMoved, and ChangeTypeKindChanged.
+  - ChangeTypePropertyChanged of properties that affect how values are stored e.g. core.FieldDataType, core.FieldDatetimeFormat, core.DatabaseFieldColumnName, and core.DatabaseTableCollectionName.
+  - ChangeTypePropertyChanged of core.FieldGroupMaxEntries if the limit was lowered.
+  - ChangeTypePropertyChanged of core.FieldSelectOptions if a value was removed.
+
+Returns an empty Changes if the metadata models are the same.
+*/
+func Diff(oldMetadataModel any, newMetadataModel any) (Changes, error) {
+	const FunctionName = "Diff"
+
+	oldIndex, err := newMetadataModelIndex(oldMetadataModel)
+	if err != nil {
+		return nil, NewError().WithFunctionName(FunctionName).WithMessage("index oldMetadataModel failed").WithNestedError(err)
+	}
+	newIndex, err := newMetadataModelIndex(newMetadataModel)
+	if err != nil {
+		return nil, NewError().WithFunctionName(FunctionName).WithMessage("index newMetadataModel failed").WithNestedError(err)
+	}
+
+	n := &metadataModelDiff{
+		oldIndex: oldIndex,
+		newIndex: newIndex,
+		oldToNew: make(map[path.JSONPath]path.JSONPath),
+		newToOld: make(map[path.JSONPath]path.JSONPath),
+		moved:    make(map[path.JSONPath]bool),
+		changes:  make(Changes, 0),
+	}
+	n.matchFieldGroups()
+
+	for _, newFieldGroupJsonPathKey := range newIndex.readOrder {
+		newEntry := newIndex.entries[newFieldGroupJsonPathKey]
+		oldFieldGroupJsonPathKey, ok := n.newToOld[newFieldGroupJsonPathKey]
+		if !ok {
+			if _, parentMatched := n.newToOld[newEntry.parent]; parentMatched || len(newEntry.parent) == 0 {
+				n.changes = append(n.changes, Change{
+					Type:                  ChangeTypeAdded,
+					FieldGroupJsonPathKey: newFieldGroupJsonPathKey,
+					NewValue:              newEntry.fieldGroup,
+				})
+			}
+			continue
+		}
+		n.compareFieldGroups(oldIndex.entries[oldFieldGroupJsonPathKey], newEntry)
+	}
+
+	for _, oldFieldGroupJsonPathKey := range oldIndex.readOrder {
+		if _, ok := n.oldToNew[oldFieldGroupJsonPathKey]; ok {
+			continue
+		}
+		oldEntry := oldIndex.entries[oldFieldGroupJsonPathKey]
+		if _, parentMatched := n.oldToNew[oldEntry.parent]; parentMatched || len(oldEntry.parent) == 0 {
+			n.changes = append(n.changes, Change{
+				Type:                     ChangeTypeRemoved,
+				OldFieldGroupJsonPathKey: oldFieldGroupJsonPathKey,
+				OldValue:                 oldEntry.fieldGroup,
+				Breaking:                 true,
+			})
+		}
+	}
+
+	return n.changes, nil
+}
+
+type metadataModelDiff struct {
+	oldIndex *metadataModelIndex
+	newIndex *metadataModelIndex
+
+	oldToNew map[path.JSONPath]path.JSONPath
+	newToOld map[path.JSONPath]path.JSONPath
+
+	// moved contains core.FieldGroupJsonPathKey in the new metadata model of fields/groups reported as ChangeTypeMoved.
+	moved map[path.JSONPath]bool
+
+	changes Changes
+}
+
+// matchFieldGroups matches fields/groups with the same core.FieldGroupJsonPathKey then detects moves among the rest, shallowest first.
+func (n *metadataModelDiff) matchFieldGroups() {
+	for _, fieldGroupJsonPathKey := range n.oldIndex.readOrder {
+		if _, ok := n.newIndex.entries[fieldGroupJsonPathKey]; ok {
+			n.match(fieldGroupJsonPathKey, fieldGroupJsonPathKey)
+		}
+	}
+
+	unmatchedOld := n.unmatched(n.oldIndex, n.oldToNew)
+	for _, oldFieldGroupJsonPathKey := range unmatchedOld {
+		if _, ok := n.oldToNew[oldFieldGroupJsonPathKey]; ok {
+			continue
+		}
+		oldEntry := n.oldIndex.entries[oldFieldGroupJsonPathKey]
+
+		candidatesWithSameKind := func(index *metadataModelIndex, matched map[path.JSONPath]path.JSONPath) []path.JSONPath {
+			candidates := make([]path.JSONPath, 0)
+			for _, fieldGroupJsonPathKey := range n.unmatched(index, matched) {
+				entry := index.entries[fieldGroupJsonPathKey]
+				if entry.fieldGroupKeySuffix == oldEntry.fieldGroupKeySuffix && entry.isGroup == oldEntry.isGroup {
+					candidates = append(candidates, fieldGroupJsonPathKey)
+				}
+			}
+			return candidates
+		}
+
+		newCandidates := candidatesWithSameKind(n.newIndex, n.newToOld)
+		if len(newCandidates) != 1 || len(candidatesWithSameKind(n.oldIndex, n.oldToNew)) != 1 {
+			continue
+		}
+
+		newFieldGroupJsonPathKey := newCandidates[0]
+		n.match(oldFieldGroupJsonPathKey, newFieldGroupJsonPathKey)
+		n.moved[newFieldGroupJsonPathKey] = true
+
+		// Fields/groups in a moved group keep their relative position.
+		for _, descendant := range n.oldIndex.readOrder {
+			if _, ok := n.oldToNew[descendant]; ok || !strings.HasPrefix(string(descendant), string(oldFieldGroupJsonPathKey)+path.JsonpathDotNotation) {
+				continue
+			}
+			newDescendant := newFieldGroupJsonPathKey + descendant[len(oldFieldGroupJsonPathKey):]
+			if _, ok := n.newIndex.entries[newDescendant]; !ok {
+				continue
+			}
+			if _, ok := n.newToOld[newDescendant]; !ok {
+				n.match(descendant, newDescendant)
+			}
+		}
+	}
+}
+
+func (n *metadataModelDiff) match(oldFieldGroupJsonPathKey path.JSONPath, newFieldGroupJsonPathKey path.JSONPath) {
+	n.oldToNew[oldFieldGroupJsonPathKey] = newFieldGroupJsonPathKey
+	n.newToOld[newFieldGroupJsonPathKey] = oldFieldGroupJsonPathKey
+}
+
+// unmatched returns core.FieldGroupJsonPathKey of fields/groups in index that are not in matched, shallowest first.
+func (n *metadataModelDiff) unmatched(index *metadataModelIndex, matched map[path.JSONPath]path.JSONPath) []path.JSONPath {
+	unmatched := make([]path.JSONPath, 0)
+	for _, fieldGroupJsonPathKey := range index.readOrder {
+		if _, ok := matched[fieldGroupJsonPathKey]; !ok {
+			unmatched = append(unmatched, fieldGroupJsonPathKey)
+		}
+	}
+	sort.SliceStable(unmatched, func(i, j int) bool {
+		return index.entries[unmatched[i]].depth < index.entries[unmatched[j]].depth
+	})
+	return unmatched
+}
+
+// compareFieldGroups reports the differences between a matched pair of fields/groups.
+func (n *metadataModelDiff) compareFieldGroups(oldEntry *fieldGroupEntry, newEntry *fieldGroupEntry) {
+	if n.moved[newEntry.fieldGroupJsonPathKey] {
+		n.changes = append(n.changes, Change{
+			Type:                     ChangeTypeMoved,
+			FieldGroupJsonPathKey:    newEntry.fieldGroupJsonPathKey,
+			OldFieldGroupJsonPathKey: oldEntry.fieldGroupJsonPathKey,
+			Breaking:                 true,
+		})
+	}
+
+	if oldEntry.isGroup != newEntry.isGroup {
+		n.changes = append(n.changes, Change{
+			Type:                     ChangeTypeKindChanged,
+			FieldGroupJsonPathKey:    newEntry.fieldGroupJsonPathKey,
+			OldFieldGroupJsonPathKey: oldEntry.fieldGroupJsonPathKey,
+			OldValue:                 oldEntry.fieldGroup,
+			NewValue:                 newEntry.fieldGroup,
+			Breaking:                 true,
+		})
+		return
+	}
+
+	oldFieldGroup, _ := core.AsJsonObject(oldEntry.fieldGroup)
+	newFieldGroup, _ := core.AsJsonObject(newEntry.fieldGroup)
+
+	properties := make([]string, 0)
+	for _, fieldGroup := range []gojsoncore.JsonObject{oldFieldGroup, newFieldGroup} {
+		for property := range fieldGroup {
+			switch property {
+			case core.FieldGroupJsonPathKey, core.GroupFields, core.GroupReadOrderOfFields:
+				continue
+			}
+			if !slices.Contains(properties, property) {
+				properties = append(properties, property)
+			}
+		}
+	}
+	sort.Strings(properties)
+
+	for _, property := range properties {
+		oldValue, newValue := oldFieldGroup[property], newFieldGroup[property]
+		if isPropertyValueEqual(oldValue, newValue) {
+			continue
+		}
+		n.changes = append(n.changes, Change{
+			Type:                     ChangeTypePropertyChanged,
+			FieldGroupJsonPathKey:    newEntry.fieldGroupJsonPathKey,
+			OldFieldGroupJsonPathKey: oldEntry.fieldGroupJsonPathKey,
+			Property:                 property,
+			OldValue:                 oldValue,
+			NewValue:                 newValue,
+			Breaking:                 isPropertyChangeBreaking(property, oldValue, newValue),
+		})
+	}
+
+	if !newEntry.isGroup {
+		return
+	}
+
+	oldReadOrder := make([]path.JSONPath, 0)
+	for _, oldFieldGroupJsonPathKey := range oldEntry.readOrderOfFields {
+		if newFieldGroupJsonPathKey, ok := n.oldToNew[oldFieldGroupJsonPathKey]; ok && n.newIndex.entries[newFieldGroupJsonPathKey].parent == newEntry.fieldGroupJsonPathKey {
+			oldReadOrder = append(oldReadOrder, newFieldGroupJsonPathKey)
+		}
+	}
+	newReadOrder := make([]path.JSONPath, 0)
+	for _, newFieldGroupJsonPathKey := range newEntry.readOrderOfFields {
+		if oldFieldGroupJsonPathKey, ok := n.newToOld[newFieldGroupJsonPathKey]; ok && n.oldIndex.entries[oldFieldGroupJsonPathKey].parent == oldEntry.fieldGroupJsonPathKey {
+			newReadOrder = append(newReadOrder, newFieldGroupJsonPathKey)
+		}
+	}
+	if !slices.Equal(oldReadOrder, newReadOrder) {
+		n.changes = append(n.changes, Change{
+			Type:                     ChangeTypeReordered,
+			FieldGroupJsonPathKey:    newEntry.fieldGroupJsonPathKey,
+			OldFieldGroupJsonPathKey: oldEntry.fieldGroupJsonPathKey,
+			OldValue:                 oldFieldGroup[core.GroupReadOrderOfFields],
+			NewValue:                 newFieldGroup[core.GroupReadOrderOfFields],
+		})
+	}
+}
+
+// isPropertyValueEqual compares values by their JSON encoding so that, for example, `1` and `1.0` are equal.
+func isPropertyValueEqual(oldValue any, newValue any) bool {
+	oldJson, oldErr := json.Marshal(oldValue)
+	newJson, newErr := json.Marshal(newValue)
+	if oldErr != nil || newErr != nil {
+		return reflect.DeepEqual(oldValue, newValue)
+	}
+	return string(oldJson) == string(newJson)
+}
+
+// isPropertyChangeBreaking checks if changing property from oldValue to newValue affects source data stored using the old metadata model.
+func isPropertyChangeBreaking(property string, oldValue any, newValue any) bool {
+	switch property {
+	case core.FieldDataType, core.FieldDatetimeFormat, core.FieldGroupIsPrimaryKey,
+		core.FieldCheckboxValueIfTrue, core.FieldCheckboxValueIfFalse, core.FieldCheckboxValuesUseInStorage,
+		core.DatabaseFieldColumnName, core.DatabaseTableCollectionUid, core.DatabaseTableCollectionName, core.DatabaseJoinDepth:
+		return true
+	case core.FieldGroupMaxEntries:
+		oldMaxEntries, newMaxEntries := maxEntries(oldValue), maxEntries(newValue)
+		return newMaxEntries > 0 && (oldMaxEntries <= 0 || newMaxEntries < oldMaxEntries)
+	case core.FieldSelectOptions:
+		newValues := make([]any, 0)
+		if newSelectOptions, err := core.AsJsonArray(newValue); err == nil {
+			for _, selectOption := range newSelectOptions {
+				if selectOption, err := core.AsJsonObject(selectOption); err == nil {
+					newValues = append(newValues, selectOption[core.Value])
+				}
+			}
+		}
+		if oldSelectOptions, err := core.AsJsonArray(oldValue); err == nil {
+			for _, selectOption := range oldSelectOptions {
+				selectOption, err := core.AsJsonObject(selectOption)
+				if err != nil {
+					continue
+				}
+				if !slices.ContainsFunc(newValues, func(newValue any) bool { return isPropertyValueEqual(selectOption[core.Value], newValue) }) {
+					return true
+				}
+			}
+		}
+		return false
+	default:
+		return false
+	}
+}
+
+// maxEntries returns value of core.FieldGroupMaxEntries as an int. Returns `0` i.e. unlimited if value is not set or not valid.
+func maxEntries(value any) int {
+	var res int
+	if value == nil {
+		return res
+	}
+	if err := schema.NewConversion().Convert(value, &schema.DynamicSchemaNode{Type: reflect.TypeOf(0), Kind: reflect.Int}, &res); err != nil {
+		return 0
+	}
+	return res
+}
+
+// newMetadataModelIndex indexes every field/group in metadataModel.
+func newMetadataModelIndex(metadataModel any) (*metadataModelIndex, error) {
+	n := &metadataModelIndex{
+		entries:   make(map[path.JSONPath]*fieldGroupEntry),
+		readOrder: make([]path.JSONPath, 0),
+	}
+
+	if _, err := n.add(metadataModel, path.JSONPath(path.JsonpathKeyRoot), "", "", 0); err != nil {
+		return nil, err
+	}
+	if !n.entries[n.readOrder[0]].isGroup {
+		return nil, fmt.Errorf("root is not a group: %w", ErrMetadataModelInvalid)
+	}
+
+	return n, nil
+}
+
+/*
+add indexes fieldGroup and the fields/groups in it.
+
+core.FieldGroupJsonPathKey of fieldGroup is used if set, otherwise fieldGroupJsonPathKey.
+
+Returns the core.FieldGroupJsonPathKey fieldGroup was indexed with.
+*/
+func (n *metadataModelIndex) add(fieldGroup any, fieldGroupJsonPathKey path.JSONPath, fieldGroupKeySuffix string, parent path.JSONPath, depth int) (path.JSONPath, error) {
+	fieldGroupProperty, err := core.AsJsonObject(fieldGroup)
+	if err != nil {
+		return "", fmt.Errorf("field/group '%s' is not a JsonObject: %w", fieldGroupJsonPathKey, ErrMetadataModelInvalid)
+	}
+	if value, err := core.AsJSONPath(fieldGroupProperty[core.FieldGroupJsonPathKey]); err == nil && len(value) > 0 {
+		fieldGroupJsonPathKey = value
+	}
+	if _, ok := n.entries[fieldGroupJsonPathKey]; ok {
+		return "", fmt.Errorf("'%s' '%s' is not unique: %w", core.FieldGroupJsonPathKey, fieldGroupJsonPathKey, ErrMetadataModelInvalid)
+	}
+
+	entry := &fieldGroupEntry{
+		fieldGroup:            fieldGroup,
+		fieldGroupJsonPathKey: fieldGroupJsonPathKey,
+		fieldGroupKeySuffix:   fieldGroupKeySuffix,
+		parent:                parent,
+		depth:                 depth,
+	}
+	n.entries[fieldGroupJsonPathKey] = entry
+	n.readOrder = append(n.readOrder, fieldGroupJsonPathKey)
+
+	groupReadOrderOfFields, err := core.GetGroupReadOrderOfFields(fieldGroupProperty)
+	if err != nil {
+		return fieldGroupJsonPathKey, nil
+	}
+	entry.isGroup = true
+	if len(groupReadOrderOfFields) == 0 {
+		return fieldGroupJsonPathKey, nil
+	}
+	groupFields, err := core.GetGroupFields(fieldGroupProperty)
+	if err != nil {
+		return "", fmt.Errorf("get '%s' of '%s' failed: %w", core.GroupFields, fieldGroupJsonPathKey, ErrMetadataModelInvalid)
+	}
+
+	entry.readOrderOfFields = make([]path.JSONPath, 0, len(groupReadOrderOfFields))
+	for _, childKeySuffix := range groupReadOrderOfFields {
+		child, ok := groupFields[childKeySuffix]
+		if !ok {
+			return "", fmt.Errorf("'%s' in '%s' of '%s' not found in '%s': %w", childKeySuffix, core.GroupReadOrderOfFields, fieldGroupJsonPathKey, core.GroupFields, ErrMetadataModelInvalid)
+		}
+		childFieldGroupJsonPathKey, err := n.add(child, fieldGroupJsonPathKey+path.JSONPath(core.GroupJsonPathPrefix+childKeySuffix), childKeySuffix, fieldGroupJsonPathKey, depth+1)
+		if err != nil {
+			return "", err
+		}
+		entry.readOrderOfFields = append(entry.readOrderOfFields, childFieldGroupJsonPathKey)
+	}
+
+	return fieldGroupJsonPathKey, nil
+}
@@ -0,0 +1,222 @@
+package diff
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	gojsoncore "github.com/rogonion/go-json/core"
+	"github.com/rogonion/go-metadatamodel/builder"
+	"github.com/rogonion/go-metadatamodel/core"
+	"github.com/rogonion/go-metadatamodel/internal"
+	"github.com/rogonion/go-metadatamodel/testdata"
+)
+
+func TestDiff_Diff(t *testing.T) {
+	for testData := range diffTestData {
+		changes, err := Diff(testData.OldMetadataModel, testData.NewMetadataModel)
+		if testData.ExpectedErr != nil {
+			if !errors.Is(err, testData.ExpectedErr) {
+				t.Error(testData.TestTitle, "\n", "expected error", testData.ExpectedErr, "got", err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Error(testData.TestTitle, "\n", "Diff failed:", err)
+			continue
+		}
+
+		actual := make([]string, 0, len(changes))
+		for _, change := range changes {
+			actual = append(actual, change.String())
+		}
+		if !slices.Equal(actual, testData.Expected) {
+			t.Error(
+				testData.TestTitle, "\n",
+				"expected changes to be equal to testData.Expected\n",
+				"Expected=", gojsoncore.JsonStringifyMust(testData.Expected), "\n",
+				"changes=", gojsoncore.JsonStringifyMust(actual),
+			)
+		}
+
+		if noOfBreaking := len(changes.Breaking()); noOfBreaking != testData.ExpectedNoOfBreaking {
+			t.Error(testData.TestTitle, "\n", "expected no of breaking changes to be", testData.ExpectedNoOfBreaking, "got", noOfBreaking)
+		}
+	}
+}
+
+type diffData struct {
+	internal.TestData
+	OldMetadataModel     any
+	NewMetadataModel     any
+	Expected             []string
+	ExpectedNoOfBreaking int
+	ExpectedErr          error
+}
+
+func diffTestData(yield func(data *diffData) bool) {
+	testCaseIndex := 1
+	if !yield(
+		&diffData{
+			TestData: internal.TestData{
+				TestTitle: "Same metadata model",
+			},
+			OldMetadataModel: testdata.EmployeeMetadataModel(nil),
+			NewMetadataModel: testdata.EmployeeMetadataModel(nil),
+			Expected:         []string{},
+		},
+	) {
+		return
+	}
+
+	testCaseIndex++
+	if !yield(
+		&diffData{
+			TestData: internal.TestData{
+				TestTitle: "Added, removed, reordered, and property changes",
+			},
+			OldMetadataModel: builder.Must(
+				builder.NewModel("User").Table("User", 0).
+					Field("ID", core.FieldTypeNumber).PrimaryKey().MaxEntries(1).
+					Field("Name", core.FieldTypeText).MaxEntries(3).
+					Field("Age", core.FieldTypeText).
+					Field("Nickname", core.FieldTypeText).
+					Build(),
+			),
+			NewMetadataModel: builder.Must(
+				builder.NewModel("User").Table("User", 0).
+					Field("ID", core.FieldTypeNumber).PrimaryKey().MaxEntries(1).
+					Field("Age", core.FieldTypeNumber).Ui(core.FieldUiText).
+					Field("Name", core.FieldTypeText).Name("Full Name").Column("full_name").MaxEntries(5).
+					Field("Email", core.FieldTypeText).
+					Build(),
+			),
+			Expected: []string{
+				`$: reordered from ["ID","Name","Age","Nickname"] to ["ID","Age","Name","Email"]`,
+				"$.GroupFields[*].Age: 'FieldDataType' changed from Text to Number (breaking)",
+				"$.GroupFields[*].Name: 'DatabaseFieldColumnName' changed from Name to full_name (breaking)",
+				"$.GroupFields[*].Name: 'FieldGroupMaxEntries' changed from 3 to 5",
+				"$.GroupFields[*].Name: 'FieldGroupName' changed from Name to Full Name",
+				"$.GroupFields[*].Email: added",
+				"$.GroupFields[*].Nickname: removed (breaking)",
+			},
+			ExpectedNoOfBreaking: 3,
+		},
+	) {
+		return
+	}
+
+	testCaseIndex++
+	if !yield(
+		&diffData{
+			TestData: internal.TestData{
+				TestTitle: "Moved fields and groups",
+			},
+			OldMetadataModel: builder.Must(
+				builder.NewModel("User").
+					Field("City", core.FieldTypeText).
+					Group("Contact").
+					Field("Phone", core.FieldTypeText).
+					Field("Email", core.FieldTypeText).
+					End().
+					Group("Address").
+					Field("Street", core.FieldTypeText).
+					End().
+					Build(),
+			),
+			NewMetadataModel: builder.Must(
+				builder.NewModel("User").
+					Group("Address").
+					Field("Street", core.FieldTypeText).
+					Field("City", core.FieldTypeText).
+					End().
+					Group("Profile").
+					Group("Contact").
+					Field("Phone", core.FieldTypeText).MaxEntries(1).
+					End().
+					End().
+					Build(),
+			),
+			Expected: []string{
+				"$.GroupFields[*].Address.GroupFields[*].City: moved from $.GroupFields[*].City (breaking)",
+				"$.GroupFields[*].Profile: added",
+				"$.GroupFields[*].Profile.GroupFields[*].Contact: moved from $.GroupFields[*].Contact (breaking)",
+				"$.GroupFields[*].Profile.GroupFields[*].Contact.GroupFields[*].Phone: 'FieldGroupMaxEntries' changed from <nil> to 1 (breaking)",
+				"$.GroupFields[*].Contact.GroupFields[*].Email: removed (breaking)",
+			},
+			ExpectedNoOfBreaking: 4,
+		},
+	) {
+		return
+	}
+
+	testCaseIndex++
+	if !yield(
+		&diffData{
+			TestData: internal.TestData{
+				TestTitle: "Field becomes a group",
+			},
+			OldMetadataModel: builder.Must(
+				builder.NewModel("User").
+					Field("Address", core.FieldTypeText).
+					Build(),
+			),
+			NewMetadataModel: builder.Must(
+				builder.NewModel("User").
+					Group("Address").
+					Field("Street", core.FieldTypeText).
+					End().
+					Build(),
+			),
+			Expected: []string{
+				"$.GroupFields[*].Address: field became a group (breaking)",
+				"$.GroupFields[*].Address.GroupFields[*].Street: added",
+			},
+			ExpectedNoOfBreaking: 1,
+		},
+	) {
+		return
+	}
+
+	testCaseIndex++
+	if !yield(
+		&diffData{
+			TestData: internal.TestData{
+				TestTitle: "Select option removed",
+			},
+			OldMetadataModel: builder.Must(
+				builder.NewModel("Order").
+					Field("Status", core.FieldTypeText).SelectOption("Open", "open").SelectOption("Closed", "closed").
+					Field("Priority", core.FieldTypeNumber).SelectOption("Low", 1).
+					Build(),
+			),
+			NewMetadataModel: builder.Must(
+				builder.NewModel("Order").
+					Field("Status", core.FieldTypeText).SelectOption("Open", "open").
+					Field("Priority", core.FieldTypeNumber).SelectOption("Low", 1.0).SelectOption("High", 2).
+					Build(),
+			),
+			Expected: []string{
+				`$.GroupFields[*].Status: 'FieldSelectOptions' changed from [{"Label":"Open","Type":"Text","Value":"open"},{"Label":"Closed","Type":"Text","Value":"closed"}] to [{"Label":"Open","Type":"Text","Value":"open"}] (breaking)`,
+				`$.GroupFields[*].Priority: 'FieldSelectOptions' changed from [{"Label":"Low","Type":"Number","Value":1}] to [{"Label":"Low","Type":"Number","Value":1},{"Label":"High","Type":"Number","Value":2}]`,
+			},
+			ExpectedNoOfBreaking: 1,
+		},
+	) {
+		return
+	}
+
+	testCaseIndex++
+	if !yield(
+		&diffData{
+			TestData: internal.TestData{
+				TestTitle: "Invalid metadata model",
+			},
+			OldMetadataModel: testdata.UserMetadataModel(nil),
+			NewMetadataModel: gojsoncore.JsonObject{core.FieldDataType: core.FieldTypeText},
+			ExpectedErr:      ErrMetadataModelInvalid,
+		},
+	) {
+		return
+	}
+}
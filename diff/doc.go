@@ -0,0 +1,28 @@
+/*
+Package diff compares two versions of a metadata model.
+
+Diff reports the following, keyed by core.FieldGroupJsonPathKey:
+  - Fields/groups added, removed, or moved to a different group.
+  - Groups whose fields/groups were reordered.
+  - Fields that became groups and vice versa.
+  - Changes to individual properties e.g. core.FieldDataType or core.DatabaseFieldColumnName.
+
+Each Change is classified as breaking if source data stored using the old metadata model would need to be migrated.
+
+# Usage
+
+	import (
+		"fmt"
+
+		"github.com/rogonion/go-metadatamodel/diff"
+	)
+
+	changes, err := diff.Diff(oldMetadataModel, newMetadataModel)
+
+	for _, change := range changes.Breaking() {
+		fmt.Println(change)
+	}
+
+	renamedColumns := changes.Filter(diff.ChangeTypePropertyChanged)
+*/
+package diff
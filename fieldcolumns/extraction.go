@@ -300,6 +300,11 @@ func NewColumnFieldsExtraction(metadataModel gojsoncore.JsonObject) *Extraction
 	return n
 }
 
+// NewColumnFieldsExtractionFromModel creates a new Extraction instance for the given typed metadata model converted with core.Model.JsonObject.
+func NewColumnFieldsExtractionFromModel(model *core.Model) *Extraction {
+	return NewColumnFieldsExtraction(model.JsonObject())
+}
+
 // Extraction handles the recursive extraction of fields from a metadata model into a flat ColumnFields structure.
 // It supports handling nested groups, pivoting arrays into columns, and applying add/skip filters.
 type Extraction struct {
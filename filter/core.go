@@ -49,6 +49,7 @@ const (
 	FilterConditionDateTimeFormat   string = "DateTimeFormat"
 	FilterConditionCaseInsensitive  string = "CaseInsensitive"
 	FilterConditionAssumedFieldType string = "AssumedFieldType"
+	FilterConditionExactMatch       string = "ExactMatch"
 )
 
 // Constants for query condition properties.
@@ -104,6 +105,7 @@ func DefaultFilterProcessors() FilterProcessors {
 		FilterConditionBeginsWith:             IsConditionTrue,
 		FilterConditionEndsWith:               IsConditionTrue,
 		FilterConditionContains:               IsConditionTrue,
+		FilterConditionFullTextSearchQuery:    IsFullTextSearchConditionTrue,
 	}
 }
 
@@ -120,6 +122,7 @@ const (
 	FilterConditionBeginsWith             string = "BeginsWith"
 	FilterConditionEndsWith               string = "EndsWith"
 	FilterConditionContains               string = "Contains"
+	FilterConditionFullTextSearchQuery    string = "FullTextSearchQuery"
 )
 
 /*
@@ -139,6 +142,9 @@ var (
 	ErrUnsupportedFilterConditionType = errors.New("unsupported filter condition type")
 
 	ErrFilterConditionPropertyNotFound = errors.New("filter condition property not found")
+
+	// ErrQueryConditionInvalid for when a query condition consumed by DataFilter is not valid structure-wise.
+	ErrQueryConditionInvalid = errors.New("query condition not valid")
 )
 
 // NewError creates a new core.Error with the default filter error base.
@@ -146,3 +152,24 @@ func NewError() *core.Error {
 	n := core.NewError().WithDefaultBaseError(ErrFilterError)
 	return n
 }
+
+/*
+ConditionValues returns FilterConditionValue or FilterConditionValues of filterValue as a list.
+
+Useful for translating filter conditions into queries. FilterConditionValues must be a non-empty JsonArray.
+*/
+func ConditionValues(filterValue gojsoncore.JsonObject) (gojsoncore.JsonArray, error) {
+	const FunctionName = "ConditionValues"
+
+	if value, ok := filterValue[FilterConditionValue]; ok {
+		return gojsoncore.JsonArray{value}, nil
+	}
+	if value, ok := filterValue[FilterConditionValues]; ok {
+		values, err := core.AsJsonArray(value)
+		if err != nil || len(values) == 0 {
+			return nil, NewError().WithFunctionName(FunctionName).WithMessage(fmt.Sprintf("filter condition property '%s' is not a non-empty JsonArray", FilterConditionValues)).WithNestedError(ErrQueryConditionInvalid).WithData(gojsoncore.JsonObject{"FilterValue": filterValue})
+		}
+		return values, nil
+	}
+	return nil, NewError().WithFunctionName(FunctionName).WithMessage(fmt.Sprintf("filter condition property '%s' or '%s' not found", FilterConditionValue, FilterConditionValues)).WithNestedError(ErrQueryConditionInvalid).WithData(gojsoncore.JsonObject{"FilterValue": filterValue})
+}
@@ -85,6 +85,13 @@ Below is an example query condition that is used to filter through data:
 	  ]
 	}
 
+The FullTextSearchQuery condition tokenizes both the query and the values found into words:
+  - Terms in the query are combined using the "LogicalOperator" property which defaults to "And". Text enclosed in double quotes is matched as a phrase.
+  - "ExactMatch" treats the whole query as one phrase.
+  - "CaseInsensitive" defaults to `true`.
+  - Groups must have core.GroupQueryAddFullTextSearchBox set to `true`. Every descendant text field is searched unless core.DatabaseFieldAddDataToFullTextSearchIndex is set to `false`.
+  - Fields with core.DatabaseFieldAddDataToFullTextSearchIndex set to `false` never match.
+
 Example filtering data usage:
 
 	import (
@@ -109,5 +116,11 @@ Example filtering data usage:
 	var err error
 
 	filterExcludeIndexes, err = filterData.Filter(queryCondition, "", "")
+
+Records can then be sorted, made distinct, and paginated in memory using core.DatabaseSortByAsc, core.DatabaseDistinct, core.DatabaseLimit, and core.DatabaseOffset. Refer to DataSort.Sort:
+
+	var indexes []int
+
+	indexes, err = filter.NewDataSort(sourceData, metadataModel).WithExcludeIndexes(filterExcludeIndexes).Sort("", "")
 */
 package filter
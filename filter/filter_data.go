@@ -409,6 +409,18 @@ func NewFilterData(sourceData *object.Object, metadataModel gojsoncore.JsonObjec
 	return n
 }
 
+/*
+NewFilterDataFromModel
+
+Parameters:
+
+  - sourceData - Refer to object.Object.
+  - model - typed data model for sourceData. Converted with core.Model.JsonObject.
+*/
+func NewFilterDataFromModel(sourceData *object.Object, model *core.Model) *DataFilter {
+	return NewFilterData(sourceData, model.JsonObject())
+}
+
 type DataFilter struct {
 	// Use to loop through values using object.ForEach in source. Refer to object.
 	sourceData *object.Object
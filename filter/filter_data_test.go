@@ -28,6 +28,14 @@ func TestFilter_FilterData(t *testing.T) {
 			)
 		}
 
+		if testData.ExpectedErr != nil && !errors.Is(err, testData.ExpectedErr) {
+			t.Error(
+				testData.TestTitle, "\n",
+				"expected err to be", testData.ExpectedErr, "\n",
+				"err=", err,
+			)
+		}
+
 		if err != nil && testData.LogErrorsIfExpectedNotOk {
 			var filterError *core.Error
 			if errors.As(err, &filterError) {
@@ -50,6 +58,7 @@ type filterData struct {
 	RootJsonPathKey      path.JSONPath
 	RootJsonPathToValue  path.JSONPath
 	FilterExcludeIndexes []int
+	ExpectedErr          error
 }
 
 func filterDataTestData(yield func(data *filterData) bool) {
@@ -226,4 +235,133 @@ func filterDataTestData(yield func(data *filterData) bool) {
 		return
 	}
 
+	testCaseIndex++
+	if !yield(
+		&filterData{
+			TestData: internal.TestData{
+				TestTitle: "User Profile Metadata Model - Full Text Search on Name with Exact Match",
+			},
+			Object:        obj,
+			MetadataModel: metadataModel,
+			QueryCondition: gojsoncore.JsonObject{
+				QueryConditionType: QuerySectionTypeFieldGroup,
+				QueryConditionValue: gojsoncore.JsonObject{
+					path.JsonpathKeyRoot + path.JsonpathDotNotation + core.GroupFields + core.ArrayPathPlaceholder + path.JsonpathDotNotation + "Name": gojsoncore.JsonObject{
+						FilterConditionFullTextSearchQuery: gojsoncore.JsonObject{
+							FilterConditionValue:      "user 2",
+							FilterConditionExactMatch: true,
+						},
+					},
+				},
+			},
+			FilterExcludeIndexes: []int{0, 2},
+		},
+	) {
+		return
+	}
+
+	nameNotInFullTextSearchIndexMetadataModel := testdata.UserProfileMetadataModel(nil)
+	nameNotInFullTextSearchIndexMetadataModel[core.GroupFields].(gojsoncore.JsonArray)[0].(gojsoncore.JsonObject)["Name"].(gojsoncore.JsonObject)[core.DatabaseFieldAddDataToFullTextSearchIndex] = false
+
+	testCaseIndex++
+	if !yield(
+		&filterData{
+			TestData: internal.TestData{
+				TestTitle: "User Profile Metadata Model - Full Text Search on Name not added to Full Text Search Index",
+			},
+			Object:        obj,
+			MetadataModel: nameNotInFullTextSearchIndexMetadataModel,
+			QueryCondition: gojsoncore.JsonObject{
+				QueryConditionType: QuerySectionTypeFieldGroup,
+				QueryConditionValue: gojsoncore.JsonObject{
+					path.JsonpathKeyRoot + path.JsonpathDotNotation + core.GroupFields + core.ArrayPathPlaceholder + path.JsonpathDotNotation + "Name": gojsoncore.JsonObject{
+						FilterConditionFullTextSearchQuery: gojsoncore.JsonObject{
+							FilterConditionValue: "user",
+						},
+					},
+				},
+			},
+			FilterExcludeIndexes: []int{0, 1, 2},
+		},
+	) {
+		return
+	}
+
+	testCaseIndex++
+	if !yield(
+		&filterData{
+			TestData: internal.TestData{
+				TestTitle:                "User Profile Metadata Model - Full Text Search on Address without Full Text Search Box",
+				LogErrorsIfExpectedNotOk: true,
+			},
+			Object:        obj,
+			MetadataModel: metadataModel,
+			QueryCondition: gojsoncore.JsonObject{
+				QueryConditionType: QuerySectionTypeFieldGroup,
+				QueryConditionValue: gojsoncore.JsonObject{
+					path.JsonpathKeyRoot + path.JsonpathDotNotation + core.GroupFields + core.ArrayPathPlaceholder + path.JsonpathDotNotation + "Address": gojsoncore.JsonObject{
+						FilterConditionFullTextSearchQuery: gojsoncore.JsonObject{
+							FilterConditionValue: "city 4",
+						},
+					},
+				},
+			},
+			FilterExcludeIndexes: []int{},
+			ExpectedErr:          ErrUnsupportedFilterConditionType,
+		},
+	) {
+		return
+	}
+
+	metadataModel = testdata.UserProfileMetadataModel(nil)
+	metadataModel[core.GroupFields].(gojsoncore.JsonArray)[0].(gojsoncore.JsonObject)["Address"].(gojsoncore.JsonObject)[core.GroupQueryAddFullTextSearchBox] = true
+
+	testCaseIndex++
+	if !yield(
+		&filterData{
+			TestData: internal.TestData{
+				TestTitle: "User Profile Metadata Model - Full Text Search on Address",
+			},
+			Object:        obj,
+			MetadataModel: metadataModel,
+			QueryCondition: gojsoncore.JsonObject{
+				QueryConditionType: QuerySectionTypeFieldGroup,
+				QueryConditionValue: gojsoncore.JsonObject{
+					path.JsonpathKeyRoot + path.JsonpathDotNotation + core.GroupFields + core.ArrayPathPlaceholder + path.JsonpathDotNotation + "Address": gojsoncore.JsonObject{
+						FilterConditionFullTextSearchQuery: gojsoncore.JsonObject{
+							FilterConditionValue: "CITY 4",
+						},
+					},
+				},
+			},
+			FilterExcludeIndexes: []int{0, 1},
+		},
+	) {
+		return
+	}
+
+	testCaseIndex++
+	if !yield(
+		&filterData{
+			TestData: internal.TestData{
+				TestTitle: "User Profile Metadata Model - Full Text Search on Address with Or and Phrase",
+			},
+			Object:        obj,
+			MetadataModel: metadataModel,
+			QueryCondition: gojsoncore.JsonObject{
+				QueryConditionType: QuerySectionTypeFieldGroup,
+				QueryConditionValue: gojsoncore.JsonObject{
+					path.JsonpathKeyRoot + path.JsonpathDotNotation + core.GroupFields + core.ArrayPathPlaceholder + path.JsonpathDotNotation + "Address": gojsoncore.JsonObject{
+						FilterConditionFullTextSearchQuery: gojsoncore.JsonObject{
+							FilterConditionValue:            `"street 1" "city 2"`,
+							QuerySectionTypeLogicalOperator: QuerySectionTypeLogicalOperatorOr,
+						},
+					},
+				},
+			},
+			FilterExcludeIndexes: []int{2},
+		},
+	) {
+		return
+	}
 }
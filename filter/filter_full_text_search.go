@@ -0,0 +1,333 @@
+package filter
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"unicode"
+
+	gojsoncore "github.com/rogonion/go-json/core"
+	"github.com/rogonion/go-json/object"
+	"github.com/rogonion/go-json/path"
+	"github.com/rogonion/go-metadatamodel/core"
+	"github.com/rogonion/go-metadatamodel/iter"
+)
+
+/*
+IsFullTextSearchConditionTrue checks if a FilterConditionFullTextSearchQuery condition is met.
+
+The query in FilterConditionValue (or each query in FilterConditionValues) is tokenized using FullTextSearchTokens.
+  - If FilterConditionExactMatch is `true`, the whole query is treated as a single phrase whose tokens must appear consecutively and in full in one of the values found.
+  - Otherwise, each token is a term that matches any token in the values found that begins with it. Phrases can still be enclosed in double quotes.
+  - Terms are combined using the QuerySectionTypeLogicalOperator property in filterValue. Default is QuerySectionTypeLogicalOperatorAnd.
+  - Case folding is done unless FilterConditionCaseInsensitive is explicitly set to `false`.
+
+If fieldGroupJsonPathKey points to a group, the group must have core.GroupQueryAddFullTextSearchBox set to `true`.
+Every descendant field with core.FieldDataType of core.FieldTypeText is then searched as one document.
+Descendant fields can be excluded by setting core.DatabaseFieldAddDataToFullTextSearchIndex to `false` or included regardless of their data type by setting it to `true`.
+*/
+func IsFullTextSearchConditionTrue(ctx FilterContext, fieldGroupJsonPathKey path.JSONPath, filterCondition string, valueFound reflect.Value, filterValue gojsoncore.JsonObject) (bool, error) {
+	const FunctionName = "IsFullTextSearchConditionTrue"
+
+	if filterCondition != FilterConditionFullTextSearchQuery {
+		if ctx.SilenceErrors() {
+			return false, nil
+		}
+		return false, NewError().WithFunctionName(FunctionName).WithMessage(fmt.Sprintf("Unsupported filter condition '%s'", filterCondition)).WithData(gojsoncore.JsonObject{"FilterValue": filterValue}).WithNestedError(ErrUnsupportedFilterConditionType)
+	}
+
+	if !valueFound.IsValid() {
+		return false, nil
+	}
+
+	caseInsensitive := true
+	if value, ok := filterValue[FilterConditionCaseInsensitive].(bool); ok {
+		caseInsensitive = value
+	}
+
+	exactMatch := false
+	if value, ok := filterValue[FilterConditionExactMatch].(bool); ok {
+		exactMatch = value
+	}
+
+	logicalOperator, err := GetQuerySectionTypeLogicalOperator(filterValue)
+	if err != nil {
+		if ctx.SilenceErrors() {
+			return false, nil
+		}
+		return false, NewError().WithFunctionName(FunctionName).WithMessage("Invalid logical operator").WithData(gojsoncore.JsonObject{"FilterValue": filterValue}).WithNestedError(err)
+	}
+
+	var queries []string
+	if filterConditionValue, ok := filterValue[FilterConditionValue]; ok {
+		if value, ok := filterConditionValue.(string); ok {
+			queries = append(queries, value)
+		} else {
+			if ctx.SilenceErrors() {
+				return false, nil
+			}
+			return false, NewError().WithFunctionName(FunctionName).WithMessage(fmt.Sprintf("filter condition property '%s' is not a string", FilterConditionValue)).WithData(gojsoncore.JsonObject{"FilterValue": filterValue})
+		}
+	} else if filterConditionValues, ok := filterValue[FilterConditionValues]; ok {
+		if value, ok := filterConditionValues.([]any); ok {
+			for _, v := range value {
+				if query, ok := v.(string); ok {
+					queries = append(queries, query)
+				} else {
+					if ctx.SilenceErrors() {
+						return false, nil
+					}
+					return false, NewError().WithFunctionName(FunctionName).WithMessage(fmt.Sprintf("filter condition property '%s' is not a []string", FilterConditionValues)).WithData(gojsoncore.JsonObject{"FilterValue": filterValue})
+				}
+			}
+		} else {
+			if ctx.SilenceErrors() {
+				return false, nil
+			}
+			return false, NewError().WithFunctionName(FunctionName).WithMessage(fmt.Sprintf("filter condition property '%s' is not a []any", FilterConditionValues)).WithData(gojsoncore.JsonObject{"FilterValue": filterValue})
+		}
+	} else {
+		if ctx.SilenceErrors() {
+			return false, nil
+		}
+		return false, NewError().WithFunctionName(FunctionName).WithMessage(fmt.Sprintf("filter condition property '%s' not found", FilterConditionValue)).WithData(gojsoncore.JsonObject{"FilterValue": filterValue}).WithNestedError(ErrFilterConditionPropertyNotFound)
+	}
+
+	fieldGroup, err := ctx.GetFieldGroupByJsonPathKey(fieldGroupJsonPathKey)
+	if err != nil {
+		if ctx.SilenceErrors() {
+			return false, nil
+		}
+		return false, NewError().WithFunctionName(FunctionName).WithMessage("get field/group properties failed").WithData(gojsoncore.JsonObject{"FieldGroupJsonPathKey": fieldGroupJsonPathKey}).WithNestedError(err)
+	}
+
+	document := make([][]string, 0)
+	if core.IsFieldAGroup(fieldGroup) {
+		if value, ok := fieldGroup[core.GroupQueryAddFullTextSearchBox].(bool); !ok || !value {
+			if ctx.SilenceErrors() {
+				return false, nil
+			}
+			return false, NewError().WithFunctionName(FunctionName).WithMessage(fmt.Sprintf("group does not have '%s' set to true", core.GroupQueryAddFullTextSearchBox)).WithData(gojsoncore.JsonObject{"FieldGroupJsonPathKey": fieldGroupJsonPathKey}).WithNestedError(ErrUnsupportedFilterConditionType)
+		}
+
+		if document, err = getGroupFullTextSearchDocument(fieldGroup, fieldGroupJsonPathKey, valueFound, caseInsensitive); err != nil {
+			if ctx.SilenceErrors() {
+				return false, nil
+			}
+			return false, NewError().WithFunctionName(FunctionName).WithMessage("get group full text search document failed").WithData(gojsoncore.JsonObject{"FieldGroupJsonPathKey": fieldGroupJsonPathKey}).WithNestedError(err)
+		}
+	} else if addToIndex, ok := fieldGroup[core.DatabaseFieldAddDataToFullTextSearchIndex].(bool); !ok || addToIndex {
+		appendFullTextSearchDocument(valueFound, true, caseInsensitive, &document)
+	}
+
+	if len(document) == 0 {
+		return false, nil
+	}
+
+	for _, query := range queries {
+		terms := FullTextSearchQueryTerms(query, exactMatch, caseInsensitive)
+		if len(terms) == 0 {
+			continue
+		}
+
+		queryTrue := logicalOperator == QuerySectionTypeLogicalOperatorAnd
+		for _, term := range terms {
+			termTrue := isFullTextSearchTermInDocument(term, document, exactMatch)
+			if logicalOperator == QuerySectionTypeLogicalOperatorOr {
+				if termTrue {
+					queryTrue = true
+					break
+				}
+			} else {
+				if !termTrue {
+					queryTrue = false
+					break
+				}
+			}
+		}
+
+		if queryTrue {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+/*
+FullTextSearchTokens splits text into tokens.
+
+A token is a continuous sequence of letters or digits. All other characters are treated as separators.
+
+If caseInsensitive is `true`, tokens are case folded to lower case.
+*/
+func FullTextSearchTokens(text string, caseInsensitive bool) []string {
+	if caseInsensitive {
+		text = strings.ToLower(text)
+	}
+
+	return strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+/*
+FullTextSearchQueryTerms splits a full text search query into terms.
+
+Each term is a slice of tokens. A term with more than one token is a phrase.
+
+If exactMatch is `true`, the whole query is returned as a single phrase. Otherwise, text enclosed in double quotes is returned as a phrase while every other token is a term on its own.
+*/
+func FullTextSearchQueryTerms(query string, exactMatch bool, caseInsensitive bool) [][]string {
+	terms := make([][]string, 0)
+
+	if exactMatch {
+		if tokens := FullTextSearchTokens(query, caseInsensitive); len(tokens) > 0 {
+			terms = append(terms, tokens)
+		}
+		return terms
+	}
+
+	for index, segment := range strings.Split(query, `"`) {
+		tokens := FullTextSearchTokens(segment, caseInsensitive)
+		if len(tokens) == 0 {
+			continue
+		}
+
+		// Odd segments are enclosed in double quotes.
+		if index%2 == 1 {
+			terms = append(terms, tokens)
+			continue
+		}
+
+		for _, token := range tokens {
+			terms = append(terms, []string{token})
+		}
+	}
+
+	return terms
+}
+
+// isFullTextSearchTermInDocument checks if term appears in any set of tokens in document.
+func isFullTextSearchTermInDocument(term []string, document [][]string, exactMatch bool) bool {
+	for _, tokens := range document {
+		for tokenIndex := 0; tokenIndex+len(term) <= len(tokens); tokenIndex++ {
+			termFound := true
+			for termIndex, termToken := range term {
+				documentToken := tokens[tokenIndex+termIndex]
+				// Phrases and exact matches compare whole tokens. Single terms may match the beginning of a token.
+				if exactMatch || len(term) > 1 {
+					if documentToken != termToken {
+						termFound = false
+						break
+					}
+				} else if !strings.HasPrefix(documentToken, termToken) {
+					termFound = false
+					break
+				}
+			}
+			if termFound {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+/*
+getGroupFullTextSearchDocument collects tokens of every descendant field in group that should be added to the full text search.
+
+groupValue is the value found at groupJsonPathKey.
+*/
+func getGroupFullTextSearchDocument(group gojsoncore.JsonObject, groupJsonPathKey path.JSONPath, groupValue reflect.Value, caseInsensitive bool) ([][]string, error) {
+	const FunctionName = "getGroupFullTextSearchDocument"
+
+	for groupValue.Kind() == reflect.Interface || groupValue.Kind() == reflect.Pointer {
+		if groupValue.IsNil() {
+			return nil, nil
+		}
+		groupValue = groupValue.Elem()
+	}
+	groupValueIsAnArray := groupValue.Kind() == reflect.Slice || groupValue.Kind() == reflect.Array
+
+	document := make([][]string, 0)
+	var forEachError error
+	iter.ForEach(group, func(fieldGroup gojsoncore.JsonObject) (bool, bool) {
+		// iter.ForEach does not propagate termination from nested groups.
+		if forEachError != nil {
+			return true, true
+		}
+
+		if core.IsFieldAGroup(fieldGroup) {
+			return false, false
+		}
+
+		addToIndex, addToIndexOk := fieldGroup[core.DatabaseFieldAddDataToFullTextSearchIndex].(bool)
+		if addToIndexOk && !addToIndex {
+			return false, false
+		}
+		if fieldDataType, ok := fieldGroup[core.FieldDataType].(string); !addToIndexOk && (!ok || fieldDataType != core.FieldTypeText) {
+			return false, false
+		}
+
+		fieldJsonPathKey, err := core.AsJSONPath(fieldGroup[core.FieldGroupJsonPathKey])
+		if err != nil {
+			forEachError = NewError().WithFunctionName(FunctionName).WithMessage("get field FieldGroupJsonPathKey failed").WithData(gojsoncore.JsonObject{"FieldGroup": fieldGroup}).WithNestedError(err)
+			return true, true
+		}
+		if !strings.HasPrefix(string(fieldJsonPathKey), string(groupJsonPathKey)) {
+			forEachError = NewError().WithFunctionName(FunctionName).WithMessage("field FieldGroupJsonPathKey does not begin with group FieldGroupJsonPathKey").WithData(gojsoncore.JsonObject{"FieldJsonPathKey": fieldJsonPathKey, "GroupJsonPathKey": groupJsonPathKey})
+			return true, true
+		}
+
+		relativeJsonPathKey := path.JSONPath(path.JsonpathKeyRoot + strings.TrimPrefix(string(fieldJsonPathKey), string(groupJsonPathKey)))
+		jsonPathToValue, err := core.NewJsonPathToValue().WithSourceOfValueIsAnArray(groupValueIsAnArray).WithReplaceArrayPathPlaceholderWithActualIndexes(false).Get(relativeJsonPathKey, nil)
+		if err != nil {
+			forEachError = NewError().WithFunctionName(FunctionName).WithMessage("get field json path to value failed").WithData(gojsoncore.JsonObject{"RelativeJsonPathKey": relativeJsonPathKey}).WithNestedError(err)
+			return true, true
+		}
+
+		object.NewObject().WithSourceReflected(groupValue).ForEach(jsonPathToValue, func(_ path.RecursiveDescentSegment, value reflect.Value) bool {
+			appendFullTextSearchDocument(value, addToIndexOk && addToIndex, caseInsensitive, &document)
+			return false
+		})
+
+		return false, false
+	})
+
+	return document, forEachError
+}
+
+/*
+appendFullTextSearchDocument appends the tokens of each string in value to document.
+
+Values that are not strings are only added if includeNonText is `true`.
+*/
+func appendFullTextSearchDocument(value reflect.Value, includeNonText bool, caseInsensitive bool, document *[][]string) {
+	if !value.IsValid() {
+		return
+	}
+
+	switch value.Kind() {
+	case reflect.Interface, reflect.Pointer:
+		if !value.IsNil() {
+			appendFullTextSearchDocument(value.Elem(), includeNonText, caseInsensitive, document)
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < value.Len(); i++ {
+			appendFullTextSearchDocument(value.Index(i), includeNonText, caseInsensitive, document)
+		}
+	case reflect.String:
+		if tokens := FullTextSearchTokens(value.String(), caseInsensitive); len(tokens) > 0 {
+			*document = append(*document, tokens)
+		}
+	case reflect.Map, reflect.Struct, reflect.Chan, reflect.Func, reflect.UnsafePointer:
+		return
+	default:
+		if includeNonText {
+			if tokens := FullTextSearchTokens(fmt.Sprint(value.Interface()), caseInsensitive); len(tokens) > 0 {
+				*document = append(*document, tokens)
+			}
+		}
+	}
+}
@@ -0,0 +1,372 @@
+package filter
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+
+	gojsoncore "github.com/rogonion/go-json/core"
+	"github.com/rogonion/go-json/object"
+	"github.com/rogonion/go-json/path"
+	"github.com/rogonion/go-json/schema"
+	"github.com/rogonion/go-metadatamodel/core"
+	"github.com/rogonion/go-metadatamodel/iter"
+)
+
+/*
+Sort applies core.DatabaseSortByAsc, core.DatabaseDistinct, core.DatabaseLimit, and core.DatabaseOffset to source data in memory, in that order.
+
+  - Records are sorted by the fields in the root group with core.DatabaseSortByAsc in read order. `true` sorts in ascending order and `false` in descending order. The sort is stable.
+  - Values are compared according to core.FieldDataType. The first value of a field in a record is used. Records without a value, or whose value cannot be converted, come last.
+  - If core.DatabaseDistinct of the root group is `true`, only the first of records that are equal is kept. Otherwise, if fields in the root group have core.DatabaseDistinct set to `true`, only the first of records whose values for those fields are equal is kept.
+  - core.DatabaseOffset and core.DatabaseLimit of the root group are applied last.
+
+Parameters:
+  - rootJsonPathKey - Set sub-set of metadata model with DataSort.metadataModel as root context.
+  - rootJsonPathToValue - Path to data in DataSort.sourceData that will act as root context.
+
+Returns:
+ 1. Array of indexes of records in the resulting order.
+ 2. An error if the root value is not a slice or array or properties in the metadata model are not valid.
+*/
+func (n *DataSort) Sort(rootJsonPathKey path.JSONPath, rootJsonPathToValue path.JSONPath) ([]int, error) {
+	const FunctionName = "Sort"
+
+	if len(rootJsonPathKey) == 0 {
+		rootJsonPathKey = path.JSONPath(path.JsonpathKeyRoot)
+	}
+	if len(rootJsonPathToValue) == 0 {
+		rootJsonPathToValue = path.JSONPath(path.JsonpathKeyRoot)
+	}
+
+	rootGroup := n.metadataModel
+	if rootJsonPathKey != path.JSONPath(path.JsonpathKeyRoot) {
+		jsonPathToValue, err := core.NewJsonPathToValue().WithRemoveGroupFields(false).Get(rootJsonPathKey, nil)
+		if err != nil {
+			return nil, NewError().WithFunctionName(FunctionName).WithMessage("get root group json path to value failed").WithNestedError(err)
+		}
+		metadataModelObject := object.NewObject().WithSourceInterface(n.metadataModel)
+		if noOfResults, err := metadataModelObject.Get(jsonPathToValue); noOfResults == 0 {
+			return nil, NewError().WithFunctionName(FunctionName).WithMessage("get root group failed").WithNestedError(err)
+		}
+		value, err := core.AsJsonObject(metadataModelObject.GetValueFoundInterface())
+		if err != nil {
+			return nil, NewError().WithFunctionName(FunctionName).WithMessage("root group not JsonObject").WithNestedError(err)
+		}
+		rootGroup = value
+	}
+
+	if noOfResults, err := n.sourceData.Get(rootJsonPathToValue); noOfResults == 0 {
+		return nil, NewError().WithFunctionName(FunctionName).WithMessage("get root value yielded 0 results").WithNestedError(err)
+	}
+	rootValue := n.sourceData.GetValueFoundReflected()
+	if rootValue.Kind() != reflect.Slice && rootValue.Kind() != reflect.Array {
+		return nil, NewError().WithFunctionName(FunctionName).WithMessage("root value should be slice or array")
+	}
+
+	sortFields := make([]gojsoncore.JsonObject, 0)
+	distinctFields := make([]gojsoncore.JsonObject, 0)
+	iter.ForEach(rootGroup, func(fieldGroup gojsoncore.JsonObject) (bool, bool) {
+		if core.IsFieldAGroup(fieldGroup) {
+			return false, true
+		}
+		if _, ok := fieldGroup[core.DatabaseSortByAsc].(bool); ok {
+			sortFields = append(sortFields, fieldGroup)
+		}
+		if value, ok := fieldGroup[core.DatabaseDistinct].(bool); ok && value {
+			distinctFields = append(distinctFields, fieldGroup)
+		}
+		return false, false
+	})
+	groupDistinct, _ := rootGroup[core.DatabaseDistinct].(bool)
+
+	records := make([]*sortRecord, 0, rootValue.Len())
+	for i := 0; i < rootValue.Len(); i++ {
+		if slices.Contains(n.excludeIndexes, i) {
+			continue
+		}
+
+		record := &sortRecord{index: i, keys: make([]any, len(sortFields))}
+		recordValue := rootValue.Index(i)
+		for j, field := range sortFields {
+			values, err := fieldValues(recordValue, field, rootJsonPathKey)
+			if err != nil {
+				return nil, NewError().WithFunctionName(FunctionName).WithMessage("get field values failed").WithNestedError(err)
+			}
+			if len(values) > 0 {
+				record.keys[j] = sortKey(values[0], field)
+			}
+		}
+
+		if groupDistinct {
+			record.distinctKey = distinctKey(recordValue.Interface())
+		} else if len(distinctFields) > 0 {
+			distinctValues := make([]any, len(distinctFields))
+			for j, field := range distinctFields {
+				values, err := fieldValues(recordValue, field, rootJsonPathKey)
+				if err != nil {
+					return nil, NewError().WithFunctionName(FunctionName).WithMessage("get field values failed").WithNestedError(err)
+				}
+				distinctValues[j] = values
+			}
+			record.distinctKey = distinctKey(distinctValues)
+		}
+
+		records = append(records, record)
+	}
+
+	slices.SortStableFunc(records, func(a *sortRecord, b *sortRecord) int {
+		for j, field := range sortFields {
+			if c := compareSortKeys(a.keys[j], b.keys[j], field[core.DatabaseSortByAsc].(bool)); c != 0 {
+				return c
+			}
+		}
+		return 0
+	})
+
+	indexes := make([]int, 0, len(records))
+	distinctKeys := make(map[string]bool)
+	for _, record := range records {
+		if groupDistinct || len(distinctFields) > 0 {
+			if distinctKeys[record.distinctKey] {
+				continue
+			}
+			distinctKeys[record.distinctKey] = true
+		}
+		indexes = append(indexes, record.index)
+	}
+
+	offset, err := groupPropertyInt(rootGroup, core.DatabaseOffset)
+	if err != nil {
+		return nil, NewError().WithFunctionName(FunctionName).WithMessage("get offset failed").WithNestedError(err)
+	}
+	if offset != nil {
+		indexes = indexes[min(*offset, len(indexes)):]
+	}
+
+	limit, err := groupPropertyInt(rootGroup, core.DatabaseLimit)
+	if err != nil {
+		return nil, NewError().WithFunctionName(FunctionName).WithMessage("get limit failed").WithNestedError(err)
+	}
+	if limit != nil && *limit < len(indexes) {
+		indexes = indexes[:*limit]
+	}
+
+	return indexes, nil
+}
+
+// sortRecord is a record in the root value being sorted.
+type sortRecord struct {
+	index       int
+	keys        []any
+	distinctKey string
+}
+
+// fieldValues returns every value of field in record. Array/slice values are expanded into their elements.
+func fieldValues(record reflect.Value, field gojsoncore.JsonObject, rootJsonPathKey path.JSONPath) ([]any, error) {
+	fieldGroupJsonPathKey, err := core.AsJSONPath(field[core.FieldGroupJsonPathKey])
+	if err != nil {
+		return nil, err
+	}
+
+	currentJsonPathKey := path.JSONPath(strings.Replace(string(fieldGroupJsonPathKey), string(rootJsonPathKey), path.JsonpathKeyRoot, 1))
+	jsonPathToValue, err := core.NewJsonPathToValue().WithReplaceArrayPathPlaceholderWithActualIndexes(false).Get(currentJsonPathKey, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	values := make([]any, 0)
+	object.NewObject().WithSourceReflected(record).ForEach(jsonPathToValue, func(_ path.RecursiveDescentSegment, value reflect.Value) bool {
+		for value.IsValid() && (value.Kind() == reflect.Pointer || value.Kind() == reflect.Interface) {
+			value = value.Elem()
+		}
+		if !value.IsValid() {
+			return false
+		}
+		if value.Kind() == reflect.Slice || value.Kind() == reflect.Array {
+			for i := 0; i < value.Len(); i++ {
+				if element := value.Index(i); element.IsValid() && !(element.Kind() == reflect.Pointer && element.IsNil()) {
+					values = append(values, element.Interface())
+				}
+			}
+		} else {
+			values = append(values, value.Interface())
+		}
+		return false
+	})
+	return values, nil
+}
+
+// sortKey converts value to a comparable value according to core.FieldDataType of field. Returns nil if value cannot be converted.
+func sortKey(value any, field gojsoncore.JsonObject) any {
+	if v := reflect.ValueOf(value); v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return nil
+		}
+		value = v.Elem().Interface()
+	}
+
+	switch field[core.FieldDataType] {
+	case core.FieldTypeNumber:
+		var valueFloat float64
+		if err := schema.NewConversion().Convert(value, &schema.DynamicSchemaNode{Type: reflect.TypeOf(0.0), Kind: reflect.Float64}, &valueFloat); err != nil {
+			return nil
+		}
+		return valueFloat
+	case core.FieldTypeText:
+		if valueString, ok := value.(string); ok {
+			return valueString
+		}
+		return fmt.Sprint(value)
+	case core.FieldTypeBoolean:
+		if valueBool, ok := value.(bool); ok {
+			return valueBool
+		}
+		if valueIfTrue, ok := field[core.FieldCheckboxValueIfTrue]; ok {
+			return reflect.DeepEqual(value, valueIfTrue)
+		}
+		return nil
+	case core.FieldTypeTimestamp:
+		switch v := value.(type) {
+		case time.Time:
+			return v
+		case string:
+			if valueTime, err := time.Parse(time.RFC3339Nano, v); err == nil {
+				return valueTime
+			}
+			if layout, ok := core.FieldDatetimeFormatLayout(fmt.Sprint(field[core.FieldDatetimeFormat])); ok {
+				if valueTime, err := time.Parse(layout, v); err == nil {
+					return valueTime
+				}
+			}
+		}
+		return nil
+	default:
+		return distinctKey(value)
+	}
+}
+
+// compareSortKeys compares sort keys of the same core.FieldDataType. nil keys come last regardless of sortByAsc.
+func compareSortKeys(a any, b any, sortByAsc bool) int {
+	if a == nil || b == nil {
+		switch {
+		case a == nil && b == nil:
+			return 0
+		case a == nil:
+			return 1
+		default:
+			return -1
+		}
+	}
+
+	var c int
+	switch a := a.(type) {
+	case float64:
+		c = cmp.Compare(a, b.(float64))
+	case string:
+		c = strings.Compare(a, b.(string))
+	case bool:
+		switch {
+		case a == b.(bool):
+			c = 0
+		case !a:
+			c = -1
+		default:
+			c = 1
+		}
+	case time.Time:
+		c = a.Compare(b.(time.Time))
+	}
+
+	if !sortByAsc {
+		return -c
+	}
+	return c
+}
+
+// distinctKey returns a string that is equal for equal values.
+func distinctKey(value any) string {
+	if jsonData, err := json.Marshal(value); err == nil {
+		return string(jsonData)
+	}
+	return fmt.Sprintf("%#v", value)
+}
+
+// groupPropertyInt returns group[key] as a non-negative int or nil if not set.
+func groupPropertyInt(group gojsoncore.JsonObject, key string) (*int, error) {
+	value, ok := group[key]
+	if !ok || value == nil {
+		return nil, nil
+	}
+
+	var valueInt int
+	if err := schema.NewConversion().Convert(value, &schema.DynamicSchemaNode{Type: reflect.TypeOf(0), Kind: reflect.Int}, &valueInt); err != nil {
+		return nil, err
+	}
+	if valueInt < 0 {
+		return nil, fmt.Errorf("'%s' is negative: %d", key, valueInt)
+	}
+	return &valueInt, nil
+}
+
+// WithMetadataModel sets the metadata model and returns the DataSort.
+func (n *DataSort) WithMetadataModel(value gojsoncore.JsonObject) *DataSort {
+	n.SetMetadataModel(value)
+	return n
+}
+
+// SetMetadataModel sets the metadata model.
+func (n *DataSort) SetMetadataModel(value gojsoncore.JsonObject) {
+	n.metadataModel = value
+}
+
+// WithSourceData sets the source data and returns the DataSort.
+func (n *DataSort) WithSourceData(value *object.Object) *DataSort {
+	n.SetSourceData(value)
+	return n
+}
+
+// SetSourceData sets the source data.
+func (n *DataSort) SetSourceData(value *object.Object) {
+	n.sourceData = value
+}
+
+// WithExcludeIndexes sets indexes of records to leave out e.g. from DataFilter.Filter and returns the DataSort.
+func (n *DataSort) WithExcludeIndexes(value []int) *DataSort {
+	n.SetExcludeIndexes(value)
+	return n
+}
+
+// SetExcludeIndexes sets indexes of records to leave out e.g. from DataFilter.Filter.
+func (n *DataSort) SetExcludeIndexes(value []int) {
+	n.excludeIndexes = value
+}
+
+/*
+NewDataSort
+
+Parameters:
+
+  - sourceData - Refer to object.Object.
+  - metadataModel - data model for sourceData.
+*/
+func NewDataSort(sourceData *object.Object, metadataModel gojsoncore.JsonObject) *DataSort {
+	n := new(DataSort)
+	n.SetSourceData(sourceData)
+	n.SetMetadataModel(metadataModel)
+	return n
+}
+
+type DataSort struct {
+	// Use to loop through records in source. Refer to object.
+	sourceData *object.Object
+
+	metadataModel gojsoncore.JsonObject
+
+	// Indexes of records in the root value to leave out.
+	excludeIndexes []int
+}
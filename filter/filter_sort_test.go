@@ -0,0 +1,214 @@
+package filter
+
+import (
+	"reflect"
+	"testing"
+
+	gojsoncore "github.com/rogonion/go-json/core"
+	"github.com/rogonion/go-json/object"
+	"github.com/rogonion/go-json/path"
+	"github.com/rogonion/go-metadatamodel/builder"
+	"github.com/rogonion/go-metadatamodel/core"
+	"github.com/rogonion/go-metadatamodel/internal"
+	"github.com/rogonion/go-metadatamodel/testdata"
+)
+
+func TestFilter_SortData(t *testing.T) {
+	for testData := range sortDataTestData {
+		res, err := NewDataSort(testData.Object, testData.MetadataModel).WithExcludeIndexes(testData.ExcludeIndexes).Sort(testData.RootJsonPathKey, testData.RootJsonPathToValue)
+		if testData.ExpectErr {
+			if err == nil {
+				t.Error(testData.TestTitle, "\n", "expected error, got indexes", res)
+			}
+			continue
+		}
+		if err != nil {
+			t.Error(testData.TestTitle, "\n", "Sort failed:", err)
+			continue
+		}
+
+		if !reflect.DeepEqual(res, testData.Expected) {
+			t.Error(
+				testData.TestTitle, "\n",
+				"expected res to be equal to testData.Expected\n",
+				"Expected=", gojsoncore.JsonStringifyMust(testData.Expected), "\n",
+				"res=", gojsoncore.JsonStringifyMust(res),
+			)
+		}
+	}
+}
+
+type sortData struct {
+	internal.TestData
+	Object              *object.Object
+	MetadataModel       gojsoncore.JsonObject
+	ExcludeIndexes      []int
+	RootJsonPathKey     path.JSONPath
+	RootJsonPathToValue path.JSONPath
+	Expected            []int
+	ExpectErr           bool
+}
+
+func sortDataTestData(yield func(data *sortData) bool) {
+	products := []*testdata.Product{
+		{ID: []int{0}, Name: []string{"Cake"}, Price: []float64{5}},
+		{ID: []int{1}, Name: []string{"Bread"}},
+		{ID: []int{2}, Name: []string{"Apple"}, Price: []float64{5}},
+		{ID: []int{3}, Name: []string{"Cake"}, Price: []float64{12.5}},
+		{ID: []int{4}, Name: []string{"Apple"}, Price: []float64{1}},
+	}
+
+	testCaseIndex := 1
+	if !yield(
+		&sortData{
+			TestData: internal.TestData{
+				TestTitle: "Sort by multiple fields with missing values last",
+			},
+			Object: object.NewObject().WithSourceInterface(products),
+			MetadataModel: builder.Must(
+				builder.NewModel("Product").
+					Field("ID", core.FieldTypeNumber).
+					Field("Name", core.FieldTypeText).Property(core.DatabaseSortByAsc, true).
+					Field("Price", core.FieldTypeNumber).Property(core.DatabaseSortByAsc, false).
+					Build(),
+			),
+			Expected: []int{2, 4, 1, 3, 0},
+		},
+	) {
+		return
+	}
+
+	testCaseIndex++
+	if !yield(
+		&sortData{
+			TestData: internal.TestData{
+				TestTitle: "Sort is stable and typed by field data type",
+			},
+			Object: object.NewObject().WithSourceInterface(products),
+			MetadataModel: builder.Must(
+				builder.NewModel("Product").
+					Field("Price", core.FieldTypeNumber).Property(core.DatabaseSortByAsc, false).
+					Build(),
+			),
+			Expected: []int{3, 0, 2, 4, 1},
+		},
+	) {
+		return
+	}
+
+	testCaseIndex++
+	if !yield(
+		&sortData{
+			TestData: internal.TestData{
+				TestTitle: "Distinct field with offset, limit, and exclude indexes",
+			},
+			Object: object.NewObject().WithSourceInterface(products),
+			MetadataModel: builder.Must(
+				builder.NewModel("Product").Property(core.DatabaseOffset, 1).Property(core.DatabaseLimit, 2).
+					Field("ID", core.FieldTypeNumber).Property(core.DatabaseSortByAsc, true).
+					Field("Name", core.FieldTypeText).Property(core.DatabaseDistinct, true).
+					Build(),
+			),
+			ExcludeIndexes: []int{1},
+			Expected:       []int{2},
+		},
+	) {
+		return
+	}
+
+	testCaseIndex++
+	if !yield(
+		&sortData{
+			TestData: internal.TestData{
+				TestTitle: "Distinct records sorted by timestamp",
+			},
+			Object: object.NewObject().WithSourceInterface(gojsoncore.JsonArray{
+				gojsoncore.JsonObject{"Day": gojsoncore.JsonArray{"2024-03-01"}, "Open": gojsoncore.JsonArray{true}},
+				gojsoncore.JsonObject{"Day": gojsoncore.JsonArray{"2024-01-15"}, "Open": gojsoncore.JsonArray{false}},
+				gojsoncore.JsonObject{"Day": gojsoncore.JsonArray{"2024-03-01"}, "Open": gojsoncore.JsonArray{true}},
+				gojsoncore.JsonObject{"Day": gojsoncore.JsonArray{"2023-12-31T23:00:00Z"}, "Open": gojsoncore.JsonArray{true}},
+			}),
+			MetadataModel: builder.Must(
+				builder.NewModel("Calendar").Property(core.DatabaseDistinct, true).
+					Field("Day", core.FieldTypeTimestamp).DatetimeFormat(core.FieldDatetimeFormatYYYYMMDD).Property(core.DatabaseSortByAsc, true).
+					Field("Open", core.FieldTypeBoolean).
+					Build(),
+			),
+			Expected: []int{3, 1, 0},
+		},
+	) {
+		return
+	}
+
+	testCaseIndex++
+	if !yield(
+		&sortData{
+			TestData: internal.TestData{
+				TestTitle: "Sort entries of a nested group",
+			},
+			Object: object.NewObject().WithSourceInterface([]*testdata.Employee{
+				{
+					ID: []int{1},
+					Profile: []*testdata.UserProfile{
+						{Name: []string{"A"}, Age: []int{30}},
+						{Name: []string{"B"}, Age: []int{45}},
+						{Name: []string{"C"}, Age: []int{18}},
+					},
+				},
+			}),
+			MetadataModel: builder.Must(
+				builder.NewModel("Employee").
+					Field("ID", core.FieldTypeNumber).
+					Group("Profile").Property(core.DatabaseLimit, 2).
+					Field("Name", core.FieldTypeText).
+					Field("Age", core.FieldTypeNumber).Property(core.DatabaseSortByAsc, false).
+					End().
+					Build(),
+			),
+			RootJsonPathKey:     "$.GroupFields[*].Profile",
+			RootJsonPathToValue: "$[0].Profile",
+			Expected:            []int{1, 0},
+		},
+	) {
+		return
+	}
+
+	testCaseIndex++
+	if !yield(
+		&sortData{
+			TestData: internal.TestData{
+				TestTitle: "Sort and distinct fields of nested groups are ignored",
+			},
+			Object: object.NewObject().WithSourceInterface([]*testdata.Employee{
+				{ID: []int{2}, Profile: []*testdata.UserProfile{{Age: []int{40}}}},
+				{ID: []int{1}, Profile: []*testdata.UserProfile{{Age: []int{10}}}},
+				{ID: []int{3}, Profile: []*testdata.UserProfile{{Age: []int{40}}}},
+			}),
+			MetadataModel: builder.Must(
+				builder.NewModel("Employee").
+					Field("ID", core.FieldTypeNumber).
+					Group("Profile").
+					Field("Age", core.FieldTypeNumber).Property(core.DatabaseSortByAsc, true).Property(core.DatabaseDistinct, true).
+					End().
+					Build(),
+			),
+			Expected: []int{0, 1, 2},
+		},
+	) {
+		return
+	}
+
+	testCaseIndex++
+	if !yield(
+		&sortData{
+			TestData: internal.TestData{
+				TestTitle: "Root value not a slice",
+			},
+			Object:        object.NewObject().WithSourceInterface(products[0]),
+			MetadataModel: testdata.ProductMetadataModel(nil),
+			ExpectErr:     true,
+		},
+	) {
+		return
+	}
+}
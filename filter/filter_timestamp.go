@@ -315,3 +315,48 @@ func IsTimestampConditionTrue(ctx FilterContext, _ path.JSONPath, filterConditio
 	}
 	return false, nil
 }
+
+/*
+TimestampPeriod returns the start (inclusive) and end (exclusive) of the period covered by value in dateTimeFormat e.g. the whole day for core.FieldDatetimeFormatYYYYMMDD.
+
+Useful for translating timestamp filter conditions into range queries. value can be time.Time, *time.Time, or a string in time.RFC3339Nano format.
+
+core.FieldDatetimeFormatHHMM and core.FieldDatetimeFormatMM repeat every day and year respectively and are not supported.
+*/
+func TimestampPeriod(value any, dateTimeFormat string) (time.Time, time.Time, error) {
+	const FunctionName = "TimestampPeriod"
+
+	var valueTime time.Time
+	switch v := value.(type) {
+	case time.Time:
+		valueTime = v
+	case *time.Time:
+		valueTime = *v
+	case string:
+		parsedTime, err := time.Parse(time.RFC3339Nano, v)
+		if err != nil {
+			return time.Time{}, time.Time{}, NewError().WithFunctionName(FunctionName).WithMessage(fmt.Sprintf("Error parsing filter condition value `%s` to time", v)).WithNestedError(err)
+		}
+		valueTime = parsedTime
+	default:
+		return time.Time{}, time.Time{}, NewError().WithFunctionName(FunctionName).WithMessage(fmt.Sprintf("filter condition value '%v' is not a time.Time or string", value))
+	}
+
+	year, month, day := valueTime.Date()
+	switch dateTimeFormat {
+	case core.FieldDatetimeFormatYYYYMMDDHHMM:
+		start := time.Date(year, month, day, valueTime.Hour(), valueTime.Minute(), 0, 0, valueTime.Location())
+		return start, start.Add(time.Minute), nil
+	case core.FieldDatetimeFormatYYYYMMDD:
+		start := time.Date(year, month, day, 0, 0, 0, 0, valueTime.Location())
+		return start, start.AddDate(0, 0, 1), nil
+	case core.FieldDatetimeFormatYYYYMM:
+		start := time.Date(year, month, 1, 0, 0, 0, 0, valueTime.Location())
+		return start, start.AddDate(0, 1, 0), nil
+	case core.FieldDatetimeFormatYYYY:
+		start := time.Date(year, 1, 1, 0, 0, 0, 0, valueTime.Location())
+		return start, start.AddDate(1, 0, 0), nil
+	default:
+		return time.Time{}, time.Time{}, NewError().WithFunctionName(FunctionName).WithMessage(fmt.Sprintf("date time format '%s' does not cover a single period", dateTimeFormat)).WithNestedError(ErrUnsupportedFilterConditionType)
+	}
+}
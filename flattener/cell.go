@@ -0,0 +1,184 @@
+package flattener
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"time"
+
+	gojsoncore "github.com/rogonion/go-json/core"
+	"github.com/rogonion/go-metadatamodel/core"
+	"github.com/rogonion/go-metadatamodel/fieldcolumns"
+)
+
+// columnFieldsHeader returns core.FieldGroupName of each column field in fieldcolumns.ColumnFields.UnskippedReadOrderOfColumnFields order.
+func columnFieldsHeader(columnFields *fieldcolumns.ColumnFields) []string {
+	header := make([]string, 0, len(columnFields.UnskippedReadOrderOfColumnFields))
+	for _, originalIndex := range columnFields.UnskippedReadOrderOfColumnFields {
+		name := ""
+		if columnField, ok := columnFields.GetColumnFieldByIndexInOriginalReadOrder(originalIndex); ok {
+			name = core.GetFieldGroupName(columnField.Property, "")
+		}
+		header = append(header, name)
+	}
+	return header
+}
+
+// rowCellsAsStrings returns each cell in row as a string in fieldcolumns.ColumnFields.UnskippedReadOrderOfColumnFields order. Refer to cellAsString.
+func rowCellsAsStrings(columnFields *fieldcolumns.ColumnFields, row FlattenedRow) ([]string, error) {
+	record := make([]string, 0, len(columnFields.UnskippedReadOrderOfColumnFields))
+	for _, sourceColIndex := range columnFields.UnskippedReadOrderOfColumnFields {
+		if sourceColIndex < 0 || sourceColIndex >= len(row) {
+			return nil, fmt.Errorf("source column index %d out of bounds for row of length %d: %w", sourceColIndex, len(row), ErrFlattenError)
+		}
+
+		var field gojsoncore.JsonObject
+		if columnField, ok := columnFields.GetColumnFieldByIndexInOriginalReadOrder(sourceColIndex); ok {
+			field = columnField.Property
+		}
+
+		value, err := cellAsString(field, row[sourceColIndex])
+		if err != nil {
+			return nil, fmt.Errorf("column %d: %w", sourceColIndex, err)
+		}
+		record = append(record, value)
+	}
+	return record, nil
+}
+
+// cellAsString converts each value in cell using field then joins them using core.JoinFieldValues.
+func cellAsString(field gojsoncore.JsonObject, cell reflect.Value) (string, error) {
+	values, err := cellValuesAsStrings(field, cell)
+	if err != nil {
+		return "", err
+	}
+	return core.JoinFieldValues(field, values)
+}
+
+// cellValuesAsStrings returns each value in cell as a string. Refer to cellValues and valueAsString.
+func cellValuesAsStrings(field gojsoncore.JsonObject, cell reflect.Value) ([]string, error) {
+	values := cellValues(cell, make([]reflect.Value, 0))
+	valuesAsStrings := make([]string, 0, len(values))
+	for _, value := range values {
+		valueAsString, err := valueAsString(field, value)
+		if err != nil {
+			return nil, err
+		}
+		valuesAsStrings = append(valuesAsStrings, valueAsString)
+	}
+	return valuesAsStrings, nil
+}
+
+// cellValues appends each non-nil value in cell, including those in nested slices/arrays, to values. Interfaces and pointers are dereferenced.
+func cellValues(cell reflect.Value, values []reflect.Value) []reflect.Value {
+	for cell.Kind() == reflect.Interface || cell.Kind() == reflect.Pointer {
+		if cell.IsNil() {
+			return values
+		}
+		cell = cell.Elem()
+	}
+	if !cell.IsValid() {
+		return values
+	}
+
+	if cell.Kind() != reflect.Slice && cell.Kind() != reflect.Array {
+		return append(values, cell)
+	}
+
+	for i := 0; i < cell.Len(); i++ {
+		values = cellValues(cell.Index(i), values)
+	}
+	return values
+}
+
+// valueAsString converts a single value in a cell to a string using core.FieldDataType, core.FieldDatetimeFormat, and the checkbox properties of field.
+func valueAsString(field gojsoncore.JsonObject, value reflect.Value) (string, error) {
+	if checkboxValue, ok := checkboxViewValue(field, value); ok {
+		return fmt.Sprint(checkboxValue), nil
+	}
+
+	if fieldDataType, _ := field[core.FieldDataType].(string); fieldDataType == core.FieldTypeTimestamp {
+		timestamp, ok, err := timestampValue(value)
+		if err != nil {
+			return "", err
+		}
+		if !ok {
+			// Leave timestamps that are already formatted as is.
+			return value.String(), nil
+		}
+		return timestamp.Format(timestampLayout(field)), nil
+	}
+
+	switch value.Kind() {
+	case reflect.Bool:
+		return strconv.FormatBool(value.Bool()), nil
+	case reflect.String:
+		return value.String(), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(value.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(value.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(value.Float(), 'f', -1, value.Type().Bits()), nil
+	}
+
+	if value.Type() == reflect.TypeOf(time.Time{}) {
+		return value.Interface().(time.Time).Format(time.RFC3339), nil
+	}
+
+	jsonValue, err := json.Marshal(value.Interface())
+	if err != nil {
+		return "", fmt.Errorf("encode value of type '%s' as json failed: %w", value.Type(), err)
+	}
+	return string(jsonValue), nil
+}
+
+// checkboxViewValue returns the Value of core.FieldCheckboxValueIfTrue or core.FieldCheckboxValueIfFalse for a boolean value of a core.FieldUiCheckbox field with core.FieldCheckboxValuesUseInView set to `true`.
+func checkboxViewValue(field gojsoncore.JsonObject, value reflect.Value) (any, bool) {
+	if value.Kind() != reflect.Bool {
+		return nil, false
+	}
+	if fieldUi, _ := field[core.FieldUI].(string); fieldUi != core.FieldUiCheckbox {
+		return nil, false
+	}
+	if useInView, ok := field[core.FieldCheckboxValuesUseInView].(bool); !ok || !useInView {
+		return nil, false
+	}
+
+	property := core.FieldCheckboxValueIfFalse
+	if value.Bool() {
+		property = core.FieldCheckboxValueIfTrue
+	}
+	checkboxValue, err := core.AsJsonObject(field[property])
+	if err != nil {
+		return nil, false
+	}
+	return checkboxValue[core.Value], true
+}
+
+// timestampValue returns value if it is a time.Time or parses it using time.RFC3339Nano if it is a string. Returns `false` if the string could not be parsed.
+func timestampValue(value reflect.Value) (time.Time, bool, error) {
+	switch v := value.Interface().(type) {
+	case time.Time:
+		return v, true, nil
+	case string:
+		timestamp, err := time.Parse(time.RFC3339Nano, v)
+		if err != nil {
+			return time.Time{}, false, nil
+		}
+		return timestamp, true, nil
+	default:
+		return time.Time{}, false, fmt.Errorf("value of type '%s' is not a timestamp: %w", value.Type(), ErrFlattenError)
+	}
+}
+
+// timestampLayout returns the time.Layout of core.FieldDatetimeFormat of field or time.RFC3339 if not set.
+func timestampLayout(field gojsoncore.JsonObject) string {
+	if fieldDatetimeFormat, ok := field[core.FieldDatetimeFormat].(string); ok {
+		if fieldDatetimeLayout, ok := core.FieldDatetimeFormatLayout(fieldDatetimeFormat); ok {
+			return fieldDatetimeLayout
+		}
+	}
+	return time.RFC3339
+}
@@ -4,6 +4,7 @@ import (
 	"errors"
 
 	"github.com/rogonion/go-metadatamodel/core"
+	"github.com/rogonion/go-metadatamodel/internal/xlsx"
 )
 
 var (
@@ -12,6 +13,9 @@ var (
 
 	// ErrNoGroupFields for when RecursiveIndexTree.GroupFields is empty if field is a group.
 	ErrNoGroupFields = errors.New("no group fields to extract found")
+
+	// ErrRowLimitExceeded for when XLSXWriter is given more rows than a worksheet can hold (1,048,576 including the header row).
+	ErrRowLimitExceeded = xlsx.ErrRowLimitExceeded
 )
 
 // NewError creates a new core.Error with the default flatten error base.
@@ -0,0 +1,136 @@
+package flattener
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+
+	"github.com/rogonion/go-json/object"
+	"github.com/rogonion/go-metadatamodel/fieldcolumns"
+)
+
+/*
+CSVWriter writes a FlattenedTable as CSV.
+
+  - The header row is core.FieldGroupName of each fieldcolumns.ColumnField in fieldcolumns.ColumnFields.UnskippedReadOrderOfColumnFields order.
+  - Multiple values in a cell are joined with core.FieldMultipleValuesJoinSymbol of the field or a comma by default using core.JoinFieldValues. Join symbols and backslashes within values are escaped with a backslash and a single empty value is written as a lone backslash. Returns core.ErrFieldMultipleValuesJoinSymbolInvalid if the join symbol contains a backslash.
+  - Values of core.FieldTypeTimestamp fields are formatted using core.FieldDatetimeFormat. time.RFC3339 is used if the format is not set.
+  - Boolean values of core.FieldUiCheckbox fields with core.FieldCheckboxValuesUseInView set to `true` are written as the Value of core.FieldCheckboxValueIfTrue or core.FieldCheckboxValueIfFalse.
+*/
+type CSVWriter struct {
+	columnFields *fieldcolumns.ColumnFields
+
+	writer *csv.Writer
+
+	headerWritten bool
+}
+
+// WithComma sets the field delimiter. Defaults to ','.
+func (n *CSVWriter) WithComma(value rune) *CSVWriter {
+	n.SetComma(value)
+	return n
+}
+
+// SetComma sets the field delimiter. Defaults to ','.
+func (n *CSVWriter) SetComma(value rune) {
+	n.writer.Comma = value
+}
+
+// WriteHeader writes the header row if it has not been written yet.
+func (n *CSVWriter) WriteHeader() error {
+	const FunctionName = "WriteHeader"
+
+	if n.headerWritten {
+		return nil
+	}
+
+	if err := n.writer.Write(columnFieldsHeader(n.columnFields)); err != nil {
+		return NewError().WithFunctionName(FunctionName).WithMessage("write header failed").WithNestedError(err)
+	}
+	n.headerWritten = true
+	return nil
+}
+
+// WriteRow writes row as a CSV record. The header row is written first if it has not been written yet.
+func (n *CSVWriter) WriteRow(row FlattenedRow) error {
+	const FunctionName = "WriteRow"
+
+	if err := n.WriteHeader(); err != nil {
+		return err
+	}
+
+	record, err := rowCellsAsStrings(n.columnFields, row)
+	if err != nil {
+		return NewError().WithFunctionName(FunctionName).WithMessage("convert row failed").WithNestedError(err)
+	}
+
+	if err := n.writer.Write(record); err != nil {
+		return NewError().WithFunctionName(FunctionName).WithMessage("write row failed").WithNestedError(err)
+	}
+	return nil
+}
+
+// WriteTable writes the header row followed by every row in table then flushes the underlying writer.
+func (n *CSVWriter) WriteTable(table FlattenedTable) error {
+	if err := n.WriteHeader(); err != nil {
+		return err
+	}
+
+	for rowIndex, row := range table {
+		if err := n.WriteRow(row); err != nil {
+			return fmt.Errorf("row %d: %w", rowIndex, err)
+		}
+	}
+
+	return n.Flush()
+}
+
+// Flush writes any buffered data to the underlying io.Writer.
+func (n *CSVWriter) Flush() error {
+	const FunctionName = "Flush"
+
+	n.writer.Flush()
+	if err := n.writer.Error(); err != nil {
+		return NewError().WithFunctionName(FunctionName).WithMessage("flush failed").WithNestedError(err)
+	}
+	return nil
+}
+
+// Close writes the header row if it has not been written yet then flushes the underlying writer. Does not close the underlying io.Writer.
+func (n *CSVWriter) Close() error {
+	if err := n.WriteHeader(); err != nil {
+		return err
+	}
+	return n.Flush()
+}
+
+// NewCSVWriter creates a new CSVWriter that writes rows whose columns are described by columnFields to writer.
+func NewCSVWriter(columnFields *fieldcolumns.ColumnFields, writer io.Writer) *CSVWriter {
+	n := &CSVWriter{
+		columnFields: columnFields,
+		writer:       csv.NewWriter(writer),
+	}
+	return n
+}
+
+// WriteCSV writes the current FlattenedTable to writer as CSV. Refer to CSVWriter.
+//
+// Call Flattener.Flatten first so that the column fields are set.
+func (n *Flattener) WriteCSV(writer io.Writer) error {
+	const FunctionName = "WriteCSV"
+
+	if n.columnFields == nil {
+		return NewError().WithFunctionName(FunctionName).WithMessage("column fields not set")
+	}
+
+	return NewCSVWriter(n.columnFields, writer).WriteTable(n.currentSourceObjectResult)
+}
+
+// StreamCSV flattens sourceObject one row at a time and writes each row to writer as CSV. Refer to Flattener.WriteRows.
+func (n *Flattener) StreamCSV(sourceObject *object.Object, writer io.Writer) error {
+	if err := n.initFieldGroupConversion(); err != nil {
+		return err
+	}
+
+	return n.WriteRows(sourceObject, NewCSVWriter(n.columnFields, writer))
+}
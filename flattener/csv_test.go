@@ -0,0 +1,198 @@
+package flattener
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/brunoga/deep"
+	gojsoncore "github.com/rogonion/go-json/core"
+	"github.com/rogonion/go-json/object"
+	"github.com/rogonion/go-json/path"
+	"github.com/rogonion/go-metadatamodel/core"
+	"github.com/rogonion/go-metadatamodel/testdata"
+)
+
+func TestFlattener_WriteCSV(t *testing.T) {
+	for data := range csvTestData {
+		f := NewFlattener(data.MetadataModel)
+		if err := f.Flatten(data.SourceObject); err != nil {
+			t.Errorf("%s: Flatten() unexpected error: %v", data.TestTitle, err)
+			continue
+		}
+
+		var buffer bytes.Buffer
+		if err := f.WriteCSV(&buffer); err != nil {
+			t.Errorf("%s: WriteCSV() unexpected error: %v", data.TestTitle, err)
+			continue
+		}
+
+		if buffer.String() != data.ExpectedResult {
+			t.Errorf("%s: Result mismatch.\nExpected:\n%s\nGot:\n%s", data.TestTitle, data.ExpectedResult, buffer.String())
+		}
+	}
+}
+
+func TestFlattener_StreamCSV(t *testing.T) {
+	for data := range csvTestData {
+		var buffer bytes.Buffer
+		if err := NewFlattener(data.MetadataModel).StreamCSV(data.SourceObject, &buffer); err != nil {
+			t.Errorf("%s: StreamCSV() unexpected error: %v", data.TestTitle, err)
+			continue
+		}
+
+		if buffer.String() != data.ExpectedResult {
+			t.Errorf("%s: Result mismatch.\nExpected:\n%s\nGot:\n%s", data.TestTitle, data.ExpectedResult, buffer.String())
+		}
+	}
+}
+
+func TestFlattener_WriteCSV_NotFlattened(t *testing.T) {
+	var buffer bytes.Buffer
+	if err := NewFlattener(testdata.UserMetadataModel(nil)).WriteCSV(&buffer); err == nil {
+		t.Error("expected error when writing csv before Flatten")
+	}
+}
+
+func TestFlattener_WriteCSV_JoinSymbolInvalid(t *testing.T) {
+	metadataModel := csvMetadataModel()
+	metadataModel[core.GroupFields].(gojsoncore.JsonArray)[0].(gojsoncore.JsonObject)["Tags"].(gojsoncore.JsonObject)[core.FieldMultipleValuesJoinSymbol] = ` \ `
+
+	f := NewFlattener(metadataModel)
+	if err := f.Flatten(object.NewObject().WithSourceInterface([]any{gojsoncore.JsonObject{"Tags": gojsoncore.JsonArray{"a", "b"}}})); err != nil {
+		t.Fatalf("Flatten() unexpected error: %v", err)
+	}
+
+	var buffer bytes.Buffer
+	if err := f.WriteCSV(&buffer); !errors.Is(err, core.ErrFieldMultipleValuesJoinSymbolInvalid) {
+		t.Errorf("expected error %v, got %v", core.ErrFieldMultipleValuesJoinSymbolInvalid, err)
+	}
+}
+
+// --- Test Data Structures ---
+
+type csvData struct {
+	TestTitle      string
+	SourceObject   *object.Object
+	MetadataModel  gojsoncore.JsonObject
+	ExpectedResult string
+}
+
+func csvTestData(yield func(data *csvData) bool) {
+	// -------------------------------------------------------------------------
+	// Case 1: Deep Nested Object (Employee -> Profile -> Address)
+	// -------------------------------------------------------------------------
+	empData := testdata.Employee{
+		ID:     []int{500},
+		Skills: []string{"Go", "Rust"},
+		Profile: []*testdata.UserProfile{
+			{
+				Name: []string{"Bob"},
+				Age:  []int{30},
+				Address: []testdata.Address{
+					{Street: []string{"123 Tech Ln"}, City: []string{"Silicon Valley"}, ZipCode: []*string{gojsoncore.Ptr("94000")}},
+					{Street: []string{"1 Main St"}, City: []string{"Nairobi"}},
+				},
+			},
+		},
+	}
+
+	if !yield(&csvData{
+		TestTitle:     "Deep Nested Employee",
+		SourceObject:  object.NewObject().WithSourceInterface(empData),
+		MetadataModel: testdata.EmployeeMetadataModel(nil),
+		ExpectedResult: "ID,Name,Age,Street,City,ZipCode,Skills\n" +
+			"500,Bob,30,123 Tech Ln,Silicon Valley,94000,\"Go,Rust\"\n" +
+			"500,Bob,30,1 Main St,Nairobi,,\"Go,Rust\"\n",
+	}) {
+		return
+	}
+
+	// -------------------------------------------------------------------------
+	// Case 2: Join symbol, timestamps, and checkboxes
+	// -------------------------------------------------------------------------
+	if !yield(&csvData{
+		TestTitle: "Join Symbol, Timestamps, and Checkboxes",
+		SourceObject: object.NewObject().WithSourceInterface([]any{
+			gojsoncore.JsonObject{
+				"Tags":      gojsoncore.JsonArray{"a", "b"},
+				"Published": gojsoncore.JsonArray{time.Date(2024, 3, 5, 14, 30, 0, 0, time.UTC)},
+				"Active":    gojsoncore.JsonArray{true},
+				"Verified":  gojsoncore.JsonArray{false},
+			},
+			gojsoncore.JsonObject{
+				"Tags":      gojsoncore.JsonArray{"c"},
+				"Published": gojsoncore.JsonArray{"2025-01-02T08:00:00Z"},
+				"Active":    gojsoncore.JsonArray{false},
+			},
+		}),
+		MetadataModel: csvMetadataModel(),
+		ExpectedResult: "Tags,Published,Active,Verified\n" +
+			"a | b,2024-03-05,yes,false\n" +
+			"c,2025-01-02,no,\n",
+	}) {
+		return
+	}
+
+	// -------------------------------------------------------------------------
+	// Case 3: Join symbols, backslashes, and a single empty value are escaped
+	// -------------------------------------------------------------------------
+	if !yield(&csvData{
+		TestTitle: "Escaped Values",
+		SourceObject: object.NewObject().WithSourceInterface([]any{
+			gojsoncore.JsonObject{
+				"Tags": gojsoncore.JsonArray{"a | b", `c\d`},
+			},
+			gojsoncore.JsonObject{
+				"Tags": gojsoncore.JsonArray{""},
+			},
+		}),
+		MetadataModel: csvMetadataModel(),
+		ExpectedResult: "Tags,Published,Active,Verified\n" +
+			`a\ | b | c\\d,,,` + "\n" +
+			`\,,,` + "\n",
+	}) {
+		return
+	}
+}
+
+func csvMetadataModel() gojsoncore.JsonObject {
+	return deep.MustCopy(gojsoncore.JsonObject{
+		core.FieldGroupJsonPathKey: path.JsonpathKeyRoot,
+		core.GroupFields: gojsoncore.JsonArray{
+			gojsoncore.JsonObject{
+				"Tags": gojsoncore.JsonObject{
+					core.FieldGroupJsonPathKey:         path.JsonpathKeyRoot + core.GroupJsonPathPrefix + "Tags",
+					core.FieldGroupName:                "Tags",
+					core.FieldDataType:                 core.FieldTypeText,
+					core.FieldUI:                       core.FieldUiText,
+					core.FieldMultipleValuesJoinSymbol: " | ",
+				},
+				"Published": gojsoncore.JsonObject{
+					core.FieldGroupJsonPathKey: path.JsonpathKeyRoot + core.GroupJsonPathPrefix + "Published",
+					core.FieldGroupName:        "Published",
+					core.FieldDataType:         core.FieldTypeTimestamp,
+					core.FieldUI:               core.FieldUiDatetime,
+					core.FieldDatetimeFormat:   core.FieldDatetimeFormatYYYYMMDD,
+				},
+				"Active": gojsoncore.JsonObject{
+					core.FieldGroupJsonPathKey:        path.JsonpathKeyRoot + core.GroupJsonPathPrefix + "Active",
+					core.FieldGroupName:               "Active",
+					core.FieldDataType:                core.FieldTypeBoolean,
+					core.FieldUI:                      core.FieldUiCheckbox,
+					core.FieldCheckboxValuesUseInView: true,
+					core.FieldCheckboxValueIfTrue:     gojsoncore.JsonObject{core.Type: core.FieldTypeText, core.Value: "yes"},
+					core.FieldCheckboxValueIfFalse:    gojsoncore.JsonObject{core.Type: core.FieldTypeText, core.Value: "no"},
+				},
+				"Verified": gojsoncore.JsonObject{
+					core.FieldGroupJsonPathKey: path.JsonpathKeyRoot + core.GroupJsonPathPrefix + "Verified",
+					core.FieldGroupName:        "Verified",
+					core.FieldDataType:         core.FieldTypeBoolean,
+					core.FieldUI:               core.FieldUiCheckbox,
+				},
+			},
+		},
+		core.GroupReadOrderOfFields: gojsoncore.JsonArray{"Tags", "Published", "Active", "Verified"},
+	})
+}
@@ -8,6 +8,9 @@ It can perform the following tasks:
   - Handle one-to-many relationships by generating Cartesian products (row explosion).
   - Handle specific fields by pivoting them into horizontal columns (horizontal expansion).
   - Write the flattened results into a destination `object.Object` using schema-based type conversion.
+  - Write the flattened results as CSV.
+  - Write the flattened results as an .xlsx workbook.
+  - Produce rows lazily via the `Rows` iterator and write them to an `io.Writer` with bounded memory.
   - Support batch processing via the `Reset` method.
 
 # Usage
@@ -54,6 +57,27 @@ There are two ways to retrieve the flattened data:
 	destObj := object.NewObject().WithSourceInterface(make([][]any, 0))
 	err := flattener.WriteToDestination(destObj)
 
+3. **Write as CSV:** Use `WriteCSV` to write the results as CSV with a header row of `core.FieldGroupName` values. Multiple values in a cell are joined with `core.FieldMultipleValuesJoinSymbol` escaping join symbols within values, timestamps are formatted using `core.FieldDatetimeFormat`, and checkbox values honour `core.FieldCheckboxValuesUseInView`. Use `NewCSVWriter` to write rows individually.
+
+	var buffer bytes.Buffer
+	err := flattener.WriteCSV(&buffer)
+
+4. **Write as XLSX:** Use `WriteXLSX` to write the results as an .xlsx workbook. Numbers, booleans, and timestamps become typed cells, columns with `core.FieldGroupViewTableLockColumn` are frozen, and columns with `core.FieldSelectOptions` get a dropdown list. Use `NewXLSXWriter` to write rows individually.
+
+	err := flattener.WriteXLSX(&buffer)
+
+## Streaming Rows
+
+To export large datasets with bounded memory, use `Rows` to produce the rows of a source object one at a time instead of accumulating them with `Flatten`.
+
+	for row, err := range flattener.Rows(sourceObj) {
+		// ...
+	}
+
+Use `WriteRows` with a `RowWriter` like `CSVWriter` or `XLSXWriter`, or `StreamCSV` and `StreamXLSX`, to write each row to an `io.Writer` as it is produced. A worksheet holds at most 1,048,576 rows including the header row, so `StreamXLSX` returns `ErrRowLimitExceeded` for sources that produce more.
+
+	err := flattener.StreamCSV(sourceObj, file)
+
 ## Batch Processing
 
 To process large datasets in chunks, use the `Reset` method to clear the internal state without re-allocating the Flattener.
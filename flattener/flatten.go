@@ -3,7 +3,9 @@ package flattener
 import (
 	"errors"
 	"fmt"
+	"iter"
 	"reflect"
+	"slices"
 
 	gojsoncore "github.com/rogonion/go-json/core"
 	"github.com/rogonion/go-json/object"
@@ -66,90 +68,74 @@ func (n *Flattener) WriteToDestination(destination *object.Object) error {
 }
 
 /*
-recursiveConvert now takes the current table state and returns the mutated table.
-*/
-func (n *Flattener) recursiveConvert(groupConversion *RecursiveIndexTree, linearCollectionIndexes []int, incomingRows FlattenedTable) (FlattenedTable, error) {
-	const FunctionName = "recursiveConvert"
+recursiveYieldRows produces the rows of sourceObject one at a time by walking groupConversion.GroupFields from fieldIndex down to the first field.
+
+Each field writes its cell into row at the column just before columnIndex. Once every field of the group has been written, next is called to write the fields that precede the group. A linear collection of a nested group produces a branch per element.
 
-	// Working set starts as a copy of incoming rows
-	currentRows := n.copyTable(incomingRows)
+Walking the fields in reverse keeps the rows in the same order as a Cartesian product built by merging fields into tables from first to last, without holding any table in memory. Only row is shared between branches; the fields of each branch overwrite the same columns.
 
-	// If incoming rows is empty (start of process), initialize with one empty row
-	// so Cartesian products work.
-	if len(currentRows) == 0 {
-		currentRows = FlattenedTable{{}}
+Returns false if iteration should stop.
+*/
+func (n *Flattener) recursiveYieldRows(sourceObject *object.Object, groupConversion *RecursiveIndexTree, linearCollectionIndexes []int, fieldIndex int, columnIndex int, row FlattenedRow, next func() (bool, error)) (bool, error) {
+	if fieldIndex < 0 {
+		return next()
 	}
 
-	for _, fgConversion := range groupConversion.GroupFields {
-		fieldData := DefaultEmptyColumn()
-		if jsonPath, err := core.NewJsonPathToValue().Get(fgConversion.FieldColumnPosition.JSONPath(), linearCollectionIndexes); err != nil {
-			return nil, NewError().WithFunctionName(FunctionName).WithMessage("get path to value at field failed").WithNestedError(err)
-		} else {
-			noOfResults, err := n.currentSourceObject.Get(jsonPath)
-			if err != nil {
-				return nil, NewError().WithFunctionName(FunctionName).WithMessage("get value at jsonPath failed").WithNestedError(err)
-			}
-			if noOfResults > 0 {
-				fieldData = n.currentSourceObject.GetValueFoundReflected()
-			}
+	fgConversion := groupConversion.GroupFields[fieldIndex]
+	fieldData, err := n.getFieldData(sourceObject, fgConversion, linearCollectionIndexes)
+	if err != nil {
+		return false, err
+	}
+
+	// 1. Handle Nested Groups / Recursion
+	if len(fgConversion.GroupFields) > 0 {
+		// Fields that precede the nested group are written once the nested group has written its columns.
+		nextField := func() (bool, error) {
+			return n.recursiveYieldRows(sourceObject, groupConversion, linearCollectionIndexes, fieldIndex-1, columnIndex-fgConversion.noOfColumns, row, next)
 		}
 
-		// 1. Handle Nested Groups / Recursion
-		if len(fgConversion.GroupFields) > 0 {
-			if fieldData.Kind() == reflect.Slice || fieldData.Kind() == reflect.Array {
-				// BRANCHING LOGIC (The Deep Copy replacement)
-				// We are splitting the universe here: Row 1 vs Row 2 vs Row 3
-				collectedBranchResults := make(FlattenedTable, 0)
-
-				for i := 0; i < fieldData.Len(); i++ {
-					// IMPORTANT: Pass a CLEAN COPY of currentRows to this branch
-					// This ensures iteration i=0 doesn't mess up i=1
-					branchInput := n.copyTable(currentRows)
-
-					branchResult, err := n.recursiveConvert(fgConversion, append(linearCollectionIndexes, i), branchInput)
-					if err != nil {
-						return nil, err
-					}
-					collectedBranchResults = append(collectedBranchResults, branchResult...)
-				}
-				// The result of processing this field is the union of all branches
-				currentRows = collectedBranchResults
-				continue
-			}
+		// Values in associative collections like maps are interfaces.
+		if fieldData.Kind() == reflect.Interface && !fieldData.IsNil() {
+			fieldData = fieldData.Elem()
+		}
 
-			// Non-array nested group (Single Object)
-			var err error
-			currentRows, err = n.recursiveConvert(fgConversion, append(linearCollectionIndexes, 0), currentRows)
-			if err != nil {
-				return nil, err
+		if fieldData.Kind() == reflect.Slice || fieldData.Kind() == reflect.Array {
+			// One branch per element. An empty collection produces no rows.
+			for i := 0; i < fieldData.Len(); i++ {
+				if ok, err := n.recursiveYieldRows(sourceObject, fgConversion, append(slices.Clone(linearCollectionIndexes), i), len(fgConversion.GroupFields)-1, columnIndex, row, nextField); !ok || err != nil {
+					return ok, err
+				}
 			}
-			continue
+			return true, nil
 		}
 
-		// 2. Handle Leaf Fields (Merge into current rows)
-		// This is a Sibling field, so we merge it into EVERY row in currentRows (Cartesian)
-		cellValue := n.getCellValueFromFieldData(fieldData)
-		currentRows = n.mergeCellValueIntoRows(currentRows, cellValue)
+		// Non-array nested group (Single Object)
+		return n.recursiveYieldRows(sourceObject, fgConversion, append(slices.Clone(linearCollectionIndexes), 0), len(fgConversion.GroupFields)-1, columnIndex, row, nextField)
 	}
 
-	return currentRows, nil
+	// 2. Handle Leaf Fields
+	row[columnIndex-1] = n.getCellValueFromFieldData(fieldData)
+	return n.recursiveYieldRows(sourceObject, groupConversion, linearCollectionIndexes, fieldIndex-1, columnIndex-1, row, next)
 }
 
-/*
-Helper to copy the table structure (to replace old deep.Copy logic)
-*/
-func (n *Flattener) copyTable(source FlattenedTable) FlattenedTable {
-	if len(source) == 0 {
-		return make(FlattenedTable, 0)
+// getFieldData returns the value of the field or group in fgConversion in sourceObject or DefaultEmptyColumn if it does not exist.
+func (n *Flattener) getFieldData(sourceObject *object.Object, fgConversion *RecursiveIndexTree, linearCollectionIndexes []int) (reflect.Value, error) {
+	const FunctionName = "getFieldData"
+
+	jsonPath, err := core.NewJsonPathToValue().Get(fgConversion.FieldColumnPosition.JSONPath(), linearCollectionIndexes)
+	if err != nil {
+		return reflect.Value{}, NewError().WithFunctionName(FunctionName).WithMessage("get path to value at field failed").WithNestedError(err)
 	}
-	newTable := make(FlattenedTable, len(source))
-	for i, row := range source {
-		// Copy the row slice so appending to it doesn't affect the original
-		newRow := make(FlattenedRow, len(row))
-		copy(newRow, row)
-		newTable[i] = newRow
+
+	// Missing values e.g. absent map entries or out of range indexes are reported with object.ErrValueAtPathSegmentInvalidError and are empty columns.
+	noOfResults, err := sourceObject.Get(jsonPath)
+	if err != nil && !errors.Is(err, object.ErrValueAtPathSegmentInvalidError) {
+		return reflect.Value{}, NewError().WithFunctionName(FunctionName).WithMessage("get value at jsonPath failed").WithNestedError(err)
+	}
+	if noOfResults == 0 {
+		return DefaultEmptyColumn(), nil
 	}
-	return newTable
+	return sourceObject.GetValueFoundReflected(), nil
 }
 
 /*
@@ -169,37 +155,9 @@ func (n *Flattener) getCellValueFromFieldData(fieldData reflect.Value) reflect.V
 	return newFieldData
 }
 
-/*
-Merges a single cell value (which is a slice) into all existing rows.
-
-If the cell value is meant to explode rows (e.g. if the cell itself contains multiple items
-that should be separate rows), logic differs, but based on your "normalizeToSlice",
-we treat the cell as 1 unit of data for that column.
-*/
-func (n *Flattener) mergeCellValueIntoRows(rows FlattenedTable, cellValue reflect.Value) FlattenedTable {
-	newRows := make(FlattenedTable, len(rows))
-
-	for i, row := range rows {
-		// Create new row with existing data + new cell
-		// Note: row is already copied if coming from copyTable, but safer to append
-		newRow := make(FlattenedRow, len(row)+1)
-		copy(newRow, row)
-		newRow[len(row)] = cellValue
-		newRows[i] = newRow
-	}
-
-	return newRows
-}
-
-// Flatten processes the sourceObject and populates the internal Flattener.currentSourceObjectResult.
-//
-// Once the process is successful, you can call Flattener.GetResult to retrieve the FlattenedTable or Flattener.WriteToDestination.
-//
-// For preset Flattener.columnFields, ensure fieldcolumns.ColumnFields.UnskippedReadOrderOfColumnFields is set.
-func (n *Flattener) Flatten(sourceObject *object.Object) error {
-	const FunctionName = "Flatten"
-
-	n.currentSourceObjectIsALinearCollection = sourceObject.GetSourceReflected().Kind() == reflect.Slice || sourceObject.GetSourceReflected().Kind() == reflect.Array
+// initFieldGroupConversion extracts the default Flattener.columnFields if not set and builds Flattener.fieldGroupConversion.
+func (n *Flattener) initFieldGroupConversion() error {
+	const FunctionName = "initFieldGroupConversion"
 
 	if n.columnFields == nil {
 		if columnFields, err := fieldcolumns.NewColumnFieldsExtraction(n.metadataModel).Extract(); err != nil {
@@ -219,33 +177,88 @@ func (n *Flattener) Flatten(sourceObject *object.Object) error {
 		}
 	}
 
-	var err error
-	if n.currentSourceObjectIsALinearCollection {
-		sourceObject.ForEach(path.JSONPath(path.JsonpathKeyRoot+path.JsonpathDotNotation+path.JsonpathLeftBracket+path.JsonpathKeyIndexAll+path.JsonpathRightBracket), func(jsonPath path.RecursiveDescentSegment, value reflect.Value) bool {
-			n.currentSourceObject = object.NewObject().WithSourceReflected(value)
-			var resultTable FlattenedTable
-			resultTable, err = n.recursiveConvert(n.fieldGroupConversion, make([]int, 0), make(FlattenedTable, 0))
-			if err == nil {
-				n.currentSourceObjectResult = append(n.currentSourceObjectResult, resultTable...)
+	return nil
+}
+
+/*
+Rows returns an iterator that flattens sourceObject one row at a time. Rows are not added to the FlattenedTable returned by Flattener.GetResult.
+
+If sourceObject is a linear collection, each associative collection at the top-level is flattened individually. Only the row being produced is held in memory, so large sources can be exported with bounded memory using Flattener.WriteRows.
+
+Each FlattenedRow yielded is a new slice that can be retained. Iteration stops after the first error.
+
+For preset Flattener.columnFields, ensure fieldcolumns.ColumnFields.UnskippedReadOrderOfColumnFields is set.
+*/
+func (n *Flattener) Rows(sourceObject *object.Object) iter.Seq2[FlattenedRow, error] {
+	return func(yield func(FlattenedRow, error) bool) {
+		if err := n.initFieldGroupConversion(); err != nil {
+			yield(nil, err)
+			return
+		}
+
+		row := make(FlattenedRow, n.fieldGroupConversion.noOfColumns)
+		yieldRows := func(recordObject *object.Object) bool {
+			ok, err := n.recursiveYieldRows(recordObject, n.fieldGroupConversion, make([]int, 0), len(n.fieldGroupConversion.GroupFields)-1, len(row), row, func() (bool, error) {
+				return yield(slices.Clone(row), nil), nil
+			})
+			if err != nil {
+				yield(nil, err)
+				return false
 			}
-			return false
-		})
-	} else {
-		n.currentSourceObject = sourceObject
-		var resultTable FlattenedTable
-		resultTable, err = n.recursiveConvert(n.fieldGroupConversion, make([]int, 0), make(FlattenedTable, 0))
-		if err == nil {
-			n.currentSourceObjectResult = append(n.currentSourceObjectResult, resultTable...)
+			return ok
+		}
+
+		if sourceObject.GetSourceReflected().Kind() == reflect.Slice || sourceObject.GetSourceReflected().Kind() == reflect.Array {
+			sourceObject.ForEach(path.JSONPath(path.JsonpathKeyRoot+path.JsonpathDotNotation+path.JsonpathLeftBracket+path.JsonpathKeyIndexAll+path.JsonpathRightBracket), func(jsonPath path.RecursiveDescentSegment, value reflect.Value) bool {
+				return !yieldRows(object.NewObject().WithSourceReflected(value))
+			})
+			return
 		}
+
+		yieldRows(sourceObject)
 	}
+}
 
-	if err != nil {
-		return err
+// Flatten processes the sourceObject and appends its rows to the internal Flattener.currentSourceObjectResult.
+//
+// Once the process is successful, you can call Flattener.GetResult to retrieve the FlattenedTable or Flattener.WriteToDestination.
+//
+// For preset Flattener.columnFields, ensure fieldcolumns.ColumnFields.UnskippedReadOrderOfColumnFields is set. Refer to Flattener.Rows.
+func (n *Flattener) Flatten(sourceObject *object.Object) error {
+	for row, err := range n.Rows(sourceObject) {
+		if err != nil {
+			return err
+		}
+		n.currentSourceObjectResult = append(n.currentSourceObjectResult, row)
 	}
 
 	return nil
 }
 
+/*
+WriteRows flattens sourceObject one row at a time using Flattener.Rows and writes each row to rowWriter then closes it.
+
+Rows are not added to the FlattenedTable returned by Flattener.GetResult, so memory stays bounded regardless of the size of sourceObject. rowWriter is not closed if an error occurs.
+
+Errors returned by rowWriter, like ErrRowLimitExceeded from XLSXWriter, stop flattening and are wrapped so that they can be checked using errors.Is.
+*/
+func (n *Flattener) WriteRows(sourceObject *object.Object, rowWriter RowWriter) error {
+	const FunctionName = "WriteRows"
+
+	rowIndex := 0
+	for row, err := range n.Rows(sourceObject) {
+		if err != nil {
+			return err
+		}
+		if err := rowWriter.WriteRow(row); err != nil {
+			return NewError().WithFunctionName(FunctionName).WithMessage(fmt.Sprintf("write row %d failed", rowIndex)).WithNestedError(err)
+		}
+		rowIndex++
+	}
+
+	return rowWriter.Close()
+}
+
 // GetResult returns the raw FlattenedTable.
 // This allows the user to read the results directly.
 func (n *Flattener) GetResult() FlattenedTable {
@@ -388,6 +401,14 @@ func (n *Flattener) recursiveInitRecursiveIndexTree(group any, groupJsonPathKey
 		}
 	}
 
+	for _, fgConversion := range groupIndexTree.GroupFields {
+		if len(fgConversion.GroupFields) > 0 {
+			groupIndexTree.noOfColumns += fgConversion.noOfColumns
+		} else {
+			groupIndexTree.noOfColumns++
+		}
+	}
+
 	if len(groupIndexTree.GroupFields) == 0 {
 		return nil, NewError().WithFunctionName(FunctionName).WithMessage("no group fields to extract found").WithData(gojsoncore.JsonObject{"Group": group}).WithNestedError(ErrNoGroupFields)
 	}
@@ -414,6 +435,11 @@ func NewFlattener(metadataModel gojsoncore.JsonObject) *Flattener {
 	}
 }
 
+// NewFlattenerFromModel creates a new Flattener instance with the provided typed metadata model converted with core.Model.JsonObject.
+func NewFlattenerFromModel(model *core.Model) *Flattener {
+	return NewFlattener(model.JsonObject())
+}
+
 // FlattenedRow represents a single row in a table.
 //
 // The flattener will attempt to enforce that each cell (column in row) is either a slice or array for uniformity.
@@ -433,28 +459,32 @@ type Flattener struct {
 	// columnFields extracted fields as table columns from metadataModel
 	columnFields *fieldcolumns.ColumnFields
 
-	// currentSourceObject individual associative collection object to convert to a 2D slice. Result appended to currentSourceObjectResult.
-	currentSourceObject *object.Object
-
-	// currentSourceObjectIsALinearCollection determines how currentSourceObject will be processed.
-	//
-	// If true, object to flatten is assumed to be a collection of associative collections (maps and structs) thus each at the top-level will be loaded into currentSourceObject for flattening individually.
-	currentSourceObjectIsALinearCollection bool
-
-	// currentSourceObjectResult holds the current result of flattening the currentSourceObject.
+	// currentSourceObjectResult holds the current result of flattening source objects with Flattener.Flatten.
 	//
 	// Will be written into destination.
 	currentSourceObjectResult FlattenedTable
 
-	// fieldGroupConversion data (tree of fields/groups) to use when converting the currentSourceObject.
+	// fieldGroupConversion data (tree of fields/groups) to use when converting a source object.
 	fieldGroupConversion *RecursiveIndexTree
 }
 
+// RowWriter writes FlattenedRow one at a time to a sink like an io.Writer. Implemented by CSVWriter and XLSXWriter.
+type RowWriter interface {
+	// WriteRow writes row whose cells are in the original read order of fieldcolumns.ColumnFields.
+	WriteRow(row FlattenedRow) error
+
+	// Close writes anything remaining after the last row.
+	Close() error
+}
+
 // RecursiveIndexTree represents tree of field/groups to read for Flattener.
 type RecursiveIndexTree struct {
 	FieldColumnPosition *fieldcolumns.FieldColumnPosition
 
 	GroupFields []*RecursiveIndexTree
+
+	// noOfColumns number of columns produced by the fields in GroupFields.
+	noOfColumns int
 }
 
 // DefaultEmptyColumn returns a reflect.Value representing an empty column (nil slice of any).
@@ -1,11 +1,14 @@
 package flattener
 
 import (
+	"errors"
+	"fmt"
 	"reflect"
 	"testing"
 
 	gojsoncore "github.com/rogonion/go-json/core"
 	"github.com/rogonion/go-json/object"
+	"github.com/rogonion/go-json/path"
 	"github.com/rogonion/go-metadatamodel/core"
 	"github.com/rogonion/go-metadatamodel/fieldcolumns"
 	"github.com/rogonion/go-metadatamodel/iter"
@@ -91,6 +94,125 @@ func TestFlattener_Reset(t *testing.T) {
 	// (Assuming column 1 is Name based on default read order)
 }
 
+func TestFlattener_FromModel(t *testing.T) {
+	employeeMeta := testdata.EmployeeMetadataModel(nil)
+	model, err := core.DecodeModel(employeeMeta)
+	if err != nil {
+		t.Fatalf("DecodeModel failed: %v", err)
+	}
+
+	employee := testdata.Employee{
+		ID:     []int{1},
+		Skills: []string{"Go"},
+		Profile: []*testdata.UserProfile{
+			{Name: []string{"Alice"}, Address: []testdata.Address{{City: []string{"Nairobi"}}, {City: []string{"Mombasa"}}}},
+		},
+	}
+
+	results := make([]any, 0, 2)
+	for _, f := range []*Flattener{NewFlattener(employeeMeta), NewFlattenerFromModel(model)} {
+		if err := f.Flatten(object.NewObject().WithSourceInterface(employee)); err != nil {
+			t.Fatalf("Flatten failed: %v", err)
+		}
+		destination := object.NewObject().WithSourceInterface(make([][]any, 0))
+		if err := f.WriteToDestination(destination); err != nil {
+			t.Fatalf("WriteToDestination failed: %v", err)
+		}
+		results = append(results, destination.GetSourceInterface())
+	}
+
+	if !reflect.DeepEqual(results[1], results[0]) {
+		t.Errorf("Result mismatch.\nExpected:\n%#v\nGot:\n%#v", results[0], results[1])
+	}
+}
+
+func TestFlattener_Rows(t *testing.T) {
+	for data := range flattenerTestData {
+		f := NewFlattener(data.MetadataModel).WithColumnFields(data.ColumnFields)
+
+		table := make(FlattenedTable, 0)
+		for row, err := range f.Rows(data.SourceObject) {
+			if err != nil {
+				t.Fatalf("%s: Rows() unexpected error: %v", data.TestTitle, err)
+			}
+			table = append(table, row)
+		}
+
+		if len(f.GetResult()) != 0 {
+			t.Errorf("%s: expected Rows() not to add rows to GetResult(), got %d", data.TestTitle, len(f.GetResult()))
+		}
+
+		f.currentSourceObjectResult = table
+		destination := object.NewObject().WithSourceInterface(make([][]any, 0))
+		if err := f.WriteToDestination(destination); err != nil {
+			t.Fatalf("%s: WriteToDestination() unexpected error: %v", data.TestTitle, err)
+		}
+
+		if actualResult := destination.GetSourceInterface(); !reflect.DeepEqual(actualResult, data.ExpectedResult) {
+			t.Errorf("%s: Result mismatch.\nExpected:\n%#v\nGot:\n%#v", data.TestTitle, data.ExpectedResult, actualResult)
+		}
+	}
+}
+
+func TestFlattener_Rows_SiblingCollections(t *testing.T) {
+	groupField := func(parentJsonPathKey string, name string, fieldName string) gojsoncore.JsonObject {
+		groupJsonPathKey := parentJsonPathKey + core.GroupJsonPathPrefix + name
+		return gojsoncore.JsonObject{
+			core.FieldGroupJsonPathKey: groupJsonPathKey,
+			core.FieldGroupName:        name,
+			core.GroupFields: gojsoncore.JsonArray{
+				gojsoncore.JsonObject{
+					fieldName: gojsoncore.JsonObject{
+						core.FieldGroupJsonPathKey: groupJsonPathKey + core.GroupJsonPathPrefix + fieldName,
+						core.FieldGroupName:        fieldName,
+						core.FieldDataType:         core.FieldTypeText,
+						core.FieldUI:               core.FieldUiText,
+					},
+				},
+			},
+			core.GroupReadOrderOfFields: gojsoncore.JsonArray{fieldName},
+		}
+	}
+	metadataModel := gojsoncore.JsonObject{
+		core.FieldGroupJsonPathKey: path.JsonpathKeyRoot,
+		core.GroupFields: gojsoncore.JsonArray{
+			gojsoncore.JsonObject{
+				"A": groupField(path.JsonpathKeyRoot, "A", "X"),
+				"B": groupField(path.JsonpathKeyRoot, "B", "Y"),
+			},
+		},
+		core.GroupReadOrderOfFields: gojsoncore.JsonArray{"A", "B"},
+	}
+	source := gojsoncore.JsonObject{
+		"A": gojsoncore.JsonArray{gojsoncore.JsonObject{"X": "a1"}, gojsoncore.JsonObject{"X": "a2"}},
+		"B": gojsoncore.JsonArray{gojsoncore.JsonObject{"Y": "b1"}, gojsoncore.JsonObject{"Y": "b2"}},
+	}
+
+	// The first collection varies fastest.
+	expectedRows := [][]string{{"a1", "b1"}, {"a2", "b1"}, {"a1", "b2"}, {"a2", "b2"}}
+
+	rows := make([][]string, 0)
+	for row, err := range NewFlattener(metadataModel).Rows(object.NewObject().WithSourceInterface(source)) {
+		if err != nil {
+			t.Fatalf("Rows() unexpected error: %v", err)
+		}
+		cells := make([]string, 0, len(row))
+		for _, cell := range row {
+			cells = append(cells, fmt.Sprint(cell.Index(0).Interface()))
+		}
+		rows = append(rows, cells)
+
+		// Stopping early must not produce further rows.
+		if len(rows) == len(expectedRows) {
+			break
+		}
+	}
+
+	if !reflect.DeepEqual(rows, expectedRows) {
+		t.Errorf("Rows mismatch.\nExpected: %v\nGot:      %v", expectedRows, rows)
+	}
+}
+
 // --- Test Data Structures ---
 
 type flattenTestData struct {
@@ -477,3 +599,29 @@ func flattenerTestData(yield func(data *flattenTestData) bool) {
 		return
 	}
 }
+
+func TestFlattener_Rows_GetError(t *testing.T) {
+	metadataModel := gojsoncore.JsonObject{
+		core.FieldGroupJsonPathKey: path.JsonpathKeyRoot,
+		core.GroupFields: gojsoncore.JsonArray{
+			gojsoncore.JsonObject{
+				"name": gojsoncore.JsonObject{
+					core.FieldGroupJsonPathKey: path.JsonpathKeyRoot + core.GroupJsonPathPrefix + "name",
+					core.FieldGroupName:        "name",
+					core.FieldDataType:         core.FieldTypeText,
+					core.FieldUI:               core.FieldUiText,
+				},
+			},
+		},
+		core.GroupReadOrderOfFields: gojsoncore.JsonArray{"name"},
+	}
+
+	// Unexported struct fields cannot be read, unlike missing values which are empty columns.
+	for _, err := range NewFlattener(metadataModel).Rows(object.NewObject().WithSourceInterface(testdata.Product{Name: []string{"Twinkies"}})) {
+		if !errors.Is(err, object.ErrPathSegmentInvalidError) {
+			t.Fatalf("expected error %v, got %v", object.ErrPathSegmentInvalidError, err)
+		}
+		return
+	}
+	t.Fatal("expected Rows() to yield an error")
+}
@@ -0,0 +1,340 @@
+package flattener
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"reflect"
+	"strconv"
+
+	gojsoncore "github.com/rogonion/go-json/core"
+	"github.com/rogonion/go-json/object"
+	"github.com/rogonion/go-metadatamodel/core"
+	"github.com/rogonion/go-metadatamodel/fieldcolumns"
+	"github.com/rogonion/go-metadatamodel/internal/xlsx"
+)
+
+/*
+XLSXWriter writes a FlattenedTable as the first worksheet of an .xlsx workbook.
+
+  - The header row is core.FieldGroupName of each fieldcolumns.ColumnField in fieldcolumns.ColumnFields.UnskippedReadOrderOfColumnFields order. It is bold and frozen.
+  - Columns up to the last one with core.FieldGroupViewTableLockColumn set to `true` are frozen.
+  - A cell with a single number or timestamp is written as a number. Timestamps are displayed using core.FieldDatetimeFormat or `yyyy-mm-dd hh:mm:ss` if the format is not set.
+  - A cell with a single boolean is written as a boolean unless the checkbox values are used in view. Refer to CSVWriter.
+  - Values of fields with core.FieldSelectOptions are written as the Label of the matching option and the column gets a dropdown list of the labels.
+  - Other cells are written as text the same way as CSVWriter.
+  - A worksheet holds at most 1,048,576 rows including the header row. XLSXWriter.WriteRow returns ErrRowLimitExceeded for rows beyond that.
+*/
+type XLSXWriter struct {
+	columnFields *fieldcolumns.ColumnFields
+
+	writer io.Writer
+
+	// sheetName of the worksheet. Defaults to `Sheet1`.
+	sheetName string
+
+	// xlsxWriter created when the header row is written.
+	xlsxWriter *xlsx.Writer
+
+	// numberFormatStyles style index of each number format used by timestamp columns.
+	numberFormatStyles map[string]int
+}
+
+// defaultTimestampNumberFormat for timestamps whose field has no core.FieldDatetimeFormat.
+const defaultTimestampNumberFormat = "yyyy-mm-dd hh:mm:ss"
+
+// WithSheetName sets the name of the worksheet. Defaults to `Sheet1`.
+func (n *XLSXWriter) WithSheetName(value string) *XLSXWriter {
+	n.SetSheetName(value)
+	return n
+}
+
+// SetSheetName sets the name of the worksheet. Defaults to `Sheet1`.
+func (n *XLSXWriter) SetSheetName(value string) {
+	n.sheetName = value
+}
+
+// WriteHeader writes the parts of the workbook that precede the rows followed by the header row if it has not been written yet.
+func (n *XLSXWriter) WriteHeader() error {
+	const FunctionName = "WriteHeader"
+
+	if n.xlsxWriter != nil {
+		return nil
+	}
+
+	sheetOptions := xlsx.SheetOptions{
+		Name:       n.sheetName,
+		FrozenRows: 1,
+	}
+	n.numberFormatStyles = make(map[string]int)
+
+	for columnIndex, originalIndex := range n.columnFields.UnskippedReadOrderOfColumnFields {
+		columnField, ok := n.columnFields.GetColumnFieldByIndexInOriginalReadOrder(originalIndex)
+		if !ok {
+			continue
+		}
+
+		if lockColumn, ok := columnField.Property[core.FieldGroupViewTableLockColumn].(bool); ok && lockColumn {
+			sheetOptions.FrozenColumns = columnIndex + 1
+		}
+
+		if fieldDataType, _ := columnField.Property[core.FieldDataType].(string); fieldDataType == core.FieldTypeTimestamp {
+			numberFormat := timestampNumberFormat(columnField.Property)
+			if _, ok := n.numberFormatStyles[numberFormat]; !ok {
+				n.numberFormatStyles[numberFormat] = xlsx.NumberFormatStyle(len(sheetOptions.NumberFormats))
+				sheetOptions.NumberFormats = append(sheetOptions.NumberFormats, numberFormat)
+			}
+		}
+
+		if selectOptions, err := core.AsJsonArray(columnField.Property[core.FieldSelectOptions]); err == nil && len(selectOptions) > 0 {
+			listValidation := xlsx.ListValidation{Column: columnIndex, FirstRow: 2}
+			for _, selectOption := range selectOptions {
+				if selectOptionObject, err := core.AsJsonObject(selectOption); err == nil {
+					listValidation.Options = append(listValidation.Options, selectOptionLabel(selectOptionObject))
+				}
+			}
+			sheetOptions.ListValidations = append(sheetOptions.ListValidations, listValidation)
+		}
+	}
+
+	xlsxWriter, err := xlsx.NewWriter(n.writer, sheetOptions)
+	if err != nil {
+		return NewError().WithFunctionName(FunctionName).WithMessage("create workbook failed").WithNestedError(err)
+	}
+	n.xlsxWriter = xlsxWriter
+
+	header := columnFieldsHeader(n.columnFields)
+	cells := make([]xlsx.Cell, 0, len(header))
+	for _, name := range header {
+		cells = append(cells, xlsx.Cell{Type: xlsx.CellTypeString, Value: name, Style: xlsx.StyleHeader})
+	}
+	if err := n.xlsxWriter.WriteRow(cells); err != nil {
+		return NewError().WithFunctionName(FunctionName).WithMessage("write header failed").WithNestedError(err)
+	}
+	return nil
+}
+
+// WriteRow writes row to the worksheet. The header row is written first if it has not been written yet.
+func (n *XLSXWriter) WriteRow(row FlattenedRow) error {
+	const FunctionName = "WriteRow"
+
+	if err := n.WriteHeader(); err != nil {
+		return err
+	}
+
+	cells := make([]xlsx.Cell, 0, len(n.columnFields.UnskippedReadOrderOfColumnFields))
+	for _, sourceColIndex := range n.columnFields.UnskippedReadOrderOfColumnFields {
+		if sourceColIndex < 0 || sourceColIndex >= len(row) {
+			return NewError().WithFunctionName(FunctionName).
+				WithMessage(fmt.Sprintf("source column index %d out of bounds", sourceColIndex)).
+				WithData(gojsoncore.JsonObject{"RowLength": len(row)})
+		}
+
+		var field gojsoncore.JsonObject
+		if columnField, ok := n.columnFields.GetColumnFieldByIndexInOriginalReadOrder(sourceColIndex); ok {
+			field = columnField.Property
+		}
+
+		cell, err := n.cell(field, row[sourceColIndex])
+		if err != nil {
+			return NewError().WithFunctionName(FunctionName).WithMessage(fmt.Sprintf("convert column %d failed", sourceColIndex)).WithNestedError(err)
+		}
+		cells = append(cells, cell)
+	}
+
+	if err := n.xlsxWriter.WriteRow(cells); err != nil {
+		if errors.Is(err, ErrRowLimitExceeded) {
+			return NewError().WithFunctionName(FunctionName).
+				WithMessage(fmt.Sprintf("worksheet holds at most %d rows including the header row", xlsx.MaxRows)).
+				WithNestedError(err).
+				WithData(gojsoncore.JsonObject{"MaxRows": xlsx.MaxRows})
+		}
+		return NewError().WithFunctionName(FunctionName).WithMessage("write row failed").WithNestedError(err)
+	}
+	return nil
+}
+
+// WriteTable writes the header row followed by every row in table then closes the workbook.
+func (n *XLSXWriter) WriteTable(table FlattenedTable) error {
+	if err := n.WriteHeader(); err != nil {
+		return err
+	}
+
+	for rowIndex, row := range table {
+		if err := n.WriteRow(row); err != nil {
+			return fmt.Errorf("row %d: %w", rowIndex, err)
+		}
+	}
+
+	return n.Close()
+}
+
+// Close writes the remaining parts of the workbook. Does not close the underlying io.Writer.
+func (n *XLSXWriter) Close() error {
+	const FunctionName = "Close"
+
+	if err := n.WriteHeader(); err != nil {
+		return err
+	}
+
+	if err := n.xlsxWriter.Close(); err != nil {
+		return NewError().WithFunctionName(FunctionName).WithMessage("close workbook failed").WithNestedError(err)
+	}
+	return nil
+}
+
+// cell converts a cell in a FlattenedRow to a typed xlsx.Cell.
+func (n *XLSXWriter) cell(field gojsoncore.JsonObject, cell reflect.Value) (xlsx.Cell, error) {
+	values := cellValues(cell, make([]reflect.Value, 0))
+	if len(values) == 0 {
+		return xlsx.Cell{}, nil
+	}
+
+	if len(values) > 1 {
+		valuesAsStrings := make([]string, 0, len(values))
+		for _, value := range values {
+			valueAsString, err := xlsxValueAsString(field, value)
+			if err != nil {
+				return xlsx.Cell{}, err
+			}
+			valuesAsStrings = append(valuesAsStrings, valueAsString)
+		}
+		joinedValues, err := core.JoinFieldValues(field, valuesAsStrings)
+		if err != nil {
+			return xlsx.Cell{}, err
+		}
+		return xlsx.Cell{Type: xlsx.CellTypeString, Value: joinedValues}, nil
+	}
+
+	value := values[0]
+
+	_, isSelectOption := findSelectOption(field, value)
+	_, isCheckboxViewValue := checkboxViewValue(field, value)
+	if !isSelectOption && !isCheckboxViewValue {
+		if typedCell, ok, err := n.typedCell(field, value); err != nil {
+			return xlsx.Cell{}, err
+		} else if ok {
+			return typedCell, nil
+		}
+	}
+
+	valueAsString, err := xlsxValueAsString(field, value)
+	if err != nil {
+		return xlsx.Cell{}, err
+	}
+	joinedValue, err := core.JoinFieldValues(field, []string{valueAsString})
+	if err != nil {
+		return xlsx.Cell{}, err
+	}
+	return xlsx.Cell{Type: xlsx.CellTypeString, Value: joinedValue}, nil
+}
+
+// typedCell returns value as a number or boolean xlsx.Cell. Returns `false` if value is neither a number, boolean, nor timestamp.
+func (n *XLSXWriter) typedCell(field gojsoncore.JsonObject, value reflect.Value) (xlsx.Cell, bool, error) {
+	if fieldDataType, _ := field[core.FieldDataType].(string); fieldDataType == core.FieldTypeTimestamp {
+		timestamp, ok, err := timestampValue(value)
+		if err != nil || !ok {
+			return xlsx.Cell{}, false, err
+		}
+		return xlsx.Cell{
+			Type:  xlsx.CellTypeNumber,
+			Value: strconv.FormatFloat(xlsx.SerialFromTime(timestamp), 'f', -1, 64),
+			Style: n.numberFormatStyles[timestampNumberFormat(field)],
+		}, true, nil
+	}
+
+	switch value.Kind() {
+	case reflect.Bool:
+		if value.Bool() {
+			return xlsx.Cell{Type: xlsx.CellTypeBoolean, Value: "1"}, true, nil
+		}
+		return xlsx.Cell{Type: xlsx.CellTypeBoolean, Value: "0"}, true, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return xlsx.Cell{Type: xlsx.CellTypeNumber, Value: strconv.FormatInt(value.Int(), 10)}, true, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return xlsx.Cell{Type: xlsx.CellTypeNumber, Value: strconv.FormatUint(value.Uint(), 10)}, true, nil
+	case reflect.Float32, reflect.Float64:
+		if !math.IsNaN(value.Float()) && !math.IsInf(value.Float(), 0) {
+			return xlsx.Cell{Type: xlsx.CellTypeNumber, Value: strconv.FormatFloat(value.Float(), 'f', -1, value.Type().Bits())}, true, nil
+		}
+	}
+	return xlsx.Cell{}, false, nil
+}
+
+// NewXLSXWriter creates a new XLSXWriter that writes rows whose columns are described by columnFields to writer.
+func NewXLSXWriter(columnFields *fieldcolumns.ColumnFields, writer io.Writer) *XLSXWriter {
+	n := &XLSXWriter{
+		columnFields: columnFields,
+		writer:       writer,
+	}
+	return n
+}
+
+// WriteXLSX writes the current FlattenedTable to writer as an .xlsx workbook. Refer to XLSXWriter.
+//
+// Call Flattener.Flatten first so that the column fields are set. Returns ErrRowLimitExceeded if the table has more rows than a worksheet can hold.
+func (n *Flattener) WriteXLSX(writer io.Writer) error {
+	const FunctionName = "WriteXLSX"
+
+	if n.columnFields == nil {
+		return NewError().WithFunctionName(FunctionName).WithMessage("column fields not set")
+	}
+
+	return NewXLSXWriter(n.columnFields, writer).WriteTable(n.currentSourceObjectResult)
+}
+
+/*
+StreamXLSX flattens sourceObject one row at a time and writes each row to writer as an .xlsx workbook. Refer to Flattener.WriteRows.
+
+A worksheet holds at most 1,048,576 rows including the header row. If sourceObject produces more, flattening stops and an error that wraps ErrRowLimitExceeded is returned. The workbook in writer is incomplete in that case and should be discarded.
+*/
+func (n *Flattener) StreamXLSX(sourceObject *object.Object, writer io.Writer) error {
+	if err := n.initFieldGroupConversion(); err != nil {
+		return err
+	}
+
+	return n.WriteRows(sourceObject, NewXLSXWriter(n.columnFields, writer))
+}
+
+// xlsxValueAsString returns the Label of the matching core.FieldSelectOptions entry of value or valueAsString.
+func xlsxValueAsString(field gojsoncore.JsonObject, value reflect.Value) (string, error) {
+	if selectOption, ok := findSelectOption(field, value); ok {
+		return selectOptionLabel(selectOption), nil
+	}
+	return valueAsString(field, value)
+}
+
+// findSelectOption returns the entry in core.FieldSelectOptions of field whose Value matches value.
+func findSelectOption(field gojsoncore.JsonObject, value reflect.Value) (gojsoncore.JsonObject, bool) {
+	selectOptions, err := core.AsJsonArray(field[core.FieldSelectOptions])
+	if err != nil {
+		return nil, false
+	}
+
+	valueString := fmt.Sprint(value.Interface())
+	for _, selectOption := range selectOptions {
+		if selectOptionObject, err := core.AsJsonObject(selectOption); err == nil && fmt.Sprint(selectOptionObject[core.Value]) == valueString {
+			return selectOptionObject, true
+		}
+	}
+	return nil, false
+}
+
+// selectOptionLabel returns core.Label of selectOption or its core.Value if the label is not set.
+func selectOptionLabel(selectOption gojsoncore.JsonObject) string {
+	if label, ok := selectOption[core.Label].(string); ok && len(label) > 0 {
+		return label
+	}
+	return fmt.Sprint(selectOption[core.Value])
+}
+
+// timestampNumberFormat returns core.FieldDatetimeFormat of field if it is a known format or defaultTimestampNumberFormat. Formats like core.FieldDatetimeFormatYYYYMMDD are valid spreadsheet number formats.
+func timestampNumberFormat(field gojsoncore.JsonObject) string {
+	if fieldDatetimeFormat, ok := field[core.FieldDatetimeFormat].(string); ok {
+		if _, ok := core.FieldDatetimeFormatLayout(fieldDatetimeFormat); ok {
+			return fieldDatetimeFormat
+		}
+	}
+	return defaultTimestampNumberFormat
+}
@@ -0,0 +1,285 @@
+package flattener
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/brunoga/deep"
+	gojsoncore "github.com/rogonion/go-json/core"
+	"github.com/rogonion/go-json/object"
+	"github.com/rogonion/go-json/path"
+	"github.com/rogonion/go-metadatamodel/core"
+	"github.com/rogonion/go-metadatamodel/internal/xlsx"
+	"github.com/rogonion/go-metadatamodel/testdata"
+)
+
+func TestFlattener_WriteXLSX(t *testing.T) {
+	for data := range xlsxTestData {
+		f := NewFlattener(data.MetadataModel)
+		if err := f.Flatten(data.SourceObject); err != nil {
+			t.Errorf("%s: Flatten() unexpected error: %v", data.TestTitle, err)
+			continue
+		}
+
+		var buffer bytes.Buffer
+		if err := f.WriteXLSX(&buffer); err != nil {
+			t.Errorf("%s: WriteXLSX() unexpected error: %v", data.TestTitle, err)
+			continue
+		}
+
+		reader, err := xlsx.NewReader(bytes.NewReader(buffer.Bytes()), int64(buffer.Len()))
+		if err != nil {
+			t.Errorf("%s: xlsx.NewReader() unexpected error: %v", data.TestTitle, err)
+			continue
+		}
+		rows := make([][]xlsx.Cell, 0)
+		for {
+			row, err := reader.ReadRow()
+			if err != nil {
+				if !errors.Is(err, io.EOF) {
+					t.Errorf("%s: ReadRow() unexpected error: %v", data.TestTitle, err)
+				}
+				break
+			}
+			rows = append(rows, row.Cells)
+		}
+		_ = reader.Close()
+
+		if !reflect.DeepEqual(rows, data.ExpectedRows) {
+			t.Errorf("%s: Rows mismatch.\nExpected: %+v\nGot:      %+v", data.TestTitle, data.ExpectedRows, rows)
+		}
+
+		worksheet := xlsxPart(t, buffer.Bytes(), "xl/worksheets/sheet1.xml")
+		for _, expected := range data.ExpectedWorksheetContains {
+			if !strings.Contains(worksheet, expected) {
+				t.Errorf("%s: expected worksheet to contain %s\nGot: %s", data.TestTitle, expected, worksheet)
+			}
+		}
+	}
+}
+
+func TestFlattener_WriteXLSX_NotFlattened(t *testing.T) {
+	var buffer bytes.Buffer
+	if err := NewFlattener(testdata.UserMetadataModel(nil)).WriteXLSX(&buffer); err == nil {
+		t.Error("expected error when writing xlsx before Flatten")
+	}
+}
+
+func TestFlattener_WriteRows_RowLimit(t *testing.T) {
+	f := NewFlattener(xlsxMetadataModel())
+	if err := f.initFieldGroupConversion(); err != nil {
+		t.Fatalf("initFieldGroupConversion() unexpected error: %v", err)
+	}
+	rowWriter := &limitedRowWriter{RowWriter: NewXLSXWriter(f.columnFields, io.Discard), limit: 2}
+
+	err := f.WriteRows(object.NewObject().WithSourceInterface([]any{
+		gojsoncore.JsonObject{"Code": gojsoncore.JsonArray{1}},
+		gojsoncore.JsonObject{"Code": gojsoncore.JsonArray{2}},
+		gojsoncore.JsonObject{"Code": gojsoncore.JsonArray{3}},
+	}), rowWriter)
+	if !errors.Is(err, ErrRowLimitExceeded) {
+		t.Fatalf("expected error %v, got %v", ErrRowLimitExceeded, err)
+	}
+	if rowWriter.written != rowWriter.limit {
+		t.Errorf("expected %d rows written, got %d", rowWriter.limit, rowWriter.written)
+	}
+	if rowWriter.closed {
+		t.Error("expected row writer not to be closed after the row limit was exceeded")
+	}
+}
+
+// limitedRowWriter returns ErrRowLimitExceeded once limit rows have been written to RowWriter, like XLSXWriter does once a worksheet is full.
+type limitedRowWriter struct {
+	RowWriter
+	limit   int
+	written int
+	closed  bool
+}
+
+func (n *limitedRowWriter) WriteRow(row FlattenedRow) error {
+	if n.written >= n.limit {
+		return NewError().WithFunctionName("WriteRow").WithMessage("worksheet full").WithNestedError(ErrRowLimitExceeded)
+	}
+	if err := n.RowWriter.WriteRow(row); err != nil {
+		return err
+	}
+	n.written++
+	return nil
+}
+
+func (n *limitedRowWriter) Close() error {
+	n.closed = true
+	return n.RowWriter.Close()
+}
+
+// xlsxPart returns the contents of the file called name in the .xlsx workbook.
+func xlsxPart(t *testing.T, workbook []byte, name string) string {
+	zipReader, err := zip.NewReader(bytes.NewReader(workbook), int64(len(workbook)))
+	if err != nil {
+		t.Fatalf("zip.NewReader() unexpected error: %v", err)
+	}
+	file, err := zipReader.Open(name)
+	if err != nil {
+		t.Fatalf("open %s unexpected error: %v", name, err)
+	}
+	defer file.Close()
+	contents, err := io.ReadAll(file)
+	if err != nil {
+		t.Fatalf("read %s unexpected error: %v", name, err)
+	}
+	return string(contents)
+}
+
+// --- Test Data Structures ---
+
+type xlsxData struct {
+	TestTitle                 string
+	SourceObject              *object.Object
+	MetadataModel             gojsoncore.JsonObject
+	ExpectedRows              [][]xlsx.Cell
+	ExpectedWorksheetContains []string
+}
+
+func xlsxTestData(yield func(data *xlsxData) bool) {
+	header := func(names ...string) []xlsx.Cell {
+		cells := make([]xlsx.Cell, 0, len(names))
+		for _, name := range names {
+			cells = append(cells, xlsx.Cell{Type: xlsx.CellTypeString, Value: name})
+		}
+		return cells
+	}
+
+	// -------------------------------------------------------------------------
+	// Case 1: Deep Nested Object (Employee -> Profile -> Address)
+	// -------------------------------------------------------------------------
+	empData := testdata.Employee{
+		ID:     []int{500},
+		Skills: []string{"Go", "Rust"},
+		Profile: []*testdata.UserProfile{
+			{
+				Name:    []string{"Bob"},
+				Age:     []int{30},
+				Address: []testdata.Address{{Street: []string{"1 Main St"}, City: []string{"Nairobi"}}},
+			},
+		},
+	}
+
+	if !yield(&xlsxData{
+		TestTitle:     "Deep Nested Employee",
+		SourceObject:  object.NewObject().WithSourceInterface(empData),
+		MetadataModel: testdata.EmployeeMetadataModel(nil),
+		ExpectedRows: [][]xlsx.Cell{
+			header("ID", "Name", "Age", "Street", "City", "ZipCode", "Skills"),
+			{
+				{Type: xlsx.CellTypeNumber, Value: "500"},
+				{Type: xlsx.CellTypeString, Value: "Bob"},
+				{Type: xlsx.CellTypeNumber, Value: "30"},
+				{Type: xlsx.CellTypeString, Value: "1 Main St"},
+				{Type: xlsx.CellTypeString, Value: "Nairobi"},
+				{},
+				{Type: xlsx.CellTypeString, Value: "Go,Rust"},
+			},
+		},
+		ExpectedWorksheetContains: []string{`ySplit="1" topLeftCell="A2" activePane="bottomLeft" state="frozen"`},
+	}) {
+		return
+	}
+
+	// -------------------------------------------------------------------------
+	// Case 2: Lock columns, select options, timestamps, and checkboxes
+	// -------------------------------------------------------------------------
+	if !yield(&xlsxData{
+		TestTitle: "Lock Columns, Select Options, Timestamps, and Checkboxes",
+		SourceObject: object.NewObject().WithSourceInterface([]any{
+			gojsoncore.JsonObject{
+				"Code":      gojsoncore.JsonArray{7},
+				"Status":    gojsoncore.JsonArray{"A"},
+				"Published": gojsoncore.JsonArray{time.Date(2024, 3, 5, 12, 0, 0, 0, time.UTC)},
+				"Active":    gojsoncore.JsonArray{true},
+				"Verified":  gojsoncore.JsonArray{false},
+			},
+			gojsoncore.JsonObject{
+				"Code":   gojsoncore.JsonArray{8},
+				"Status": gojsoncore.JsonArray{"X"},
+			},
+		}),
+		MetadataModel: xlsxMetadataModel(),
+		ExpectedRows: [][]xlsx.Cell{
+			header("Code", "Status", "Published", "Active", "Verified"),
+			{
+				{Type: xlsx.CellTypeNumber, Value: "7"},
+				{Type: xlsx.CellTypeString, Value: "Active"},
+				{Type: xlsx.CellTypeNumber, Value: "45356.5"},
+				{Type: xlsx.CellTypeString, Value: "yes"},
+				{Type: xlsx.CellTypeBoolean, Value: "0"},
+			},
+			{
+				{Type: xlsx.CellTypeNumber, Value: "8"},
+				{Type: xlsx.CellTypeString, Value: "X"},
+			},
+		},
+		ExpectedWorksheetContains: []string{
+			`xSplit="1" ySplit="1" topLeftCell="B2" activePane="bottomRight" state="frozen"`,
+			`<c r="C2" s="2"><v>45356.5</v></c>`,
+			`<dataValidation type="list" allowBlank="1" showErrorMessage="1" sqref="B2:B1048576"><formula1>&#39;_Options&#39;!$A$1:$A$2</formula1></dataValidation>`,
+		},
+	}) {
+		return
+	}
+}
+
+func xlsxMetadataModel() gojsoncore.JsonObject {
+	return deep.MustCopy(gojsoncore.JsonObject{
+		core.FieldGroupJsonPathKey: path.JsonpathKeyRoot,
+		core.GroupFields: gojsoncore.JsonArray{
+			gojsoncore.JsonObject{
+				"Code": gojsoncore.JsonObject{
+					core.FieldGroupJsonPathKey:         path.JsonpathKeyRoot + core.GroupJsonPathPrefix + "Code",
+					core.FieldGroupName:                "Code",
+					core.FieldDataType:                 core.FieldTypeNumber,
+					core.FieldUI:                       core.FieldUiNumber,
+					core.FieldGroupViewTableLockColumn: true,
+				},
+				"Status": gojsoncore.JsonObject{
+					core.FieldGroupJsonPathKey: path.JsonpathKeyRoot + core.GroupJsonPathPrefix + "Status",
+					core.FieldGroupName:        "Status",
+					core.FieldDataType:         core.FieldTypeText,
+					core.FieldUI:               core.FieldUiSelect,
+					core.FieldSelectOptions: gojsoncore.JsonArray{
+						gojsoncore.JsonObject{core.Label: "Active", core.Type: core.FieldTypeText, core.Value: "A"},
+						gojsoncore.JsonObject{core.Label: "Inactive", core.Type: core.FieldTypeText, core.Value: "I"},
+					},
+				},
+				"Published": gojsoncore.JsonObject{
+					core.FieldGroupJsonPathKey: path.JsonpathKeyRoot + core.GroupJsonPathPrefix + "Published",
+					core.FieldGroupName:        "Published",
+					core.FieldDataType:         core.FieldTypeTimestamp,
+					core.FieldUI:               core.FieldUiDatetime,
+					core.FieldDatetimeFormat:   core.FieldDatetimeFormatYYYYMMDD,
+				},
+				"Active": gojsoncore.JsonObject{
+					core.FieldGroupJsonPathKey:        path.JsonpathKeyRoot + core.GroupJsonPathPrefix + "Active",
+					core.FieldGroupName:               "Active",
+					core.FieldDataType:                core.FieldTypeBoolean,
+					core.FieldUI:                      core.FieldUiCheckbox,
+					core.FieldCheckboxValuesUseInView: true,
+					core.FieldCheckboxValueIfTrue:     gojsoncore.JsonObject{core.Type: core.FieldTypeText, core.Value: "yes"},
+					core.FieldCheckboxValueIfFalse:    gojsoncore.JsonObject{core.Type: core.FieldTypeText, core.Value: "no"},
+				},
+				"Verified": gojsoncore.JsonObject{
+					core.FieldGroupJsonPathKey: path.JsonpathKeyRoot + core.GroupJsonPathPrefix + "Verified",
+					core.FieldGroupName:        "Verified",
+					core.FieldDataType:         core.FieldTypeBoolean,
+					core.FieldUI:               core.FieldUiCheckbox,
+				},
+			},
+		},
+		core.GroupReadOrderOfFields: gojsoncore.JsonArray{"Code", "Status", "Published", "Active", "Verified"},
+	})
+}
@@ -0,0 +1,62 @@
+package fulltextsearch
+
+import (
+	"errors"
+
+	"github.com/rogonion/go-json/path"
+	"github.com/rogonion/go-metadatamodel/core"
+)
+
+// Ranking algorithms supported by Index.Search.
+const (
+	// RankingBM25 ranks records using Okapi BM25. Default.
+	RankingBM25 string = "BM25"
+	// RankingTFIDF ranks records using term frequency–inverse document frequency.
+	RankingTFIDF string = "TFIDF"
+)
+
+// Default BM25 parameters.
+const (
+	DefaultBM25K1 float64 = 1.2
+	DefaultBM25B  float64 = 0.75
+)
+
+// SearchResult is a record that matched a full text search query.
+type SearchResult struct {
+	// Index of the record in the source data.
+	RecordIndex int
+
+	// Relevance of the record to the query. Higher is more relevant.
+	Score float64
+
+	// core.FieldGroupJsonPathKey of fields in the record in which at least one query term was found.
+	//
+	// Follows the read order of fields in the metadata model.
+	FieldGroupJsonPathKeys []path.JSONPath
+}
+
+// SearchResults is sorted by SearchResult.Score in descending order.
+type SearchResults []SearchResult
+
+// RecordIndexes returns SearchResult.RecordIndex of each result in order.
+func (n SearchResults) RecordIndexes() []int {
+	recordIndexes := make([]int, len(n))
+	for i, result := range n {
+		recordIndexes[i] = result.RecordIndex
+	}
+	return recordIndexes
+}
+
+var (
+	// ErrFullTextSearchError default error for full text search module.
+	ErrFullTextSearchError = errors.New("full text search encountered an error")
+
+	// ErrRecordAlreadyIndexed for when Index.Add is called with a record index that is already in the index.
+	ErrRecordAlreadyIndexed = errors.New("record already indexed")
+)
+
+// NewError creates a new core.Error with the default full text search error base.
+func NewError() *core.Error {
+	n := core.NewError().WithDefaultBaseError(ErrFullTextSearchError)
+	return n
+}
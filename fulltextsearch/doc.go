@@ -0,0 +1,45 @@
+/*
+Package fulltextsearch provides an in-memory inverted index for searching through source data whose structure is defined by a metadata model.
+
+Only fields with core.DatabaseFieldAddDataToFullTextSearchIndex set to `true` are indexed. Terms are keyed by record index and core.FieldGroupJsonPathKey of the field they were found in.
+
+It can perform the following tasks:
+  - Build an index from a slice or array of records.
+  - Incrementally add, update, and remove records.
+  - Rank records matching a query using BM25 (default) or TF-IDF.
+  - Restrict a search to a subset of indexed fields.
+
+Text is tokenized using filter.FullTextSearchTokens, the same tokenizer used by the filter.FilterConditionFullTextSearchQuery condition.
+
+# Usage
+
+	import (
+		gojsoncore "github.com/rogonion/go-json/core"
+		"github.com/rogonion/go-json/object"
+		"github.com/rogonion/go-metadatamodel/fulltextsearch"
+	)
+
+## Building the Index
+
+	var metadataModel gojsoncore.JsonObject // ... load metadata model
+
+	// Set other properties using builder pattern 'With' or 'Set'. Refer to fulltextsearch.Index structure.
+	index := fulltextsearch.NewIndex(metadataModel)
+
+	var sourceData []any // ... records
+	err := index.AddAll(object.NewObject().WithSourceInterface(sourceData))
+
+## Searching
+
+	results := index.Search("nairobi mombasa")
+
+	// Record indexes ordered by relevance.
+	recordIndexes := results.RecordIndexes()
+
+## Keeping the Index Up to Date
+
+	err = index.Add(10, newRecord)
+	err = index.Update(2, modifiedRecord)
+	removed := index.Remove(3)
+*/
+package fulltextsearch
@@ -0,0 +1,453 @@
+package fulltextsearch
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"slices"
+	"sort"
+
+	gojsoncore "github.com/rogonion/go-json/core"
+	"github.com/rogonion/go-json/object"
+	"github.com/rogonion/go-json/path"
+	"github.com/rogonion/go-metadatamodel/core"
+	"github.com/rogonion/go-metadatamodel/filter"
+	"github.com/rogonion/go-metadatamodel/iter"
+)
+
+/*
+Search returns records that contain at least one token in query ranked by relevance.
+
+Query is tokenized using filter.FullTextSearchTokens.
+
+Parameters:
+  - query - text to search for.
+  - fieldGroupJsonPathKeys - Optional. Restrict the search to the fields with these core.FieldGroupJsonPathKey. Searches all indexed fields if empty.
+
+Returns results sorted by SearchResult.Score in descending order. Results with equal scores are sorted by SearchResult.RecordIndex in ascending order.
+*/
+func (n *Index) Search(query string, fieldGroupJsonPathKeys ...path.JSONPath) SearchResults {
+	results := make(SearchResults, 0)
+
+	noOfRecords := len(n.records)
+	if noOfRecords == 0 {
+		return results
+	}
+
+	fieldIncluded := func(fieldGroupJsonPathKey path.JSONPath) bool {
+		return len(fieldGroupJsonPathKeys) == 0 || slices.Contains(fieldGroupJsonPathKeys, fieldGroupJsonPathKey)
+	}
+
+	totalNoOfTokens := 0
+	for fieldGroupJsonPathKey, noOfTokens := range n.totalFieldNoOfTokens {
+		if fieldIncluded(fieldGroupJsonPathKey) {
+			totalNoOfTokens += noOfTokens
+		}
+	}
+	averageNoOfTokens := float64(totalNoOfTokens) / float64(noOfRecords)
+
+	scores := make(map[int]float64)
+	matchedFields := make(map[int]map[path.JSONPath]bool)
+	queryTerms := make(map[string]bool)
+	for _, term := range filter.FullTextSearchTokens(query, n.caseInsensitive) {
+		if queryTerms[term] {
+			continue
+		}
+		queryTerms[term] = true
+
+		termFrequencies := make(map[int]int)
+		for recordIndex, fields := range n.postings[term] {
+			for fieldGroupJsonPathKey, frequency := range fields {
+				if !fieldIncluded(fieldGroupJsonPathKey) {
+					continue
+				}
+				termFrequencies[recordIndex] += frequency
+				if _, ok := matchedFields[recordIndex]; !ok {
+					matchedFields[recordIndex] = make(map[path.JSONPath]bool)
+				}
+				matchedFields[recordIndex][fieldGroupJsonPathKey] = true
+			}
+		}
+		if len(termFrequencies) == 0 {
+			continue
+		}
+
+		for recordIndex, termFrequency := range termFrequencies {
+			switch n.ranking {
+			case RankingTFIDF:
+				idf := math.Log(1 + float64(noOfRecords)/float64(len(termFrequencies)))
+				scores[recordIndex] += (1 + math.Log(float64(termFrequency))) * idf
+			default:
+				idf := math.Log(1 + (float64(noOfRecords)-float64(len(termFrequencies))+0.5)/(float64(len(termFrequencies))+0.5))
+				noOfTokens := 0
+				for fieldGroupJsonPathKey, fieldNoOfTokens := range n.records[recordIndex] {
+					if fieldIncluded(fieldGroupJsonPathKey) {
+						noOfTokens += fieldNoOfTokens
+					}
+				}
+				lengthNormalization := 1 - n.bm25B
+				if averageNoOfTokens > 0 {
+					lengthNormalization += n.bm25B * float64(noOfTokens) / averageNoOfTokens
+				}
+				scores[recordIndex] += idf * (float64(termFrequency) * (n.bm25K1 + 1)) / (float64(termFrequency) + n.bm25K1*lengthNormalization)
+			}
+		}
+	}
+
+	for recordIndex, score := range scores {
+		result := SearchResult{
+			RecordIndex:            recordIndex,
+			Score:                  score,
+			FieldGroupJsonPathKeys: make([]path.JSONPath, 0),
+		}
+		for _, field := range n.indexedFields {
+			if matchedFields[recordIndex][field.FieldGroupJsonPathKey] {
+				result.FieldGroupJsonPathKeys = append(result.FieldGroupJsonPathKeys, field.FieldGroupJsonPathKey)
+			}
+		}
+		results = append(results, result)
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
+		return results[i].RecordIndex < results[j].RecordIndex
+	})
+
+	return results
+}
+
+/*
+AddAll adds each record in sourceData to the index.
+
+The value in sourceData must be a slice or array. The index of each record in sourceData is used as its record index.
+*/
+func (n *Index) AddAll(sourceData *object.Object) error {
+	const FunctionName = "AddAll"
+
+	sourceValue := sourceData.GetSourceReflected()
+	for sourceValue.Kind() == reflect.Interface || sourceValue.Kind() == reflect.Pointer {
+		if sourceValue.IsNil() {
+			return NewError().WithFunctionName(FunctionName).WithMessage("source data is nil")
+		}
+		sourceValue = sourceValue.Elem()
+	}
+	if sourceValue.Kind() != reflect.Slice && sourceValue.Kind() != reflect.Array {
+		return NewError().WithFunctionName(FunctionName).WithMessage("source data should be slice or array")
+	}
+
+	for recordIndex := 0; recordIndex < sourceValue.Len(); recordIndex++ {
+		if err := n.addReflected(recordIndex, sourceValue.Index(recordIndex)); err != nil {
+			return NewError().WithFunctionName(FunctionName).WithMessage("add record failed").WithData(gojsoncore.JsonObject{"RecordIndex": recordIndex}).WithNestedError(err)
+		}
+	}
+
+	return nil
+}
+
+/*
+Add adds record to the index.
+
+Parameters:
+  - recordIndex - unique identifier of the record, typically its index in the source data.
+  - record - value whose structure is defined by the metadata model.
+
+Returns ErrRecordAlreadyIndexed if recordIndex is already in the index. Use Index.Update instead.
+*/
+func (n *Index) Add(recordIndex int, record any) error {
+	return n.addReflected(recordIndex, reflect.ValueOf(record))
+}
+
+// Update replaces the indexed record at recordIndex with record. Works like Index.Add if recordIndex is not yet in the index.
+func (n *Index) Update(recordIndex int, record any) error {
+	n.Remove(recordIndex)
+	return n.addReflected(recordIndex, reflect.ValueOf(record))
+}
+
+/*
+Remove removes the record at recordIndex from the index.
+
+Record indexes of other records are not shifted.
+
+Returns `true` if the record was in the index.
+*/
+func (n *Index) Remove(recordIndex int) bool {
+	if _, ok := n.records[recordIndex]; !ok {
+		return false
+	}
+
+	for _, term := range n.recordTerms[recordIndex] {
+		delete(n.postings[term], recordIndex)
+		if len(n.postings[term]) == 0 {
+			delete(n.postings, term)
+		}
+	}
+
+	for fieldGroupJsonPathKey, noOfTokens := range n.records[recordIndex] {
+		n.totalFieldNoOfTokens[fieldGroupJsonPathKey] -= noOfTokens
+	}
+
+	delete(n.records, recordIndex)
+	delete(n.recordTerms, recordIndex)
+	return true
+}
+
+// Len returns the number of records in the index.
+func (n *Index) Len() int {
+	return len(n.records)
+}
+
+// Reset removes all records from the index.
+func (n *Index) Reset() {
+	n.postings = make(map[string]map[int]map[path.JSONPath]int)
+	n.records = make(map[int]map[path.JSONPath]int)
+	n.recordTerms = make(map[int][]string)
+	n.totalFieldNoOfTokens = make(map[path.JSONPath]int)
+}
+
+// IndexedFields returns core.FieldGroupJsonPathKey of fields whose data is added to the index.
+func (n *Index) IndexedFields() ([]path.JSONPath, error) {
+	const FunctionName = "IndexedFields"
+
+	if err := n.initIndexedFields(); err != nil {
+		return nil, NewError().WithFunctionName(FunctionName).WithMessage("init indexed fields failed").WithNestedError(err)
+	}
+
+	fieldGroupJsonPathKeys := make([]path.JSONPath, len(n.indexedFields))
+	for i, field := range n.indexedFields {
+		fieldGroupJsonPathKeys[i] = field.FieldGroupJsonPathKey
+	}
+	return fieldGroupJsonPathKeys, nil
+}
+
+func (n *Index) addReflected(recordIndex int, record reflect.Value) error {
+	const FunctionName = "addReflected"
+
+	if _, ok := n.records[recordIndex]; ok {
+		return NewError().WithFunctionName(FunctionName).WithMessage(fmt.Sprintf("record at index %d already indexed", recordIndex)).WithNestedError(ErrRecordAlreadyIndexed)
+	}
+
+	if err := n.initIndexedFields(); err != nil {
+		return NewError().WithFunctionName(FunctionName).WithMessage("init indexed fields failed").WithNestedError(err)
+	}
+
+	recordObject := object.NewObject().WithSourceReflected(record)
+	fieldsNoOfTokens := make(map[path.JSONPath]int)
+	terms := make([]string, 0)
+	for _, field := range n.indexedFields {
+		tokens := make([]string, 0)
+		recordObject.ForEach(field.JsonPathToValue, func(_ path.RecursiveDescentSegment, value reflect.Value) bool {
+			tokens = n.appendTokens(tokens, value)
+			return false
+		})
+		if len(tokens) == 0 {
+			continue
+		}
+
+		fieldsNoOfTokens[field.FieldGroupJsonPathKey] = len(tokens)
+		n.totalFieldNoOfTokens[field.FieldGroupJsonPathKey] += len(tokens)
+
+		for _, token := range tokens {
+			if _, ok := n.postings[token]; !ok {
+				n.postings[token] = make(map[int]map[path.JSONPath]int)
+			}
+			if _, ok := n.postings[token][recordIndex]; !ok {
+				n.postings[token][recordIndex] = make(map[path.JSONPath]int)
+				terms = append(terms, token)
+			}
+			n.postings[token][recordIndex][field.FieldGroupJsonPathKey]++
+		}
+	}
+
+	n.records[recordIndex] = fieldsNoOfTokens
+	n.recordTerms[recordIndex] = terms
+	return nil
+}
+
+// appendTokens appends the tokens of each primitive value in value to tokens.
+func (n *Index) appendTokens(tokens []string, value reflect.Value) []string {
+	if !value.IsValid() {
+		return tokens
+	}
+
+	switch value.Kind() {
+	case reflect.Interface, reflect.Pointer:
+		if !value.IsNil() {
+			return n.appendTokens(tokens, value.Elem())
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < value.Len(); i++ {
+			tokens = n.appendTokens(tokens, value.Index(i))
+		}
+	case reflect.String:
+		tokens = append(tokens, filter.FullTextSearchTokens(value.String(), n.caseInsensitive)...)
+	case reflect.Map, reflect.Struct, reflect.Chan, reflect.Func, reflect.UnsafePointer:
+	default:
+		tokens = append(tokens, filter.FullTextSearchTokens(fmt.Sprint(value.Interface()), n.caseInsensitive)...)
+	}
+	return tokens
+}
+
+// initIndexedFields collects fields in metadataModel with core.DatabaseFieldAddDataToFullTextSearchIndex set to `true` if not yet done.
+func (n *Index) initIndexedFields() error {
+	const FunctionName = "initIndexedFields"
+
+	if n.indexedFields != nil {
+		return nil
+	}
+
+	indexedFields := make([]indexedField, 0)
+	var forEachError error
+	iter.ForEach(n.metadataModel, func(fieldGroup gojsoncore.JsonObject) (bool, bool) {
+		// iter.ForEach does not propagate termination from nested groups.
+		if forEachError != nil {
+			return true, true
+		}
+
+		if core.IsFieldAGroup(fieldGroup) {
+			return false, false
+		}
+
+		if value, ok := fieldGroup[core.DatabaseFieldAddDataToFullTextSearchIndex].(bool); !ok || !value {
+			return false, false
+		}
+
+		fieldGroupJsonPathKey, err := core.AsJSONPath(fieldGroup[core.FieldGroupJsonPathKey])
+		if err != nil {
+			forEachError = NewError().WithFunctionName(FunctionName).WithMessage("get field FieldGroupJsonPathKey failed").WithData(gojsoncore.JsonObject{"FieldGroup": fieldGroup}).WithNestedError(err)
+			return true, true
+		}
+
+		jsonPathToValue, err := core.NewJsonPathToValue().WithReplaceArrayPathPlaceholderWithActualIndexes(false).Get(fieldGroupJsonPathKey, nil)
+		if err != nil {
+			forEachError = NewError().WithFunctionName(FunctionName).WithMessage("get field json path to value failed").WithData(gojsoncore.JsonObject{"FieldGroupJsonPathKey": fieldGroupJsonPathKey}).WithNestedError(err)
+			return true, true
+		}
+
+		indexedFields = append(indexedFields, indexedField{
+			FieldGroupJsonPathKey: fieldGroupJsonPathKey,
+			JsonPathToValue:       jsonPathToValue,
+		})
+		return false, false
+	})
+	if forEachError != nil {
+		return forEachError
+	}
+
+	n.indexedFields = indexedFields
+	return nil
+}
+
+// WithMetadataModel sets the metadata model and returns the Index.
+func (n *Index) WithMetadataModel(value gojsoncore.JsonObject) *Index {
+	n.SetMetadataModel(value)
+	return n
+}
+
+// SetMetadataModel sets the metadata model and removes all records from the index.
+func (n *Index) SetMetadataModel(value gojsoncore.JsonObject) {
+	n.metadataModel = value
+	n.indexedFields = nil
+	n.Reset()
+}
+
+// WithCaseInsensitive sets whether tokens are case folded and returns the Index.
+func (n *Index) WithCaseInsensitive(value bool) *Index {
+	n.SetCaseInsensitive(value)
+	return n
+}
+
+// SetCaseInsensitive sets whether tokens are case folded. Should be set before records are added.
+func (n *Index) SetCaseInsensitive(value bool) {
+	n.caseInsensitive = value
+}
+
+// WithRanking sets the ranking algorithm and returns the Index.
+func (n *Index) WithRanking(value string) *Index {
+	n.SetRanking(value)
+	return n
+}
+
+// SetRanking sets the ranking algorithm. Can be RankingBM25 or RankingTFIDF.
+func (n *Index) SetRanking(value string) {
+	n.ranking = value
+}
+
+// WithBM25Parameters sets the BM25 parameters and returns the Index.
+func (n *Index) WithBM25Parameters(k1 float64, b float64) *Index {
+	n.SetBM25Parameters(k1, b)
+	return n
+}
+
+/*
+SetBM25Parameters sets the BM25 parameters.
+
+Parameters:
+  - k1 - term frequency saturation. Default DefaultBM25K1.
+  - b - document length normalization. Default DefaultBM25B.
+*/
+func (n *Index) SetBM25Parameters(k1 float64, b float64) {
+	n.bm25K1 = k1
+	n.bm25B = b
+}
+
+/*
+NewIndex
+
+Parameters:
+  - metadataModel - Metadata model of each record in the index. Only fields with core.DatabaseFieldAddDataToFullTextSearchIndex set to `true` are indexed.
+
+Defaults to case insensitive RankingBM25.
+*/
+func NewIndex(metadataModel gojsoncore.JsonObject) *Index {
+	n := new(Index)
+	n.caseInsensitive = true
+	n.ranking = RankingBM25
+	n.bm25K1 = DefaultBM25K1
+	n.bm25B = DefaultBM25B
+	n.SetMetadataModel(metadataModel)
+	return n
+}
+
+/*
+Index is an in-memory inverted index of records whose structure is defined by a metadata model.
+
+Records are keyed by their record index while terms are further keyed by core.FieldGroupJsonPathKey of the field they were found in.
+
+Not safe for concurrent use.
+*/
+type Index struct {
+	metadataModel gojsoncore.JsonObject
+
+	// If `true`, tokens are case folded to lower case.
+	caseInsensitive bool
+
+	// RankingBM25 or RankingTFIDF.
+	ranking string
+
+	bm25K1 float64
+	bm25B  float64
+
+	// Fields in metadataModel to index. Set by Index.initIndexedFields.
+	indexedFields []indexedField
+
+	// Term -> record index -> core.FieldGroupJsonPathKey -> no. of occurrences of term.
+	postings map[string]map[int]map[path.JSONPath]int
+
+	// Record index -> core.FieldGroupJsonPathKey -> no. of tokens in field.
+	records map[int]map[path.JSONPath]int
+
+	// Record index -> unique terms in record. Used by Index.Remove.
+	recordTerms map[int][]string
+
+	// core.FieldGroupJsonPathKey -> total no. of tokens in field across all records.
+	totalFieldNoOfTokens map[path.JSONPath]int
+}
+
+type indexedField struct {
+	FieldGroupJsonPathKey path.JSONPath
+
+	// Path to field value in a record.
+	JsonPathToValue path.JSONPath
+}
@@ -0,0 +1,189 @@
+package fulltextsearch
+
+import (
+	"reflect"
+	"testing"
+
+	gojsoncore "github.com/rogonion/go-json/core"
+	"github.com/rogonion/go-json/object"
+	"github.com/rogonion/go-json/path"
+	"github.com/rogonion/go-metadatamodel/core"
+	"github.com/rogonion/go-metadatamodel/internal"
+	"github.com/rogonion/go-metadatamodel/testdata"
+)
+
+func TestFullTextSearch_IndexSearch(t *testing.T) {
+	for testData := range indexSearchTestData {
+		index := NewIndex(testData.MetadataModel).WithRanking(testData.Ranking)
+		if err := index.AddAll(testData.Object); err != nil {
+			t.Fatal(testData.TestTitle, "\n", "add all failed:", err)
+		}
+
+		res := index.Search(testData.Query, testData.FieldGroupJsonPathKeys...)
+		if !reflect.DeepEqual(res.RecordIndexes(), testData.ExpectedRecordIndexes) {
+			t.Error(
+				testData.TestTitle, "\n",
+				"expected res record indexes to be equal to testData.ExpectedRecordIndexes\n",
+				"ExpectedRecordIndexes=", gojsoncore.JsonStringifyMust(testData.ExpectedRecordIndexes), "\n",
+				"res=", gojsoncore.JsonStringifyMust(res),
+			)
+		}
+	}
+}
+
+func TestFullTextSearch_IndexUpdateRemove(t *testing.T) {
+	index := NewIndex(userProfileFullTextSearchMetadataModel())
+	if err := index.AddAll(userProfilesObject()); err != nil {
+		t.Fatal("add all failed:", err)
+	}
+
+	if err := index.Add(0, &testdata.UserProfile{}); err == nil {
+		t.Error("expected adding an already indexed record to fail")
+	}
+
+	if err := index.Update(0, &testdata.UserProfile{Name: []string{"Kamau Nairobi"}}); err != nil {
+		t.Fatal("update failed:", err)
+	}
+	if res := index.Search("kamau").RecordIndexes(); !reflect.DeepEqual(res, []int{0}) {
+		t.Error("expected updated record to be found\n", "res=", res)
+	}
+	if res := index.Search("wanjiku").RecordIndexes(); len(res) != 0 {
+		t.Error("expected terms of replaced record to be removed\n", "res=", res)
+	}
+
+	if !index.Remove(1) {
+		t.Error("expected record 1 to be removed")
+	}
+	if index.Remove(1) {
+		t.Error("expected record 1 to no longer be in index")
+	}
+	if res := index.Search("otieno").RecordIndexes(); len(res) != 0 {
+		t.Error("expected no results after removing record\n", "res=", res)
+	}
+	if index.Len() != 2 {
+		t.Error("expected index to have 2 records\n", "Len=", index.Len())
+	}
+}
+
+type indexSearchData struct {
+	internal.TestData
+	Object                 *object.Object
+	MetadataModel          gojsoncore.JsonObject
+	Ranking                string
+	Query                  string
+	FieldGroupJsonPathKeys []path.JSONPath
+	ExpectedRecordIndexes  []int
+}
+
+func indexSearchTestData(yield func(data *indexSearchData) bool) {
+	testCaseIndex := 1
+	if !yield(
+		&indexSearchData{
+			TestData: internal.TestData{
+				TestTitle: "User Profile - BM25 ranks record with more matches in shorter fields first",
+			},
+			Object:                userProfilesObject(),
+			MetadataModel:         userProfileFullTextSearchMetadataModel(),
+			Ranking:               RankingBM25,
+			Query:                 "Mombasa",
+			ExpectedRecordIndexes: []int{1, 2},
+		},
+	) {
+		return
+	}
+
+	testCaseIndex++
+	if !yield(
+		&indexSearchData{
+			TestData: internal.TestData{
+				TestTitle: "User Profile - TFIDF with multiple terms",
+			},
+			Object:                userProfilesObject(),
+			MetadataModel:         userProfileFullTextSearchMetadataModel(),
+			Ranking:               RankingTFIDF,
+			Query:                 "wanjiku nairobi",
+			ExpectedRecordIndexes: []int{0, 2},
+		},
+	) {
+		return
+	}
+
+	testCaseIndex++
+	if !yield(
+		&indexSearchData{
+			TestData: internal.TestData{
+				TestTitle: "User Profile - Search restricted to Name field",
+			},
+			Object:                 userProfilesObject(),
+			MetadataModel:          userProfileFullTextSearchMetadataModel(),
+			Ranking:                RankingBM25,
+			Query:                  "mombasa",
+			FieldGroupJsonPathKeys: []path.JSONPath{path.JSONPath(path.JsonpathKeyRoot + core.GroupJsonPathPrefix + "Name")},
+			ExpectedRecordIndexes:  []int{},
+		},
+	) {
+		return
+	}
+
+	testCaseIndex++
+	if !yield(
+		&indexSearchData{
+			TestData: internal.TestData{
+				TestTitle: "User Profile - Street not indexed",
+			},
+			Object:                userProfilesObject(),
+			MetadataModel:         userProfileFullTextSearchMetadataModel(),
+			Ranking:               RankingBM25,
+			Query:                 "Moi Avenue",
+			ExpectedRecordIndexes: []int{},
+		},
+	) {
+		return
+	}
+}
+
+func userProfilesObject() *object.Object {
+	return object.NewObject().WithSourceInterface([]*testdata.UserProfile{
+		{
+			Name: []string{"Wanjiku"},
+			Address: []testdata.Address{
+				{
+					Street: []string{"Moi Avenue"},
+					City:   []string{"Nairobi"},
+				},
+			},
+		},
+		{
+			Name: []string{"Otieno"},
+			Address: []testdata.Address{
+				{
+					City: []string{"Mombasa"},
+				},
+				{
+					City: []string{"Mombasa"},
+				},
+			},
+		},
+		{
+			Name: []string{"Achieng"},
+			Address: []testdata.Address{
+				{
+					City: []string{"Nairobi"},
+				},
+				{
+					City: []string{"Mombasa Old Town"},
+				},
+			},
+		},
+	})
+}
+
+// userProfileFullTextSearchMetadataModel adds Name and Address.City to the full text search index.
+func userProfileFullTextSearchMetadataModel() gojsoncore.JsonObject {
+	metadataModel := testdata.UserProfileMetadataModel(nil)
+	groupFields := metadataModel[core.GroupFields].(gojsoncore.JsonArray)[0].(gojsoncore.JsonObject)
+	groupFields["Name"].(gojsoncore.JsonObject)[core.DatabaseFieldAddDataToFullTextSearchIndex] = true
+	addressGroupFields := groupFields["Address"].(gojsoncore.JsonObject)[core.GroupFields].(gojsoncore.JsonArray)[0].(gojsoncore.JsonObject)
+	addressGroupFields["City"].(gojsoncore.JsonObject)[core.DatabaseFieldAddDataToFullTextSearchIndex] = true
+	return metadataModel
+}
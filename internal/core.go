@@ -1,6 +1,26 @@
 package internal
 
+import (
+	"encoding/json"
+	"testing"
+)
+
 type TestData struct {
 	TestTitle                string
 	LogErrorsIfExpectedNotOk bool
 }
+
+// AsJsonDecoded returns value after encoding it to JSON and decoding it back into an `any` so that values can be compared with reflect.DeepEqual regardless of their Go types e.g. `[]string` and `[]any`.
+func AsJsonDecoded(t testing.TB, value any) any {
+	t.Helper()
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		t.Fatal("marshal failed:", err)
+	}
+	var res any
+	if err := json.Unmarshal(data, &res); err != nil {
+		t.Fatal("unmarshal failed:", err)
+	}
+	return res
+}
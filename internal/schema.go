@@ -0,0 +1,76 @@
+package internal
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/rogonion/go-json/schema"
+)
+
+// ErrStructTypeRecursive for when SchemaFromType encounters a struct type that contains itself.
+var ErrStructTypeRecursive = errors.New("struct type is recursive")
+
+// SchemaFromType generates the schema.DynamicSchemaNode of valueType including its exported struct fields, pointers, slices, arrays, and maps.
+func SchemaFromType(valueType reflect.Type) (*schema.DynamicSchemaNode, error) {
+	return schemaFromType(valueType, make(map[reflect.Type]bool))
+}
+
+// schemaFromType generates the schema.DynamicSchemaNode of valueType including its exported struct fields, pointers, slices, arrays, and maps.
+func schemaFromType(valueType reflect.Type, visiting map[reflect.Type]bool) (*schema.DynamicSchemaNode, error) {
+	node := &schema.DynamicSchemaNode{
+		Kind: valueType.Kind(),
+		Type: valueType,
+	}
+
+	var err error
+	switch valueType.Kind() {
+	case reflect.Pointer:
+		if node.ChildNodesPointerSchema, err = schemaFromTypeNode(valueType.Elem(), visiting); err != nil {
+			return nil, err
+		}
+	case reflect.Slice, reflect.Array:
+		if node.ChildNodesLinearCollectionElementsSchema, err = schemaFromTypeNode(valueType.Elem(), visiting); err != nil {
+			return nil, err
+		}
+	case reflect.Map:
+		if node.ChildNodesAssociativeCollectionEntriesKeySchema, err = schemaFromTypeNode(valueType.Key(), visiting); err != nil {
+			return nil, err
+		}
+		if node.ChildNodesAssociativeCollectionEntriesValueSchema, err = schemaFromTypeNode(valueType.Elem(), visiting); err != nil {
+			return nil, err
+		}
+	case reflect.Struct:
+		if valueType == reflect.TypeOf(time.Time{}) {
+			break
+		}
+		if visiting[valueType] {
+			return nil, fmt.Errorf("type '%s': %w", valueType, ErrStructTypeRecursive)
+		}
+		visiting[valueType] = true
+		defer delete(visiting, valueType)
+
+		node.ChildNodes = make(schema.ChildNodes)
+		for i := 0; i < valueType.NumField(); i++ {
+			structField := valueType.Field(i)
+			if !structField.IsExported() {
+				continue
+			}
+			if node.ChildNodes[structField.Name], err = schemaFromTypeNode(structField.Type, visiting); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return node, nil
+}
+
+// schemaFromTypeNode calls schemaFromType and returns the result as a schema.Schema.
+func schemaFromTypeNode(valueType reflect.Type, visiting map[reflect.Type]bool) (schema.Schema, error) {
+	node, err := schemaFromType(valueType, visiting)
+	if err != nil {
+		return nil, err
+	}
+	return node, nil
+}
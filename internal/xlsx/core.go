@@ -0,0 +1,115 @@
+/*
+Package xlsx reads and writes the first worksheet of an Office Open XML (.xlsx) workbook using archive/zip and encoding/xml.
+
+It only covers what the flattener and unflattener packages need: typed cells, number formats, frozen panes, and list data validations.
+*/
+package xlsx
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CellType is the type of the value of a Cell.
+type CellType int
+
+const (
+	// CellTypeEmpty for cells without a value. Not written.
+	CellTypeEmpty CellType = iota
+	// CellTypeString for text.
+	CellTypeString
+	// CellTypeNumber for numbers including timestamps in serial date format. Refer to SerialFromTime.
+	CellTypeNumber
+	// CellTypeBoolean for `1` (true) or `0` (false).
+	CellTypeBoolean
+	// CellTypeError for formula errors like `#N/A`. Only read.
+	CellTypeError
+)
+
+// Cell in a worksheet row.
+type Cell struct {
+	Type CellType
+
+	// Value as text. Numbers are in decimal notation.
+	Value string
+
+	// Style index. Refer to StyleHeader and NumberFormatStyle. Only written.
+	Style int
+}
+
+const (
+	// StyleDefault is the default cell style.
+	StyleDefault int = 0
+	// StyleHeader is a bold cell style.
+	StyleHeader int = 1
+)
+
+// NumberFormatStyle returns the style index of SheetOptions.NumberFormats[index].
+func NumberFormatStyle(index int) int {
+	return 2 + index
+}
+
+var (
+	// ErrWorkbookInvalid for when a workbook does not have the parts expected of an .xlsx file.
+	ErrWorkbookInvalid = errors.New("xlsx workbook not valid")
+
+	// ErrCellReferenceInvalid for when a cell reference is not like `B3`.
+	ErrCellReferenceInvalid = errors.New("xlsx cell reference not valid")
+
+	// ErrRowLimitExceeded for when a row is written after the last row of a worksheet. Refer to MaxRows.
+	ErrRowLimitExceeded = errors.New("xlsx worksheet row limit exceeded")
+)
+
+// ColumnName returns the name of the column at index (0-based) e.g. `A` for 0 and `AA` for 26.
+func ColumnName(index int) string {
+	name := ""
+	for index >= 0 {
+		name = string(rune('A'+index%26)) + name
+		index = index/26 - 1
+	}
+	return name
+}
+
+// CellReference returns the reference of the cell at column (0-based) and row (1-based) e.g. `B3`.
+func CellReference(column int, row int) string {
+	return ColumnName(column) + strconv.Itoa(row)
+}
+
+// ParseCellReference returns the column (0-based) and row (1-based) of reference e.g. `B3`.
+func ParseCellReference(reference string) (int, int, error) {
+	column := 0
+	i := 0
+	for ; i < len(reference) && reference[i] >= 'A' && reference[i] <= 'Z'; i++ {
+		column = column*26 + int(reference[i]-'A') + 1
+	}
+	if i == 0 {
+		return 0, 0, fmt.Errorf("'%s': %w", reference, ErrCellReferenceInvalid)
+	}
+	row, err := strconv.Atoi(reference[i:])
+	if err != nil || row < 1 {
+		return 0, 0, fmt.Errorf("'%s': %w", reference, ErrCellReferenceInvalid)
+	}
+	return column - 1, row, nil
+}
+
+// serialEpoch is day 0 of the 1900 date system adjusted for the leap year bug carried over from Lotus 1-2-3.
+var serialEpoch = time.Date(1899, 12, 30, 0, 0, 0, 0, time.UTC)
+
+// SerialFromTime returns value as a serial date: the number of days since the 1900 date system epoch with the time of day as a fraction. The wall clock of value is used.
+func SerialFromTime(value time.Time) float64 {
+	wallClock := time.Date(value.Year(), value.Month(), value.Day(), value.Hour(), value.Minute(), value.Second(), value.Nanosecond(), time.UTC)
+	return float64(wallClock.Sub(serialEpoch)) / float64(24*time.Hour)
+}
+
+// TimeFromSerial returns the UTC time of serial rounded to the nearest millisecond. Refer to SerialFromTime.
+func TimeFromSerial(serial float64) time.Time {
+	return serialEpoch.Add(time.Duration(serial * float64(24*time.Hour))).Round(time.Millisecond)
+}
+
+// quoteSheetName quotes name for use in formulas e.g. `'My Sheet'`.
+func quoteSheetName(name string) string {
+	return "'" + strings.ReplaceAll(name, "'", "''") + "'"
+}
@@ -0,0 +1,105 @@
+package xlsx
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestXlsx_CellReference(t *testing.T) {
+	for data := range cellReferenceTestData {
+		t.Run(data.TestTitle, func(t *testing.T) {
+			if actual := CellReference(data.Column, data.Row); actual != data.Reference {
+				t.Errorf("CellReference(%d, %d) expected %s, got %s", data.Column, data.Row, data.Reference, actual)
+			}
+
+			column, row, err := ParseCellReference(data.Reference)
+			if err != nil {
+				t.Fatalf("ParseCellReference(%s) unexpected error: %v", data.Reference, err)
+			}
+			if column != data.Column || row != data.Row {
+				t.Errorf("ParseCellReference(%s) expected (%d, %d), got (%d, %d)", data.Reference, data.Column, data.Row, column, row)
+			}
+		})
+	}
+}
+
+type cellReferenceData struct {
+	TestTitle string
+	Column    int
+	Row       int
+	Reference string
+}
+
+func cellReferenceTestData(yield func(data *cellReferenceData) bool) {
+	for _, data := range []*cellReferenceData{
+		{TestTitle: "First Cell", Column: 0, Row: 1, Reference: "A1"},
+		{TestTitle: "Last Single Letter Column", Column: 25, Row: 3, Reference: "Z3"},
+		{TestTitle: "First Double Letter Column", Column: 26, Row: 10, Reference: "AA10"},
+		{TestTitle: "Last Double Letter Column", Column: 701, Row: 2, Reference: "ZZ2"},
+		{TestTitle: "First Triple Letter Column", Column: 702, Row: 2, Reference: "AAA2"},
+		{TestTitle: "Last Cell", Column: 16383, Row: MaxRows, Reference: "XFD1048576"},
+	} {
+		if !yield(data) {
+			return
+		}
+	}
+}
+
+func TestXlsx_ParseCellReference_Invalid(t *testing.T) {
+	for _, reference := range []string{"", "A", "1", "A0", "A-1", "a1", "A1B"} {
+		t.Run(reference, func(t *testing.T) {
+			if _, _, err := ParseCellReference(reference); !errors.Is(err, ErrCellReferenceInvalid) {
+				t.Errorf("expected error %v, got %v", ErrCellReferenceInvalid, err)
+			}
+		})
+	}
+}
+
+func TestXlsx_SerialFromTime(t *testing.T) {
+	for data := range serialTestData {
+		t.Run(data.TestTitle, func(t *testing.T) {
+			if actual := SerialFromTime(data.Time); actual != data.Serial {
+				t.Errorf("SerialFromTime(%v) expected %v, got %v", data.Time, data.Serial, actual)
+			}
+
+			if actual := TimeFromSerial(data.Serial); !actual.Equal(data.Time) {
+				t.Errorf("TimeFromSerial(%v) expected %v, got %v", data.Serial, data.Time, actual)
+			}
+		})
+	}
+}
+
+type serialData struct {
+	TestTitle string
+	Time      time.Time
+	Serial    float64
+}
+
+func serialTestData(yield func(data *serialData) bool) {
+	for _, data := range []*serialData{
+		{TestTitle: "Epoch", Time: time.Date(1899, 12, 30, 0, 0, 0, 0, time.UTC), Serial: 0},
+		{TestTitle: "Day After Leap Year Bug", Time: time.Date(1900, 3, 1, 0, 0, 0, 0, time.UTC), Serial: 61},
+		{TestTitle: "Date", Time: time.Date(2024, 3, 5, 0, 0, 0, 0, time.UTC), Serial: 45356},
+		{TestTitle: "Date and Time", Time: time.Date(2024, 3, 5, 18, 0, 0, 0, time.UTC), Serial: 45356.75},
+		{TestTitle: "Before Epoch", Time: time.Date(1899, 12, 29, 12, 0, 0, 0, time.UTC), Serial: -0.5},
+	} {
+		if !yield(data) {
+			return
+		}
+	}
+}
+
+func TestXlsx_SerialFromTime_WallClock(t *testing.T) {
+	location := time.FixedZone("UTC+3", 3*60*60)
+	if actual, expected := SerialFromTime(time.Date(2024, 3, 5, 18, 0, 0, 0, location)), 45356.75; actual != expected {
+		t.Errorf("expected %v, got %v", expected, actual)
+	}
+}
+
+func TestXlsx_TimeFromSerial_RoundsToMillisecond(t *testing.T) {
+	expected := time.Date(2024, 3, 5, 12, 30, 15, 0, time.UTC)
+	if actual := TimeFromSerial(SerialFromTime(expected) + 1e-10); !actual.Equal(expected) {
+		t.Errorf("expected %v, got %v", expected, actual)
+	}
+}
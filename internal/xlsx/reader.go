@@ -0,0 +1,241 @@
+package xlsx
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+)
+
+// Row read by Reader.
+type Row struct {
+	// Index of the row (1-based).
+	Index int
+
+	// Cells by column index (0-based). Missing cells have CellTypeEmpty.
+	Cells []Cell
+}
+
+// Reader reads the rows of the first worksheet of a workbook one at a time.
+type Reader struct {
+	sharedStrings []string
+
+	sheet io.ReadCloser
+
+	decoder *xml.Decoder
+}
+
+// ReadRow returns the next row. Returns io.EOF if there are no more rows.
+func (n *Reader) ReadRow() (Row, error) {
+	for {
+		token, err := n.decoder.Token()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return Row{}, io.EOF
+			}
+			return Row{}, fmt.Errorf("read worksheet failed: %w", err)
+		}
+
+		startElement, ok := token.(xml.StartElement)
+		if !ok || startElement.Name.Local != "row" {
+			continue
+		}
+
+		var xmlRow worksheetRow
+		if err := n.decoder.DecodeElement(&xmlRow, &startElement); err != nil {
+			return Row{}, fmt.Errorf("read row failed: %w", err)
+		}
+		return n.row(xmlRow)
+	}
+}
+
+// Close closes the worksheet.
+func (n *Reader) Close() error {
+	return n.sheet.Close()
+}
+
+// row converts xmlRow to a Row resolving shared strings.
+func (n *Reader) row(xmlRow worksheetRow) (Row, error) {
+	row := Row{Index: xmlRow.Index, Cells: make([]Cell, 0, len(xmlRow.Cells))}
+
+	for _, xmlCell := range xmlRow.Cells {
+		column := len(row.Cells)
+		if len(xmlCell.Reference) > 0 {
+			cellColumn, cellRow, err := ParseCellReference(xmlCell.Reference)
+			if err != nil {
+				return Row{}, err
+			}
+			column = cellColumn
+			if row.Index == 0 {
+				row.Index = cellRow
+			}
+		}
+		for len(row.Cells) <= column {
+			row.Cells = append(row.Cells, Cell{})
+		}
+
+		cell := Cell{Value: xmlCell.Value}
+		switch xmlCell.Type {
+		case "s":
+			var index int
+			if _, err := fmt.Sscan(xmlCell.Value, &index); err != nil || index < 0 || index >= len(n.sharedStrings) {
+				return Row{}, fmt.Errorf("cell '%s' shared string '%s': %w", xmlCell.Reference, xmlCell.Value, ErrWorkbookInvalid)
+			}
+			cell.Type = CellTypeString
+			cell.Value = n.sharedStrings[index]
+		case "inlineStr":
+			cell.Type = CellTypeString
+			cell.Value = xmlCell.InlineString.text()
+		case "str", "d":
+			cell.Type = CellTypeString
+		case "b":
+			cell.Type = CellTypeBoolean
+		case "e":
+			cell.Type = CellTypeError
+		default:
+			cell.Type = CellTypeNumber
+		}
+		if cell.Type != CellTypeString && len(cell.Value) == 0 {
+			cell.Type = CellTypeEmpty
+		}
+		row.Cells[column] = cell
+	}
+
+	return row, nil
+}
+
+// NewReader opens the first worksheet of the workbook in readerAt. Call Reader.Close once done.
+func NewReader(readerAt io.ReaderAt, size int64) (*Reader, error) {
+	zipReader, err := zip.NewReader(readerAt, size)
+	if err != nil {
+		return nil, fmt.Errorf("open zip failed: %w: %w", err, ErrWorkbookInvalid)
+	}
+
+	files := make(map[string]*zip.File)
+	for _, file := range zipReader.File {
+		files[file.Name] = file
+	}
+
+	var xmlWorkbook workbook
+	if err := decodePart(files, "xl/workbook.xml", &xmlWorkbook); err != nil {
+		return nil, err
+	}
+	if len(xmlWorkbook.Sheets) == 0 {
+		return nil, fmt.Errorf("workbook has no worksheets: %w", ErrWorkbookInvalid)
+	}
+
+	var xmlRelationships relationships
+	if err := decodePart(files, "xl/_rels/workbook.xml.rels", &xmlRelationships); err != nil {
+		return nil, err
+	}
+
+	sheetName := ""
+	sharedStringsName := ""
+	for _, relationship := range xmlRelationships.Relationships {
+		target := relationship.Target
+		if strings.HasPrefix(target, "/") {
+			target = strings.TrimPrefix(target, "/")
+		} else {
+			target = path.Join("xl", target)
+		}
+		if relationship.ID == xmlWorkbook.Sheets[0].RelationshipID {
+			sheetName = target
+		}
+		if strings.HasSuffix(relationship.Type, "/sharedStrings") {
+			sharedStringsName = target
+		}
+	}
+
+	sheetFile, ok := files[sheetName]
+	if !ok {
+		return nil, fmt.Errorf("worksheet '%s' not found: %w", xmlWorkbook.Sheets[0].Name, ErrWorkbookInvalid)
+	}
+
+	n := new(Reader)
+	if len(sharedStringsName) > 0 {
+		var xmlSharedStrings sharedStrings
+		if err := decodePart(files, sharedStringsName, &xmlSharedStrings); err != nil {
+			return nil, err
+		}
+		n.sharedStrings = make([]string, 0, len(xmlSharedStrings.Items))
+		for _, item := range xmlSharedStrings.Items {
+			n.sharedStrings = append(n.sharedStrings, item.text())
+		}
+	}
+
+	n.sheet, err = sheetFile.Open()
+	if err != nil {
+		return nil, fmt.Errorf("open worksheet failed: %w", err)
+	}
+	n.decoder = xml.NewDecoder(n.sheet)
+
+	return n, nil
+}
+
+// decodePart decodes the XML file called name in files into destination.
+func decodePart(files map[string]*zip.File, name string, destination any) error {
+	file, ok := files[name]
+	if !ok {
+		return fmt.Errorf("'%s' not found: %w", name, ErrWorkbookInvalid)
+	}
+	reader, err := file.Open()
+	if err != nil {
+		return fmt.Errorf("open '%s' failed: %w", name, err)
+	}
+	defer reader.Close()
+	if err := xml.NewDecoder(reader).Decode(destination); err != nil {
+		return fmt.Errorf("decode '%s' failed: %w: %w", name, err, ErrWorkbookInvalid)
+	}
+	return nil
+}
+
+type workbook struct {
+	Sheets []struct {
+		Name           string `xml:"name,attr"`
+		RelationshipID string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
+	} `xml:"sheets>sheet"`
+}
+
+type relationships struct {
+	Relationships []struct {
+		ID     string `xml:"Id,attr"`
+		Type   string `xml:"Type,attr"`
+		Target string `xml:"Target,attr"`
+	} `xml:"Relationship"`
+}
+
+type sharedStrings struct {
+	Items []richText `xml:"si"`
+}
+
+// richText is text that is either plain (`<t>`) or split into runs (`<r><t>`).
+type richText struct {
+	Text string `xml:"t"`
+	Runs []struct {
+		Text string `xml:"t"`
+	} `xml:"r"`
+}
+
+func (n richText) text() string {
+	if len(n.Runs) == 0 {
+		return n.Text
+	}
+	var b strings.Builder
+	for _, run := range n.Runs {
+		b.WriteString(run.Text)
+	}
+	return b.String()
+}
+
+type worksheetRow struct {
+	Index int `xml:"r,attr"`
+	Cells []struct {
+		Reference    string   `xml:"r,attr"`
+		Type         string   `xml:"t,attr"`
+		Value        string   `xml:"v"`
+		InlineString richText `xml:"is"`
+	} `xml:"c"`
+}
@@ -0,0 +1,203 @@
+package xlsx
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"io"
+	"reflect"
+	"testing"
+)
+
+func TestXlsx_Reader(t *testing.T) {
+	for data := range readerTestData {
+		t.Run(data.TestTitle, func(t *testing.T) {
+			workbook := zipArchive(data.Parts)
+
+			reader, err := NewReader(bytes.NewReader(workbook), int64(len(workbook)))
+			if err != nil {
+				if data.ExpectedErr != nil && errors.Is(err, data.ExpectedErr) {
+					return
+				}
+				t.Fatalf("NewReader() unexpected error: %v", err)
+			}
+			defer reader.Close()
+
+			var actualRows []Row
+			for {
+				row, err := reader.ReadRow()
+				if errors.Is(err, io.EOF) {
+					break
+				}
+				if err != nil {
+					if data.ExpectedErr != nil && errors.Is(err, data.ExpectedErr) {
+						return
+					}
+					t.Fatalf("ReadRow() unexpected error: %v", err)
+				}
+				actualRows = append(actualRows, row)
+			}
+			if data.ExpectedErr != nil {
+				t.Fatalf("expected error %v, got nil", data.ExpectedErr)
+			}
+			if !reflect.DeepEqual(actualRows, data.ExpectedRows) {
+				t.Errorf("Rows mismatch.\nExpected:\n%#v\nGot:\n%#v", data.ExpectedRows, actualRows)
+			}
+		})
+	}
+}
+
+type readerData struct {
+	TestTitle    string
+	Parts        map[string]string
+	ExpectedRows []Row
+	ExpectedErr  error
+}
+
+func readerTestData(yield func(data *readerData) bool) {
+	// -------------------------------------------------------------------------
+	// Case 1: Cell types written by other applications
+	// -------------------------------------------------------------------------
+	if !yield(&readerData{
+		TestTitle: "Shared Strings and Cell Types",
+		Parts: readerParts(
+			`<row r="1"><c r="A1" t="s"><v>0</v></c><c r="C1" t="s"><v>1</v></c></row>`+
+				`<row r="2"><c r="A2"><v>42</v></c><c r="B2" t="b"><v>1</v></c><c r="C2" t="e"><v>#N/A</v></c><c r="D2" t="str"><v>formula</v></c><c r="E2" t="d"><v>2024-03-05</v></c><c r="F2"/></row>`+
+				`<row r="4"><c r="B4" t="inlineStr"><is><r><t>in</t></r><r><t>line</t></r></is></c></row>`,
+			`<si><t>Name</t></si><si><r><t>Rich </t></r><r><t>Text</t></r></si>`,
+		),
+		ExpectedRows: []Row{
+			{Index: 1, Cells: []Cell{{Type: CellTypeString, Value: "Name"}, {}, {Type: CellTypeString, Value: "Rich Text"}}},
+			{Index: 2, Cells: []Cell{
+				{Type: CellTypeNumber, Value: "42"},
+				{Type: CellTypeBoolean, Value: "1"},
+				{Type: CellTypeError, Value: "#N/A"},
+				{Type: CellTypeString, Value: "formula"},
+				{Type: CellTypeString, Value: "2024-03-05"},
+				{},
+			}},
+			{Index: 4, Cells: []Cell{{}, {Type: CellTypeString, Value: "inline"}}},
+		},
+	}) {
+		return
+	}
+
+	// -------------------------------------------------------------------------
+	// Case 2: Cells and rows without references
+	// -------------------------------------------------------------------------
+	if !yield(&readerData{
+		TestTitle: "Cells Without References",
+		Parts: readerParts(
+			`<row><c r="B3"><v>1</v></c><c><v>2</v></c></row>`,
+			"",
+		),
+		ExpectedRows: []Row{
+			{Index: 3, Cells: []Cell{{}, {Type: CellTypeNumber, Value: "1"}, {Type: CellTypeNumber, Value: "2"}}},
+		},
+	}) {
+		return
+	}
+
+	// -------------------------------------------------------------------------
+	// Case 3: Absolute relationship targets
+	// -------------------------------------------------------------------------
+	parts := readerParts(`<row r="1"><c r="A1" t="s"><v>0</v></c></row>`, `<si><t>Absolute</t></si>`)
+	parts["xl/_rels/workbook.xml.rels"] = `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="/xl/worksheets/sheet1.xml"/>` +
+		`<Relationship Id="rId2" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/sharedStrings" Target="/xl/sharedStrings.xml"/>` +
+		`</Relationships>`
+	if !yield(&readerData{
+		TestTitle: "Absolute Relationship Targets",
+		Parts:     parts,
+		ExpectedRows: []Row{
+			{Index: 1, Cells: []Cell{{Type: CellTypeString, Value: "Absolute"}}},
+		},
+	}) {
+		return
+	}
+
+	// -------------------------------------------------------------------------
+	// Case 4: Errors
+	// -------------------------------------------------------------------------
+	if !yield(&readerData{
+		TestTitle:   "Shared String Out of Range",
+		Parts:       readerParts(`<row r="1"><c r="A1" t="s"><v>1</v></c></row>`, `<si><t>Only</t></si>`),
+		ExpectedErr: ErrWorkbookInvalid,
+	}) {
+		return
+	}
+
+	if !yield(&readerData{
+		TestTitle:   "Invalid Cell Reference",
+		Parts:       readerParts(`<row r="1"><c r="1A"><v>1</v></c></row>`, ""),
+		ExpectedErr: ErrCellReferenceInvalid,
+	}) {
+		return
+	}
+
+	parts = readerParts("", "")
+	delete(parts, "xl/workbook.xml")
+	if !yield(&readerData{
+		TestTitle:   "Missing Workbook",
+		Parts:       parts,
+		ExpectedErr: ErrWorkbookInvalid,
+	}) {
+		return
+	}
+
+	parts = readerParts("", "")
+	delete(parts, "xl/worksheets/sheet1.xml")
+	if !yield(&readerData{
+		TestTitle:   "Missing Worksheet",
+		Parts:       parts,
+		ExpectedErr: ErrWorkbookInvalid,
+	}) {
+		return
+	}
+
+	parts = readerParts("", "")
+	parts["xl/workbook.xml"] = `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheets></sheets></workbook>`
+	if !yield(&readerData{
+		TestTitle:   "No Worksheets",
+		Parts:       parts,
+		ExpectedErr: ErrWorkbookInvalid,
+	}) {
+		return
+	}
+}
+
+func TestXlsx_NewReader_NotZip(t *testing.T) {
+	content := []byte("Name,Age\nBob,30\n")
+	if _, err := NewReader(bytes.NewReader(content), int64(len(content))); !errors.Is(err, ErrWorkbookInvalid) {
+		t.Errorf("expected error %v, got %v", ErrWorkbookInvalid, err)
+	}
+}
+
+// readerParts returns the parts of a workbook whose first worksheet has rows and whose shared strings part has sharedStringItems.
+func readerParts(rows string, sharedStringItems string) map[string]string {
+	return map[string]string{
+		"xl/workbook.xml": `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+			`<sheets><sheet name="Data" sheetId="1" r:id="rId1"/></sheets></workbook>`,
+		"xl/_rels/workbook.xml.rels": `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+			`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>` +
+			`<Relationship Id="rId2" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/sharedStrings" Target="sharedStrings.xml"/>` +
+			`</Relationships>`,
+		"xl/sharedStrings.xml":     `<sst xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">` + sharedStringItems + `</sst>`,
+		"xl/worksheets/sheet1.xml": `<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>` + rows + `</sheetData></worksheet>`,
+	}
+}
+
+// zipArchive returns parts as a zip archive.
+func zipArchive(parts map[string]string) []byte {
+	var buffer bytes.Buffer
+	zipWriter := zip.NewWriter(&buffer)
+	for name, content := range parts {
+		if err := writePart(zipWriter, name, content); err != nil {
+			panic(err)
+		}
+	}
+	if err := zipWriter.Close(); err != nil {
+		panic(err)
+	}
+	return buffer.Bytes()
+}
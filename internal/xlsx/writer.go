@@ -0,0 +1,282 @@
+package xlsx
+
+import (
+	"archive/zip"
+	"bufio"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// SheetOptions of the worksheet written by Writer.
+type SheetOptions struct {
+	// Name of the worksheet. Defaults to `Sheet1`.
+	Name string
+
+	// FrozenColumns number of columns from the left that remain visible when scrolling.
+	FrozenColumns int
+
+	// FrozenRows number of rows from the top that remain visible when scrolling.
+	FrozenRows int
+
+	// NumberFormats custom number formats like `yyyy-mm-dd`. Refer to NumberFormatStyle.
+	NumberFormats []string
+
+	// ListValidations dropdown lists of allowed values for columns.
+	ListValidations []ListValidation
+}
+
+// ListValidation restricts the values of a column to Options from FirstRow (1-based) downwards.
+type ListValidation struct {
+	Column   int
+	FirstRow int
+	Options  []string
+}
+
+// optionsSheetName is the name of the hidden worksheet that holds the options of SheetOptions.ListValidations.
+const optionsSheetName = "_Options"
+
+// MaxRows is the number of rows in a worksheet.
+const MaxRows = 1048576
+
+// Writer writes a workbook with a single visible worksheet whose rows are written one at a time.
+type Writer struct {
+	zipWriter *zip.Writer
+
+	sheetWriter *bufio.Writer
+
+	options SheetOptions
+
+	// rowIndex of the last row written (1-based).
+	rowIndex int
+}
+
+// WriteRow writes cells as the next row. Cells with CellTypeEmpty are left out.
+//
+// Returns ErrRowLimitExceeded if MaxRows rows have already been written.
+func (n *Writer) WriteRow(cells []Cell) error {
+	if n.rowIndex >= MaxRows {
+		return fmt.Errorf("write row %d failed: %w", n.rowIndex+1, ErrRowLimitExceeded)
+	}
+	n.rowIndex++
+
+	var b strings.Builder
+	b.WriteString(`<row r="` + strconv.Itoa(n.rowIndex) + `">`)
+	for column, cell := range cells {
+		if cell.Type == CellTypeEmpty {
+			continue
+		}
+		b.WriteString(`<c r="` + CellReference(column, n.rowIndex) + `"`)
+		if cell.Style != StyleDefault {
+			b.WriteString(` s="` + strconv.Itoa(cell.Style) + `"`)
+		}
+		switch cell.Type {
+		case CellTypeNumber:
+			b.WriteString(`><v>` + escape(cell.Value) + `</v></c>`)
+		case CellTypeBoolean:
+			b.WriteString(` t="b"><v>` + escape(cell.Value) + `</v></c>`)
+		default:
+			b.WriteString(` t="inlineStr"><is><t xml:space="preserve">` + escape(cell.Value) + `</t></is></c>`)
+		}
+	}
+	b.WriteString(`</row>`)
+
+	if _, err := n.sheetWriter.WriteString(b.String()); err != nil {
+		return fmt.Errorf("write row %d failed: %w", n.rowIndex, err)
+	}
+	return nil
+}
+
+// Close finishes the worksheet, writes the remaining parts of the workbook, and closes the zip archive. Does not close the underlying io.Writer.
+func (n *Writer) Close() error {
+	var b strings.Builder
+	b.WriteString(`</sheetData>`)
+	if len(n.options.ListValidations) > 0 {
+		b.WriteString(`<dataValidations count="` + strconv.Itoa(len(n.options.ListValidations)) + `">`)
+		for index, listValidation := range n.options.ListValidations {
+			firstRow := max(listValidation.FirstRow, 1)
+			column := ColumnName(listValidation.Column)
+			optionsColumn := ColumnName(index)
+			b.WriteString(`<dataValidation type="list" allowBlank="1" showErrorMessage="1" sqref="` + column + strconv.Itoa(firstRow) + `:` + column + strconv.Itoa(MaxRows) + `">`)
+			b.WriteString(`<formula1>` + escape(quoteSheetName(optionsSheetName)+`!$`+optionsColumn+`$1:$`+optionsColumn+`$`+strconv.Itoa(max(len(listValidation.Options), 1))) + `</formula1>`)
+			b.WriteString(`</dataValidation>`)
+		}
+		b.WriteString(`</dataValidations>`)
+	}
+	b.WriteString(`</worksheet>`)
+	if _, err := n.sheetWriter.WriteString(b.String()); err != nil {
+		return fmt.Errorf("write worksheet failed: %w", err)
+	}
+	if err := n.sheetWriter.Flush(); err != nil {
+		return fmt.Errorf("write worksheet failed: %w", err)
+	}
+
+	if len(n.options.ListValidations) > 0 {
+		if err := n.writeOptionsSheet(); err != nil {
+			return err
+		}
+	}
+
+	if err := n.zipWriter.Close(); err != nil {
+		return fmt.Errorf("close zip failed: %w", err)
+	}
+	return nil
+}
+
+// writeOptionsSheet writes the hidden worksheet with the options of each SheetOptions.ListValidations in a column.
+func (n *Writer) writeOptionsSheet() error {
+	maxOptions := 0
+	for _, listValidation := range n.options.ListValidations {
+		maxOptions = max(maxOptions, len(listValidation.Options))
+	}
+
+	var b strings.Builder
+	b.WriteString(xml.Header + `<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`)
+	for rowIndex := 0; rowIndex < maxOptions; rowIndex++ {
+		b.WriteString(`<row r="` + strconv.Itoa(rowIndex+1) + `">`)
+		for column, listValidation := range n.options.ListValidations {
+			if rowIndex < len(listValidation.Options) {
+				b.WriteString(`<c r="` + CellReference(column, rowIndex+1) + `" t="inlineStr"><is><t xml:space="preserve">` + escape(listValidation.Options[rowIndex]) + `</t></is></c>`)
+			}
+		}
+		b.WriteString(`</row>`)
+	}
+	b.WriteString(`</sheetData></worksheet>`)
+
+	return writePart(n.zipWriter, "xl/worksheets/sheet2.xml", b.String())
+}
+
+// NewWriter writes the workbook parts that precede the rows of the worksheet to writer. Call Writer.Close once all rows are written.
+func NewWriter(writer io.Writer, options SheetOptions) (*Writer, error) {
+	if len(options.Name) == 0 {
+		options.Name = "Sheet1"
+	}
+
+	n := &Writer{
+		zipWriter: zip.NewWriter(writer),
+		options:   options,
+	}
+
+	hasOptionsSheet := len(options.ListValidations) > 0
+
+	contentTypes := xml.Header + `<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+		`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+		`<Default Extension="xml" ContentType="application/xml"/>` +
+		`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+		`<Override PartName="/xl/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/>` +
+		`<Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>`
+	if hasOptionsSheet {
+		contentTypes += `<Override PartName="/xl/worksheets/sheet2.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>`
+	}
+	contentTypes += `</Types>`
+
+	rootRelationships := xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+		`</Relationships>`
+
+	workbook := xml.Header + `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><sheets>` +
+		`<sheet name="` + escape(options.Name) + `" sheetId="1" r:id="rId1"/>`
+	if hasOptionsSheet {
+		workbook += `<sheet name="` + optionsSheetName + `" sheetId="2" state="hidden" r:id="rId2"/>`
+	}
+	workbook += `</sheets></workbook>`
+
+	workbookRelationships := xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>`
+	if hasOptionsSheet {
+		workbookRelationships += `<Relationship Id="rId2" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet2.xml"/>`
+	}
+	workbookRelationships += `<Relationship Id="rId3" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/>` +
+		`</Relationships>`
+
+	for _, part := range []struct{ name, content string }{
+		{"[Content_Types].xml", contentTypes},
+		{"_rels/.rels", rootRelationships},
+		{"xl/workbook.xml", workbook},
+		{"xl/_rels/workbook.xml.rels", workbookRelationships},
+		{"xl/styles.xml", styles(options.NumberFormats)},
+	} {
+		if err := writePart(n.zipWriter, part.name, part.content); err != nil {
+			return nil, err
+		}
+	}
+
+	sheetWriter, err := n.zipWriter.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		return nil, fmt.Errorf("create worksheet failed: %w", err)
+	}
+	n.sheetWriter = bufio.NewWriter(sheetWriter)
+
+	var b strings.Builder
+	b.WriteString(xml.Header + `<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">`)
+	if options.FrozenColumns > 0 || options.FrozenRows > 0 {
+		activePane := "bottomRight"
+		if options.FrozenColumns == 0 {
+			activePane = "bottomLeft"
+		} else if options.FrozenRows == 0 {
+			activePane = "topRight"
+		}
+		b.WriteString(`<sheetViews><sheetView workbookViewId="0"><pane`)
+		if options.FrozenColumns > 0 {
+			b.WriteString(` xSplit="` + strconv.Itoa(options.FrozenColumns) + `"`)
+		}
+		if options.FrozenRows > 0 {
+			b.WriteString(` ySplit="` + strconv.Itoa(options.FrozenRows) + `"`)
+		}
+		b.WriteString(` topLeftCell="` + CellReference(options.FrozenColumns, options.FrozenRows+1) + `" activePane="` + activePane + `" state="frozen"/></sheetView></sheetViews>`)
+	}
+	b.WriteString(`<sheetData>`)
+	if _, err := n.sheetWriter.WriteString(b.String()); err != nil {
+		return nil, fmt.Errorf("write worksheet failed: %w", err)
+	}
+
+	return n, nil
+}
+
+// styles returns the stylesheet with StyleDefault, StyleHeader, and a style for each of numberFormats.
+func styles(numberFormats []string) string {
+	var b strings.Builder
+	b.WriteString(xml.Header + `<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">`)
+	if len(numberFormats) > 0 {
+		b.WriteString(`<numFmts count="` + strconv.Itoa(len(numberFormats)) + `">`)
+		for index, numberFormat := range numberFormats {
+			b.WriteString(`<numFmt numFmtId="` + strconv.Itoa(164+index) + `" formatCode="` + escape(numberFormat) + `"/>`)
+		}
+		b.WriteString(`</numFmts>`)
+	}
+	b.WriteString(`<fonts count="2"><font><sz val="11"/><name val="Calibri"/></font><font><b/><sz val="11"/><name val="Calibri"/></font></fonts>`)
+	b.WriteString(`<fills count="2"><fill><patternFill patternType="none"/></fill><fill><patternFill patternType="gray125"/></fill></fills>`)
+	b.WriteString(`<borders count="1"><border><left/><right/><top/><bottom/><diagonal/></border></borders>`)
+	b.WriteString(`<cellStyleXfs count="1"><xf numFmtId="0" fontId="0" fillId="0" borderId="0"/></cellStyleXfs>`)
+	b.WriteString(`<cellXfs count="` + strconv.Itoa(2+len(numberFormats)) + `">`)
+	b.WriteString(`<xf numFmtId="0" fontId="0" fillId="0" borderId="0" xfId="0"/>`)
+	b.WriteString(`<xf numFmtId="0" fontId="1" fillId="0" borderId="0" xfId="0" applyFont="1"/>`)
+	for index := range numberFormats {
+		b.WriteString(`<xf numFmtId="` + strconv.Itoa(164+index) + `" fontId="0" fillId="0" borderId="0" xfId="0" applyNumberFormat="1"/>`)
+	}
+	b.WriteString(`</cellXfs>`)
+	b.WriteString(`<cellStyles count="1"><cellStyle name="Normal" xfId="0" builtinId="0"/></cellStyles>`)
+	b.WriteString(`</styleSheet>`)
+	return b.String()
+}
+
+// writePart writes content to a new file called name in zipWriter.
+func writePart(zipWriter *zip.Writer, name string, content string) error {
+	partWriter, err := zipWriter.Create(name)
+	if err != nil {
+		return fmt.Errorf("create '%s' failed: %w", name, err)
+	}
+	if _, err := io.WriteString(partWriter, content); err != nil {
+		return fmt.Errorf("write '%s' failed: %w", name, err)
+	}
+	return nil
+}
+
+// escape returns value with XML special characters escaped.
+func escape(value string) string {
+	var b strings.Builder
+	_ = xml.EscapeText(&b, []byte(value))
+	return b.String()
+}
@@ -0,0 +1,181 @@
+package xlsx
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestXlsx_Writer(t *testing.T) {
+	for data := range writerTestData {
+		t.Run(data.TestTitle, func(t *testing.T) {
+			var buffer bytes.Buffer
+			writer, err := NewWriter(&buffer, data.Options)
+			if err != nil {
+				t.Fatalf("NewWriter() unexpected error: %v", err)
+			}
+			for _, row := range data.Rows {
+				if err := writer.WriteRow(row); err != nil {
+					t.Fatalf("WriteRow() unexpected error: %v", err)
+				}
+			}
+			if err := writer.Close(); err != nil {
+				t.Fatalf("Close() unexpected error: %v", err)
+			}
+
+			for name, expectedContents := range data.ExpectedParts {
+				part := zipPart(t, buffer.Bytes(), name)
+				for _, expectedContent := range expectedContents {
+					if !strings.Contains(part, expectedContent) {
+						t.Errorf("%s expected to contain %s, got:\n%s", name, expectedContent, part)
+					}
+				}
+			}
+
+			reader, err := NewReader(bytes.NewReader(buffer.Bytes()), int64(buffer.Len()))
+			if err != nil {
+				t.Fatalf("NewReader() unexpected error: %v", err)
+			}
+			defer reader.Close()
+
+			var actualRows []Row
+			for {
+				row, err := reader.ReadRow()
+				if errors.Is(err, io.EOF) {
+					break
+				}
+				if err != nil {
+					t.Fatalf("ReadRow() unexpected error: %v", err)
+				}
+				actualRows = append(actualRows, row)
+			}
+			if !reflect.DeepEqual(actualRows, data.ExpectedRows) {
+				t.Errorf("Rows mismatch.\nExpected:\n%#v\nGot:\n%#v", data.ExpectedRows, actualRows)
+			}
+		})
+	}
+}
+
+type writerData struct {
+	TestTitle     string
+	Options       SheetOptions
+	Rows          [][]Cell
+	ExpectedParts map[string][]string
+	ExpectedRows  []Row
+}
+
+func writerTestData(yield func(data *writerData) bool) {
+	// -------------------------------------------------------------------------
+	// Case 1: Typed cells
+	// -------------------------------------------------------------------------
+	if !yield(&writerData{
+		TestTitle: "Typed Cells",
+		Rows: [][]Cell{
+			{{Type: CellTypeString, Value: "Name", Style: StyleHeader}, {Type: CellTypeString, Value: "Age", Style: StyleHeader}, {Type: CellTypeString, Value: "Active", Style: StyleHeader}},
+			{{Type: CellTypeString, Value: "Bob"}, {Type: CellTypeNumber, Value: "30.5"}, {Type: CellTypeBoolean, Value: "1"}},
+			{{}, {}, {Type: CellTypeBoolean, Value: "0"}},
+		},
+		ExpectedParts: map[string][]string{
+			"xl/workbook.xml":          {`<sheet name="Sheet1" sheetId="1" r:id="rId1"/>`},
+			"xl/worksheets/sheet1.xml": {`<c r="A1" s="1" t="inlineStr">`, `<c r="B2"><v>30.5</v></c>`, `<row r="3"><c r="C3" t="b"><v>0</v></c></row>`},
+		},
+		ExpectedRows: []Row{
+			{Index: 1, Cells: []Cell{{Type: CellTypeString, Value: "Name"}, {Type: CellTypeString, Value: "Age"}, {Type: CellTypeString, Value: "Active"}}},
+			{Index: 2, Cells: []Cell{{Type: CellTypeString, Value: "Bob"}, {Type: CellTypeNumber, Value: "30.5"}, {Type: CellTypeBoolean, Value: "1"}}},
+			{Index: 3, Cells: []Cell{{}, {}, {Type: CellTypeBoolean, Value: "0"}}},
+		},
+	}) {
+		return
+	}
+
+	// -------------------------------------------------------------------------
+	// Case 2: Escaping
+	// -------------------------------------------------------------------------
+	if !yield(&writerData{
+		TestTitle: "Escaping",
+		Options: SheetOptions{
+			Name:          `R&D <"Q1">`,
+			NumberFormats: []string{`"Day" dd`},
+			ListValidations: []ListValidation{
+				{Column: 0, FirstRow: 2, Options: []string{"Tom & Jerry", "<none>"}},
+			},
+		},
+		Rows: [][]Cell{
+			{{Type: CellTypeString, Value: `a < b & c > "d" 'e'`}, {Type: CellTypeString, Value: "  leading and trailing spaces  "}},
+			{{Type: CellTypeString, Value: "line 1\nline 2"}},
+		},
+		ExpectedParts: map[string][]string{
+			"xl/workbook.xml":          {`<sheet name="R&amp;D &lt;&#34;Q1&#34;&gt;"`, `<sheet name="_Options" sheetId="2" state="hidden" r:id="rId2"/>`},
+			"xl/styles.xml":            {`formatCode="&#34;Day&#34; dd"`},
+			"xl/worksheets/sheet1.xml": {`a &lt; b &amp; c &gt; &#34;d&#34; &#39;e&#39;`, `<formula1>&#39;_Options&#39;!$A$1:$A$2</formula1>`, `sqref="A2:A1048576"`},
+			"xl/worksheets/sheet2.xml": {`Tom &amp; Jerry`, `&lt;none&gt;`},
+		},
+		ExpectedRows: []Row{
+			{Index: 1, Cells: []Cell{{Type: CellTypeString, Value: `a < b & c > "d" 'e'`}, {Type: CellTypeString, Value: "  leading and trailing spaces  "}}},
+			{Index: 2, Cells: []Cell{{Type: CellTypeString, Value: "line 1\nline 2"}}},
+		},
+	}) {
+		return
+	}
+
+	// -------------------------------------------------------------------------
+	// Case 3: Frozen panes
+	// -------------------------------------------------------------------------
+	if !yield(&writerData{
+		TestTitle: "Frozen Panes",
+		Options:   SheetOptions{FrozenColumns: 2, FrozenRows: 1},
+		Rows: [][]Cell{
+			{{Type: CellTypeString, Value: "A"}},
+		},
+		ExpectedParts: map[string][]string{
+			"xl/worksheets/sheet1.xml": {`<pane xSplit="2" ySplit="1" topLeftCell="C2" activePane="bottomRight" state="frozen"/>`},
+		},
+		ExpectedRows: []Row{
+			{Index: 1, Cells: []Cell{{Type: CellTypeString, Value: "A"}}},
+		},
+	}) {
+		return
+	}
+}
+
+func TestXlsx_Writer_RowLimit(t *testing.T) {
+	writer, err := NewWriter(io.Discard, SheetOptions{})
+	if err != nil {
+		t.Fatalf("NewWriter() unexpected error: %v", err)
+	}
+	writer.rowIndex = MaxRows - 1
+
+	if err := writer.WriteRow([]Cell{{Type: CellTypeString, Value: "last"}}); err != nil {
+		t.Fatalf("WriteRow() unexpected error for row %d: %v", MaxRows, err)
+	}
+	if err := writer.WriteRow([]Cell{{Type: CellTypeString, Value: "overflow"}}); !errors.Is(err, ErrRowLimitExceeded) {
+		t.Fatalf("expected error %v, got %v", ErrRowLimitExceeded, err)
+	}
+	if writer.rowIndex != MaxRows {
+		t.Errorf("expected rowIndex %d, got %d", MaxRows, writer.rowIndex)
+	}
+}
+
+// zipPart returns the content of the file called name in the zip archive.
+func zipPart(t *testing.T, archive []byte, name string) string {
+	t.Helper()
+
+	zipReader, err := zip.NewReader(bytes.NewReader(archive), int64(len(archive)))
+	if err != nil {
+		t.Fatalf("open zip failed: %v", err)
+	}
+	file, err := zipReader.Open(name)
+	if err != nil {
+		t.Fatalf("open '%s' failed: %v", name, err)
+	}
+	defer file.Close()
+	content, err := io.ReadAll(file)
+	if err != nil {
+		t.Fatalf("read '%s' failed: %v", name, err)
+	}
+	return string(content)
+}
@@ -0,0 +1,67 @@
+package jsonschema
+
+import (
+	"errors"
+
+	"github.com/rogonion/go-metadatamodel/core"
+)
+
+// Draft202012 is the value of KeywordSchema in exported JSON Schemas.
+const Draft202012 string = "https://json-schema.org/draft/2020-12/schema"
+
+// JSON Schema keywords read and written by Export and Import.
+const (
+	KeywordSchema      string = "$schema"
+	KeywordRef         string = "$ref"
+	KeywordType        string = "type"
+	KeywordFormat      string = "format"
+	KeywordTitle       string = "title"
+	KeywordDescription string = "description"
+	KeywordDefault     string = "default"
+	KeywordEnum        string = "enum"
+	KeywordItems       string = "items"
+	KeywordMaxItems    string = "maxItems"
+	KeywordProperties  string = "properties"
+
+	// KeywordPropertyOrder lists the keys in KeywordProperties in core.GroupReadOrderOfFields order.
+	//
+	// JSON Schema does not define property ordering. This annotation is understood by form generators like json-editor.
+	KeywordPropertyOrder string = "propertyOrder"
+
+	// ExtensionKeywordPrefix is prepended to field/group properties without a JSON Schema equivalent e.g. `x-DatabaseTableCollectionName`.
+	ExtensionKeywordPrefix string = "x-"
+)
+
+// Values of KeywordType.
+const (
+	TypeObject  string = "object"
+	TypeArray   string = "array"
+	TypeString  string = "string"
+	TypeNumber  string = "number"
+	TypeInteger string = "integer"
+	TypeBoolean string = "boolean"
+	TypeNull    string = "null"
+)
+
+// Values of KeywordFormat.
+const (
+	FormatDateTime string = "date-time"
+	FormatDate     string = "date"
+)
+
+var (
+	// ErrJsonSchemaError default error for json schema module.
+	ErrJsonSchemaError = errors.New("json schema conversion encountered an error")
+
+	// ErrJsonSchemaInvalid for when a JSON Schema does not describe an object with properties.
+	ErrJsonSchemaInvalid = errors.New("json schema invalid")
+
+	// ErrJsonSchemaKeywordUnsupported for when a JSON Schema contains keywords that Import cannot convert e.g. KeywordRef.
+	ErrJsonSchemaKeywordUnsupported = errors.New("json schema keyword not supported")
+)
+
+// NewError creates a new core.Error with the default json schema error base.
+func NewError() *core.Error {
+	n := core.NewError().WithDefaultBaseError(ErrJsonSchemaError)
+	return n
+}
@@ -0,0 +1,27 @@
+/*
+Package jsonschema converts metadata models to and from JSON Schema (draft 2020-12).
+
+Export describes a single record of source data. The root group becomes an object schema and every other field/group becomes an array schema since its value is always a collection:
+  - core.FieldDataType becomes `type` and `format` of `items`.
+  - core.FieldSelectOptions becomes `enum`, core.FieldDefaultValue becomes `default`.
+  - core.FieldGroupMaxEntries becomes `maxItems`, core.FieldGroupName becomes `title`, core.FieldGroupDescription becomes `description`.
+  - core.GroupReadOrderOfFields becomes `propertyOrder`.
+  - Every other property is kept as an extension keyword prefixed with `x-`.
+
+Import reverses Export. It also accepts generic object schemas, in which case properties that are not array schemas get core.FieldGroupMaxEntries of `1`.
+
+# Usage
+
+	import (
+		"github.com/rogonion/go-metadatamodel/jsonschema"
+	)
+
+## Export
+
+	jsonSchema, err := jsonschema.Export(metadataModel)
+
+## Import
+
+	metadataModel, err := jsonschema.Import(jsonSchema)
+*/
+package jsonschema
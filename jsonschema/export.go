@@ -0,0 +1,202 @@
+package jsonschema
+
+import (
+	"fmt"
+	"reflect"
+
+	gojsoncore "github.com/rogonion/go-json/core"
+	"github.com/rogonion/go-json/schema"
+	"github.com/rogonion/go-metadatamodel/core"
+)
+
+/*
+Export converts metadataModel into a JSON Schema (draft 2020-12) describing a single record of its source data.
+
+The root group becomes an object schema. Every other field/group becomes an array schema since each holds a collection of values:
+  - Groups have KeywordItems of type TypeObject with KeywordProperties and KeywordPropertyOrder from core.GroupReadOrderOfFields.
+  - core.FieldDataType becomes KeywordType and KeywordFormat of KeywordItems.
+  - core.FieldSelectOptions becomes KeywordEnum of KeywordItems.
+  - core.FieldDefaultValue becomes KeywordDefault of KeywordItems.
+  - core.FieldGroupMaxEntries greater than `0` becomes KeywordMaxItems.
+  - core.FieldGroupName becomes KeywordTitle.
+  - core.FieldGroupDescription becomes KeywordDescription.
+
+Every other property, including custom ones, is kept as an extension keyword prefixed with ExtensionKeywordPrefix so that Import can restore it.
+*/
+func Export(metadataModel gojsoncore.JsonObject) (gojsoncore.JsonObject, error) {
+	const FunctionName = "Export"
+
+	jsonSchema, err := exportGroupObject(metadataModel)
+	if err != nil {
+		return nil, NewError().WithFunctionName(FunctionName).WithMessage("export metadata model failed").WithNestedError(err)
+	}
+	if err := exportAnnotations(metadataModel, jsonSchema, jsonSchema, true); err != nil {
+		return nil, NewError().WithFunctionName(FunctionName).WithMessage("export metadata model properties failed").WithNestedError(err)
+	}
+	jsonSchema[KeywordSchema] = Draft202012
+
+	return jsonSchema, nil
+}
+
+// exportFieldGroup converts a field/group that is not the root group into an array schema.
+func exportFieldGroup(fieldGroup gojsoncore.JsonObject) (gojsoncore.JsonObject, error) {
+	var items gojsoncore.JsonObject
+	if core.IsFieldAGroup(fieldGroup) {
+		groupObject, err := exportGroupObject(fieldGroup)
+		if err != nil {
+			return nil, err
+		}
+		items = groupObject
+	} else {
+		items = exportFieldItems(fieldGroup)
+	}
+
+	jsonSchema := gojsoncore.JsonObject{
+		KeywordType:  TypeArray,
+		KeywordItems: items,
+	}
+	if err := exportAnnotations(fieldGroup, jsonSchema, items, false); err != nil {
+		return nil, err
+	}
+	return jsonSchema, nil
+}
+
+// exportGroupObject converts the fields/groups in group into an object schema.
+func exportGroupObject(group gojsoncore.JsonObject) (gojsoncore.JsonObject, error) {
+	groupReadOrderOfFields, err := core.GetGroupReadOrderOfFields(group)
+	if err != nil {
+		return nil, fmt.Errorf("get %s of '%s' failed: %w", core.GroupReadOrderOfFields, core.GetFieldGroupName(group, ""), err)
+	}
+	groupFields, err := core.GetGroupFields(group)
+	if err != nil {
+		return nil, fmt.Errorf("get %s of '%s' failed: %w", core.GroupFields, core.GetFieldGroupName(group, ""), err)
+	}
+
+	properties := make(gojsoncore.JsonObject)
+	propertyOrder := make(gojsoncore.JsonArray, 0, len(groupReadOrderOfFields))
+	for _, fieldGroupKeySuffix := range groupReadOrderOfFields {
+		fieldGroup, err := core.AsJsonObject(groupFields[fieldGroupKeySuffix])
+		if err != nil {
+			return nil, fmt.Errorf("field/group '%s' of '%s' not valid: %w", fieldGroupKeySuffix, core.GetFieldGroupName(group, ""), err)
+		}
+		property, err := exportFieldGroup(fieldGroup)
+		if err != nil {
+			return nil, err
+		}
+		properties[fieldGroupKeySuffix] = property
+		propertyOrder = append(propertyOrder, fieldGroupKeySuffix)
+	}
+
+	return gojsoncore.JsonObject{
+		KeywordType:          TypeObject,
+		KeywordProperties:    properties,
+		KeywordPropertyOrder: propertyOrder,
+	}, nil
+}
+
+// exportFieldItems converts the value type of field into a schema for each of its values.
+func exportFieldItems(field gojsoncore.JsonObject) gojsoncore.JsonObject {
+	items := make(gojsoncore.JsonObject)
+
+	fieldDataType, _ := field[core.FieldDataType].(string)
+	switch fieldDataType {
+	case core.FieldTypeText:
+		items[KeywordType] = TypeString
+	case core.FieldTypeNumber:
+		items[KeywordType] = TypeNumber
+	case core.FieldTypeBoolean:
+		items[KeywordType] = TypeBoolean
+	case core.FieldTypeTimestamp:
+		items[KeywordType] = TypeString
+		switch fieldDatetimeFormat, _ := field[core.FieldDatetimeFormat].(string); fieldDatetimeFormat {
+		case "":
+			items[KeywordFormat] = FormatDateTime
+		case core.FieldDatetimeFormatYYYYMMDD:
+			items[KeywordFormat] = FormatDate
+		}
+	}
+
+	if selectOptions, err := core.AsJsonArray(field[core.FieldSelectOptions]); err == nil {
+		enum := make(gojsoncore.JsonArray, 0, len(selectOptions))
+		for _, selectOption := range selectOptions {
+			if selectOption, err := core.AsJsonObject(selectOption); err == nil {
+				enum = append(enum, selectOption[core.Value])
+			}
+		}
+		items[KeywordEnum] = enum
+	}
+
+	if fieldDefaultValue, ok := field[core.FieldDefaultValue]; ok {
+		items[KeywordDefault] = fieldDefaultValue
+	}
+
+	return items
+}
+
+// exportAnnotations sets KeywordTitle, KeywordDescription, KeywordMaxItems, and extension keywords of jsonSchema from the properties of fieldGroup.
+//
+// Properties already represented in items by exportFieldItems are skipped.
+func exportAnnotations(fieldGroup gojsoncore.JsonObject, jsonSchema gojsoncore.JsonObject, items gojsoncore.JsonObject, isRoot bool) error {
+	for property, value := range fieldGroup {
+		switch property {
+		case core.FieldGroupJsonPathKey, core.GroupFields, core.GroupReadOrderOfFields, core.FieldDefaultValue:
+			continue
+		case core.FieldGroupName:
+			if title, ok := value.(string); ok && len(title) > 0 {
+				jsonSchema[KeywordTitle] = title
+				continue
+			}
+		case core.FieldGroupDescription:
+			if description, ok := value.(string); ok && len(description) > 0 {
+				jsonSchema[KeywordDescription] = description
+				continue
+			}
+		case core.FieldGroupMaxEntries:
+			if isRoot {
+				break
+			}
+			var maxEntries int
+			if err := schema.NewConversion().Convert(value, &schema.DynamicSchemaNode{Type: reflect.TypeOf(0), Kind: reflect.Int}, &maxEntries); err != nil {
+				return fmt.Errorf("convert '%s' of '%s' to int failed: %w", core.FieldGroupMaxEntries, core.GetFieldGroupName(fieldGroup, ""), err)
+			}
+			if maxEntries > 0 {
+				jsonSchema[KeywordMaxItems] = maxEntries
+				continue
+			}
+		case core.FieldDataType:
+			if fieldDataType, _ := value.(string); fieldDataType == importFieldDataType(items) {
+				continue
+			}
+		case core.FieldDatetimeFormat:
+			if fieldDatetimeFormat, _ := items[KeywordFormat].(string); fieldDatetimeFormat == FormatDate && value == core.FieldDatetimeFormatYYYYMMDD {
+				continue
+			}
+		case core.FieldSelectOptions:
+			if isFieldSelectOptionsDerivable(value, fieldGroup[core.FieldDataType]) {
+				continue
+			}
+		}
+
+		jsonSchema[ExtensionKeywordPrefix+property] = value
+	}
+
+	return nil
+}
+
+// isFieldSelectOptionsDerivable checks if Import would recreate selectOptions from KeywordEnum i.e. each Label is the string form of Value and each Type is fieldDataType.
+func isFieldSelectOptionsDerivable(selectOptions any, fieldDataType any) bool {
+	selectOptionsArray, err := core.AsJsonArray(selectOptions)
+	if err != nil {
+		return false
+	}
+	for _, selectOption := range selectOptionsArray {
+		selectOptionObject, err := core.AsJsonObject(selectOption)
+		if err != nil || len(selectOptionObject) != 3 {
+			return false
+		}
+		if selectOptionObject[core.Label] != fmt.Sprint(selectOptionObject[core.Value]) || selectOptionObject[core.Type] != fieldDataType {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,235 @@
+package jsonschema
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	gojsoncore "github.com/rogonion/go-json/core"
+	"github.com/rogonion/go-json/path"
+	"github.com/rogonion/go-metadatamodel/core"
+)
+
+/*
+Import converts jsonSchema into a metadata model. It reverses Export.
+
+jsonSchema must be an object schema with KeywordProperties, or an array schema whose KeywordItems is one.
+
+Each property becomes:
+  - A group if its schema, or KeywordItems of its schema if it is an array schema, is an object schema.
+  - A field otherwise. core.FieldDataType is derived from KeywordType and KeywordFormat.
+
+Properties that are not array schemas get core.FieldGroupMaxEntries of `1`.
+
+Keywords prefixed with ExtensionKeywordPrefix are restored as field/group properties and take precedence over derived ones. Other keywords are ignored. KeywordRef is not supported.
+*/
+func Import(jsonSchema gojsoncore.JsonObject) (gojsoncore.JsonObject, error) {
+	const FunctionName = "Import"
+
+	objectSchema := jsonSchema
+	isCollection := false
+	if schemaType(jsonSchema) == TypeArray {
+		items, err := core.AsJsonObject(jsonSchema[KeywordItems])
+		if err != nil {
+			return nil, NewError().WithFunctionName(FunctionName).WithMessage(fmt.Sprintf("'%s' of root array schema is not an object", KeywordItems)).WithNestedError(ErrJsonSchemaInvalid)
+		}
+		objectSchema = items
+		isCollection = true
+	}
+	if !isObjectSchema(objectSchema) {
+		return nil, NewError().WithFunctionName(FunctionName).WithMessage(fmt.Sprintf("root schema has no '%s'", KeywordProperties)).WithNestedError(ErrJsonSchemaInvalid)
+	}
+
+	metadataModel, err := importGroup(objectSchema, jsonSchema, path.JsonpathKeyRoot, "", isCollection)
+	if err != nil {
+		return nil, NewError().WithFunctionName(FunctionName).WithMessage("import json schema failed").WithNestedError(err)
+	}
+
+	return metadataModel, nil
+}
+
+// importFieldGroup converts propertySchema into a field/group.
+func importFieldGroup(propertySchema gojsoncore.JsonObject, fieldGroupJsonPathKey string, fieldGroupKeySuffix string) (gojsoncore.JsonObject, error) {
+	if err := checkKeywordsSupported(propertySchema, fieldGroupJsonPathKey); err != nil {
+		return nil, err
+	}
+
+	items := propertySchema
+	isCollection := false
+	if schemaType(propertySchema) == TypeArray {
+		isCollection = true
+		if itemsSchema, err := core.AsJsonObject(propertySchema[KeywordItems]); err == nil {
+			items = itemsSchema
+		} else {
+			items = make(gojsoncore.JsonObject)
+		}
+		if err := checkKeywordsSupported(items, fieldGroupJsonPathKey); err != nil {
+			return nil, err
+		}
+	}
+
+	if isObjectSchema(items) {
+		return importGroup(items, propertySchema, fieldGroupJsonPathKey, fieldGroupKeySuffix, isCollection)
+	}
+
+	fieldDataType := importFieldDataType(items)
+	field := gojsoncore.JsonObject{
+		core.FieldGroupJsonPathKey: fieldGroupJsonPathKey,
+		core.FieldDataType:         fieldDataType,
+	}
+	if fieldUi := core.DefaultFieldUi(fieldDataType); len(fieldUi) > 0 {
+		field[core.FieldUI] = fieldUi
+	}
+	if format, _ := items[KeywordFormat].(string); format == FormatDate {
+		field[core.FieldDatetimeFormat] = core.FieldDatetimeFormatYYYYMMDD
+	}
+	if enum, err := core.AsJsonArray(items[KeywordEnum]); err == nil {
+		field[core.FieldSelectOptions] = importFieldSelectOptions(enum, fieldDataType)
+		field[core.FieldUI] = core.FieldUiSelect
+	}
+	if defaultValue, ok := items[KeywordDefault]; ok {
+		field[core.FieldDefaultValue] = defaultValue
+	}
+
+	importAnnotations(field, propertySchema, fieldGroupKeySuffix, isCollection)
+
+	return field, nil
+}
+
+// importGroup converts the properties of objectSchema into a group. Annotations are read from annotationsSchema.
+func importGroup(objectSchema gojsoncore.JsonObject, annotationsSchema gojsoncore.JsonObject, fieldGroupJsonPathKey string, fieldGroupKeySuffix string, isCollection bool) (gojsoncore.JsonObject, error) {
+	properties, _ := core.AsJsonObject(objectSchema[KeywordProperties])
+
+	groupReadOrderOfFields := make([]string, 0, len(properties))
+	if propertyOrder, err := core.AsJsonArray(objectSchema[KeywordPropertyOrder]); err == nil {
+		for _, property := range propertyOrder {
+			if property, ok := property.(string); ok {
+				if _, ok := properties[property]; ok && !slices.Contains(groupReadOrderOfFields, property) {
+					groupReadOrderOfFields = append(groupReadOrderOfFields, property)
+				}
+			}
+		}
+	}
+	remainingProperties := make([]string, 0)
+	for property := range properties {
+		if !slices.Contains(groupReadOrderOfFields, property) {
+			remainingProperties = append(remainingProperties, property)
+		}
+	}
+	sort.Strings(remainingProperties)
+	groupReadOrderOfFields = append(groupReadOrderOfFields, remainingProperties...)
+
+	groupFields := make(gojsoncore.JsonObject)
+	readOrder := make(gojsoncore.JsonArray, 0, len(groupReadOrderOfFields))
+	for _, property := range groupReadOrderOfFields {
+		propertySchema, err := core.AsJsonObject(properties[property])
+		if err != nil {
+			return nil, fmt.Errorf("schema of property '%s' in '%s' is not an object: %w", property, fieldGroupJsonPathKey, ErrJsonSchemaInvalid)
+		}
+		fieldGroup, err := importFieldGroup(propertySchema, fieldGroupJsonPathKey+core.GroupJsonPathPrefix+property, property)
+		if err != nil {
+			return nil, err
+		}
+		groupFields[property] = fieldGroup
+		readOrder = append(readOrder, property)
+	}
+
+	group := gojsoncore.JsonObject{
+		core.FieldGroupJsonPathKey:  fieldGroupJsonPathKey,
+		core.GroupFields:            gojsoncore.JsonArray{groupFields},
+		core.GroupReadOrderOfFields: readOrder,
+	}
+
+	importAnnotations(group, annotationsSchema, fieldGroupKeySuffix, isCollection)
+
+	return group, nil
+}
+
+// importAnnotations sets properties of fieldGroup from KeywordTitle, KeywordDescription, KeywordMaxItems, and extension keywords in jsonSchema.
+//
+// An empty fieldGroupKeySuffix indicates the root group.
+func importAnnotations(fieldGroup gojsoncore.JsonObject, jsonSchema gojsoncore.JsonObject, fieldGroupKeySuffix string, isCollection bool) {
+	if title, ok := jsonSchema[KeywordTitle].(string); ok && len(title) > 0 {
+		fieldGroup[core.FieldGroupName] = title
+	} else if len(fieldGroupKeySuffix) > 0 {
+		fieldGroup[core.FieldGroupName] = fieldGroupKeySuffix
+	}
+	if description, ok := jsonSchema[KeywordDescription].(string); ok && len(description) > 0 {
+		fieldGroup[core.FieldGroupDescription] = description
+	}
+	if isCollection {
+		if maxItems, ok := jsonSchema[KeywordMaxItems]; ok {
+			fieldGroup[core.FieldGroupMaxEntries] = maxItems
+		}
+	} else if len(fieldGroupKeySuffix) > 0 {
+		fieldGroup[core.FieldGroupMaxEntries] = 1
+	}
+
+	for keyword, value := range jsonSchema {
+		if property, ok := strings.CutPrefix(keyword, ExtensionKeywordPrefix); ok && len(property) > 0 {
+			fieldGroup[property] = value
+		}
+	}
+}
+
+// importFieldDataType returns the core.FieldDataType matching KeywordType and KeywordFormat of items.
+func importFieldDataType(items gojsoncore.JsonObject) string {
+	switch schemaType(items) {
+	case TypeString:
+		if format, _ := items[KeywordFormat].(string); format == FormatDateTime || format == FormatDate {
+			return core.FieldTypeTimestamp
+		}
+		return core.FieldTypeText
+	case TypeNumber, TypeInteger:
+		return core.FieldTypeNumber
+	case TypeBoolean:
+		return core.FieldTypeBoolean
+	default:
+		return core.FieldTypeAny
+	}
+}
+
+// importFieldSelectOptions converts each value in enum into a core.FieldSelectOptions entry.
+func importFieldSelectOptions(enum gojsoncore.JsonArray, fieldDataType any) gojsoncore.JsonArray {
+	selectOptions := make(gojsoncore.JsonArray, 0, len(enum))
+	for _, value := range enum {
+		selectOptions = append(selectOptions, gojsoncore.JsonObject{
+			core.Label: fmt.Sprint(value),
+			core.Type:  fieldDataType,
+			core.Value: value,
+		})
+	}
+	return selectOptions
+}
+
+// schemaType returns KeywordType of jsonSchema. If it is a list of types, the first one that is not TypeNull is returned.
+func schemaType(jsonSchema gojsoncore.JsonObject) string {
+	if value, ok := jsonSchema[KeywordType].(string); ok {
+		return value
+	}
+	if values, err := core.AsJsonArray(jsonSchema[KeywordType]); err == nil {
+		for _, value := range values {
+			if value, ok := value.(string); ok && value != TypeNull {
+				return value
+			}
+		}
+	}
+	return ""
+}
+
+// isObjectSchema checks if jsonSchema describes an object with KeywordProperties.
+func isObjectSchema(jsonSchema gojsoncore.JsonObject) bool {
+	if _, err := core.AsJsonObject(jsonSchema[KeywordProperties]); err == nil {
+		return schemaType(jsonSchema) == TypeObject || schemaType(jsonSchema) == ""
+	}
+	return false
+}
+
+// checkKeywordsSupported returns an error if jsonSchema contains keywords that Import cannot convert.
+func checkKeywordsSupported(jsonSchema gojsoncore.JsonObject, fieldGroupJsonPathKey string) error {
+	if _, ok := jsonSchema[KeywordRef]; ok {
+		return fmt.Errorf("'%s' in '%s': %w", KeywordRef, fieldGroupJsonPathKey, ErrJsonSchemaKeywordUnsupported)
+	}
+	return nil
+}
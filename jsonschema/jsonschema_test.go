@@ -0,0 +1,290 @@
+package jsonschema
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	gojsoncore "github.com/rogonion/go-json/core"
+	"github.com/rogonion/go-metadatamodel/builder"
+	"github.com/rogonion/go-metadatamodel/core"
+	"github.com/rogonion/go-metadatamodel/internal"
+	"github.com/rogonion/go-metadatamodel/testdata"
+)
+
+func TestJsonSchema_RoundTrip(t *testing.T) {
+	for testData := range roundTripTestData {
+		jsonSchema, err := Export(testData.MetadataModel)
+		if err != nil {
+			t.Error(testData.TestTitle, "\n", "Export failed:", err)
+			continue
+		}
+		metadataModel, err := Import(internal.AsJsonDecoded(t, jsonSchema).(map[string]any))
+		if err != nil {
+			t.Error(testData.TestTitle, "\n", "Import failed:", err)
+			continue
+		}
+
+		if expected, actual := internal.AsJsonDecoded(t, testData.MetadataModel), internal.AsJsonDecoded(t, metadataModel); !reflect.DeepEqual(expected, actual) {
+			t.Error(
+				testData.TestTitle, "\n",
+				"expected metadataModel to be equal to testData.MetadataModel\n",
+				"MetadataModel=", gojsoncore.JsonStringifyMust(expected), "\n",
+				"metadataModel=", gojsoncore.JsonStringifyMust(actual), "\n",
+				"jsonSchema=", gojsoncore.JsonStringifyMust(jsonSchema),
+			)
+		}
+	}
+}
+
+type roundTripData struct {
+	internal.TestData
+	MetadataModel gojsoncore.JsonObject
+}
+
+func roundTripTestData(yield func(data *roundTripData) bool) {
+	testCaseIndex := 1
+	for _, metadataModel := range []gojsoncore.JsonObject{
+		testdata.UserMetadataModel(nil),
+		testdata.ProductMetadataModel(nil),
+		testdata.CompanyMetadataModel(nil),
+		testdata.AddressMetadataModel(nil),
+		testdata.UserProfileMetadataModel(nil),
+		testdata.EmployeeMetadataModel(nil),
+	} {
+		if !yield(
+			&roundTripData{
+				TestData: internal.TestData{
+					TestTitle: core.GetFieldGroupName(metadataModel, "") + " metadata model",
+				},
+				MetadataModel: metadataModel,
+			},
+		) {
+			return
+		}
+		testCaseIndex++
+	}
+
+	if !yield(
+		&roundTripData{
+			TestData: internal.TestData{
+				TestTitle: "Select options, default values, and custom properties",
+			},
+			MetadataModel: builder.Must(
+				builder.NewModel("Order").Description("An order").Property("Owner", "sales").
+					Field("Status", core.FieldTypeText).SelectOption("Open", "Open").SelectOption("Closed", "Closed").DefaultValue("Open").MaxEntries(1).
+					Field("Priority", core.FieldTypeNumber).SelectOption("Low", 1).SelectOption("High", 2).MaxEntries(1).
+					Field("Paid", core.FieldTypeBoolean).MaxEntries(1).
+					Field("Placed", core.FieldTypeTimestamp).DatetimeFormat(core.FieldDatetimeFormatYYYYMMDD).
+					Field("Delivered", core.FieldTypeTimestamp).DatetimeFormat(core.FieldDatetimeFormatYYYYMMDDHHMM).Property("Hint", "local time").
+					Group("Items").MaxEntries(10).
+					Field("Sku", core.FieldTypeText).PrimaryKey().
+					Field("Extra", core.FieldTypeAny).
+					End().
+					Build(),
+			),
+		},
+	) {
+		return
+	}
+}
+
+func TestJsonSchema_Export(t *testing.T) {
+	metadataModel := builder.Must(
+		builder.NewModel("Order").
+			Field("Placed", core.FieldTypeTimestamp).DatetimeFormat(core.FieldDatetimeFormatYYYYMMDD).MaxEntries(1).
+			Group("Items").
+			Field("Quantity", core.FieldTypeNumber).DefaultValue(1).
+			End().
+			Build(),
+	)
+	expected := gojsoncore.JsonObject{
+		KeywordSchema: Draft202012,
+		KeywordTitle:  "Order",
+		KeywordType:   TypeObject,
+		KeywordProperties: gojsoncore.JsonObject{
+			"Placed": gojsoncore.JsonObject{
+				KeywordType:                           TypeArray,
+				KeywordTitle:                          "Placed",
+				KeywordMaxItems:                       1,
+				ExtensionKeywordPrefix + core.FieldUI: core.FieldUiDatetime,
+				KeywordItems: gojsoncore.JsonObject{
+					KeywordType:   TypeString,
+					KeywordFormat: FormatDate,
+				},
+			},
+			"Items": gojsoncore.JsonObject{
+				KeywordType:  TypeArray,
+				KeywordTitle: "Items",
+				KeywordItems: gojsoncore.JsonObject{
+					KeywordType: TypeObject,
+					KeywordProperties: gojsoncore.JsonObject{
+						"Quantity": gojsoncore.JsonObject{
+							KeywordType:                           TypeArray,
+							KeywordTitle:                          "Quantity",
+							ExtensionKeywordPrefix + core.FieldUI: core.FieldUiNumber,
+							KeywordItems: gojsoncore.JsonObject{
+								KeywordType:    TypeNumber,
+								KeywordDefault: 1,
+							},
+						},
+					},
+					KeywordPropertyOrder: gojsoncore.JsonArray{"Quantity"},
+				},
+			},
+		},
+		KeywordPropertyOrder: gojsoncore.JsonArray{"Placed", "Items"},
+	}
+
+	jsonSchema, err := Export(metadataModel)
+	if err != nil {
+		t.Fatal("Export failed:", err)
+	}
+	if expected, actual := internal.AsJsonDecoded(t, expected), internal.AsJsonDecoded(t, jsonSchema); !reflect.DeepEqual(expected, actual) {
+		t.Error(
+			"expected jsonSchema to be equal to expected\n",
+			"expected=", gojsoncore.JsonStringifyMust(expected), "\n",
+			"jsonSchema=", gojsoncore.JsonStringifyMust(actual),
+		)
+	}
+}
+
+func TestJsonSchema_Import(t *testing.T) {
+	for testData := range importTestData {
+		metadataModel, err := Import(testData.JsonSchema)
+		if testData.ExpectedErr != nil {
+			if !errors.Is(err, testData.ExpectedErr) {
+				t.Error(testData.TestTitle, "\n", "expected error", testData.ExpectedErr, "got", err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Error(testData.TestTitle, "\n", "Import failed:", err)
+			continue
+		}
+
+		if expected, actual := internal.AsJsonDecoded(t, testData.Expected), internal.AsJsonDecoded(t, metadataModel); !reflect.DeepEqual(expected, actual) {
+			t.Error(
+				testData.TestTitle, "\n",
+				"expected metadataModel to be equal to testData.Expected\n",
+				"Expected=", gojsoncore.JsonStringifyMust(expected), "\n",
+				"metadataModel=", gojsoncore.JsonStringifyMust(actual),
+			)
+		}
+	}
+}
+
+type importData struct {
+	internal.TestData
+	JsonSchema  gojsoncore.JsonObject
+	Expected    gojsoncore.JsonObject
+	ExpectedErr error
+}
+
+func importTestData(yield func(data *importData) bool) {
+	testCaseIndex := 1
+	if !yield(
+		&importData{
+			TestData: internal.TestData{
+				TestTitle: "Plain object schema",
+			},
+			JsonSchema: gojsoncore.JsonObject{
+				KeywordTitle: "Pet",
+				KeywordType:  TypeObject,
+				KeywordProperties: gojsoncore.JsonObject{
+					"name":   gojsoncore.JsonObject{KeywordType: TypeString, KeywordDescription: "Name of the pet"},
+					"age":    gojsoncore.JsonObject{KeywordType: gojsoncore.JsonArray{TypeNull, TypeInteger}},
+					"born":   gojsoncore.JsonObject{KeywordType: TypeString, KeywordFormat: FormatDate},
+					"kind":   gojsoncore.JsonObject{KeywordEnum: gojsoncore.JsonArray{"cat", "dog"}},
+					"extra":  gojsoncore.JsonObject{KeywordType: TypeObject},
+					"owner":  gojsoncore.JsonObject{KeywordType: TypeObject, KeywordProperties: gojsoncore.JsonObject{"email": gojsoncore.JsonObject{KeywordType: TypeString}}},
+					"tags":   gojsoncore.JsonObject{KeywordType: TypeArray, KeywordItems: gojsoncore.JsonObject{KeywordType: TypeString}, KeywordMaxItems: 5},
+					"visits": gojsoncore.JsonObject{KeywordType: TypeArray, KeywordItems: gojsoncore.JsonObject{KeywordProperties: gojsoncore.JsonObject{"vaccinated": gojsoncore.JsonObject{KeywordType: TypeBoolean}}}},
+				},
+				KeywordPropertyOrder: gojsoncore.JsonArray{"name", "kind"},
+			},
+			Expected: builder.Must(
+				builder.NewModel("Pet").
+					Field("name", core.FieldTypeText).Description("Name of the pet").MaxEntries(1).
+					Field("kind", core.FieldTypeAny).SelectOption("cat", "cat").SelectOption("dog", "dog").MaxEntries(1).
+					Field("age", core.FieldTypeNumber).MaxEntries(1).
+					Field("born", core.FieldTypeTimestamp).DatetimeFormat(core.FieldDatetimeFormatYYYYMMDD).MaxEntries(1).
+					Field("extra", core.FieldTypeAny).MaxEntries(1).
+					Group("owner").MaxEntries(1).
+					Field("email", core.FieldTypeText).MaxEntries(1).
+					End().
+					Field("tags", core.FieldTypeText).MaxEntries(5).
+					Group("visits").
+					Field("vaccinated", core.FieldTypeBoolean).MaxEntries(1).
+					End().
+					Build(),
+			),
+		},
+	) {
+		return
+	}
+
+	testCaseIndex++
+	if !yield(
+		&importData{
+			TestData: internal.TestData{
+				TestTitle: "Root array schema",
+			},
+			JsonSchema: gojsoncore.JsonObject{
+				KeywordType:     TypeArray,
+				KeywordMaxItems: 3,
+				KeywordItems: gojsoncore.JsonObject{
+					KeywordType:       TypeObject,
+					KeywordProperties: gojsoncore.JsonObject{"id": gojsoncore.JsonObject{KeywordType: TypeInteger}},
+				},
+			},
+			Expected: gojsoncore.JsonObject{
+				core.FieldGroupJsonPathKey: "$",
+				core.FieldGroupMaxEntries:  3,
+				core.GroupFields: gojsoncore.JsonArray{gojsoncore.JsonObject{
+					"id": gojsoncore.JsonObject{
+						core.FieldGroupJsonPathKey: "$" + core.GroupJsonPathPrefix + "id",
+						core.FieldGroupName:        "id",
+						core.FieldDataType:         core.FieldTypeNumber,
+						core.FieldUI:               core.FieldUiNumber,
+						core.FieldGroupMaxEntries:  1,
+					},
+				}},
+				core.GroupReadOrderOfFields: gojsoncore.JsonArray{"id"},
+			},
+		},
+	) {
+		return
+	}
+
+	testCaseIndex++
+	if !yield(
+		&importData{
+			TestData: internal.TestData{
+				TestTitle: "Schema with $ref",
+			},
+			JsonSchema: gojsoncore.JsonObject{
+				KeywordType: TypeObject,
+				KeywordProperties: gojsoncore.JsonObject{
+					"owner": gojsoncore.JsonObject{KeywordRef: "#/$defs/owner"},
+				},
+			},
+			ExpectedErr: ErrJsonSchemaKeywordUnsupported,
+		},
+	) {
+		return
+	}
+
+	testCaseIndex++
+	if !yield(
+		&importData{
+			TestData: internal.TestData{
+				TestTitle: "Schema without properties",
+			},
+			JsonSchema:  gojsoncore.JsonObject{KeywordType: TypeString},
+			ExpectedErr: ErrJsonSchemaInvalid,
+		},
+	) {
+		return
+	}
+}
@@ -0,0 +1,111 @@
+package migration
+
+import (
+	"errors"
+	"fmt"
+
+	gojsoncore "github.com/rogonion/go-json/core"
+	"github.com/rogonion/go-json/path"
+	"github.com/rogonion/go-json/schema"
+	"github.com/rogonion/go-metadatamodel/core"
+	"github.com/rogonion/go-metadatamodel/diff"
+)
+
+/*
+Migration moves source data stored using an old version of a metadata model into the structure of a new version.
+
+Usage:
+ 1. Instantiate Migration using NewMigration.
+ 2. Set optional parameters.
+ 3. Migrate source data using Migration.Migrate.
+*/
+type Migration struct {
+	// Differences between oldMetadataModel and newMetadataModel. Refer to diff.Diff.
+	changes diff.Changes
+
+	// Fields/groups by core.FieldGroupJsonPathKey.
+	oldFieldGroups map[path.JSONPath]gojsoncore.JsonObject
+	newFieldGroups map[path.JSONPath]gojsoncore.JsonObject
+
+	// core.FieldGroupJsonPathKey of each field/group in newMetadataModel in read order.
+	newReadOrder []path.JSONPath
+
+	// Schema of a single migrated record. Useful for migrating into user defined types like structs.
+	//
+	// Defaults to nil in which case migrated records are gojsoncore.JsonObject.
+	schema schema.Schema
+
+	// Used to convert values when core.FieldDataType changes.
+	defaultConverter schema.DefaultConverter
+}
+
+/*
+Failure represents a value that could not be migrated.
+*/
+type Failure struct {
+	// RecordIndex is the index of the record in the source data. `0` if the source data is a single record.
+	RecordIndex int
+
+	// core.FieldGroupJsonPathKey of the field in the new metadata model.
+	FieldGroupJsonPathKey path.JSONPath
+
+	// Err classifies the failure e.g. ErrValueConversionFailed. Can be checked using errors.Is.
+	Err error
+}
+
+// String returns a human-readable representation of the Failure.
+func (n Failure) String() string {
+	return fmt.Sprintf("record %d: %s: %v", n.RecordIndex, n.FieldGroupJsonPathKey, n.Err)
+}
+
+// Failures is a list of Failure in the order they were encountered.
+type Failures []Failure
+
+/*
+Err returns nil if there are no failures.
+
+Otherwise, returns an error that wraps ErrMigrationFailed and the Failure.Err of each failure.
+*/
+func (n Failures) Err() error {
+	if len(n) == 0 {
+		return nil
+	}
+
+	errs := make([]error, 0, len(n)+1)
+	errs = append(errs, ErrMigrationFailed)
+	for _, failure := range n {
+		errs = append(errs, fmt.Errorf("record %d: %s: %w", failure.RecordIndex, failure.FieldGroupJsonPathKey, failure.Err))
+	}
+	return errors.Join(errs...)
+}
+
+// RecordIndexes returns the index of each record with at least one failure in ascending order.
+func (n Failures) RecordIndexes() []int {
+	recordIndexes := make([]int, 0)
+	for _, failure := range n {
+		if len(recordIndexes) == 0 || recordIndexes[len(recordIndexes)-1] != failure.RecordIndex {
+			recordIndexes = append(recordIndexes, failure.RecordIndex)
+		}
+	}
+	return recordIndexes
+}
+
+var (
+	// ErrMigrationError default error for migration module.
+	ErrMigrationError = errors.New("migration encountered an error")
+
+	// ErrMigrationFailed for when one or more values could not be migrated.
+	ErrMigrationFailed = errors.New("migration failed")
+
+	// ErrValueConversionFailed for when a value could not be converted to the new core.FieldDataType.
+	ErrValueConversionFailed = errors.New("value conversion failed")
+
+	// ErrValueSetFailed for when a value could not be set in the migrated record.
+	ErrValueSetFailed = errors.New("value set failed")
+)
+
+// NewError creates a new core.Error with the default migration error base.
+func NewError() *core.Error {
+	n := core.NewError().WithDefaultBaseError(ErrMigrationError)
+	return n
+}
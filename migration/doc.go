@@ -0,0 +1,30 @@
+/*
+Package migration moves source data stored using one version of a metadata model into the structure of another.
+
+Differences between the two versions are detected using diff.Diff. For each field in the new metadata model, values are read from the old location using object.Object.ForEach and written using object.Object.Set, the same way database.FieldValue manipulates source data:
+  - Values of moved fields/groups are moved.
+  - Values are converted using schema.DefaultConverter when core.FieldDataType changes e.g. Text to Number.
+  - A field that became a group is wrapped into a field of the group. A group that became a field is unwrapped.
+  - Fields only present in the new metadata model are set to core.FieldDefaultValue.
+
+Values that cannot be migrated are reported per record as Failures.
+
+# Usage
+
+	import (
+		"github.com/rogonion/go-json/object"
+		"github.com/rogonion/go-metadatamodel/migration"
+	)
+
+	m, err := migration.NewMigration(oldMetadataModel, newMetadataModel)
+
+	// Optional. Migrate into user defined types like structs.
+	m.SetSchema(recordSchema)
+
+	migratedSourceData, failures := m.Migrate(object.NewObject().WithSourceInterface(records))
+
+	for _, recordIndex := range failures.RecordIndexes() {
+		// Handle records that were partially migrated.
+	}
+*/
+package migration
@@ -0,0 +1,465 @@
+package migration
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	gojsoncore "github.com/rogonion/go-json/core"
+	"github.com/rogonion/go-json/object"
+	"github.com/rogonion/go-json/path"
+	"github.com/rogonion/go-json/schema"
+	"github.com/rogonion/go-metadatamodel/core"
+	"github.com/rogonion/go-metadatamodel/diff"
+	"github.com/rogonion/go-metadatamodel/iter"
+)
+
+/*
+Migrate moves each record in sourceData from the structure of the old metadata model into the structure of the new one.
+
+sourceData can be a single record or a slice/array of records. It is not modified.
+
+For each field in the new metadata model, values are read from its counterpart in the old metadata model using object.Object.ForEach and written using object.Object.Set:
+  - Fields that were moved, or are in a group that was moved, are read from their old location.
+  - Entry indexes are kept for groups present in both metadata models. Values go into the first entry of groups only present in the new metadata model.
+    Values from entries of groups only present in the old metadata model are appended.
+  - Values are converted using schema.DefaultConverter if core.FieldDataType changed.
+  - A field that became a group is wrapped i.e. its values are moved into the field of the new group with the same key suffix, or the first field in core.GroupReadOrderOfFields.
+  - A group that became a field is unwrapped i.e. values are read from the field of the old group chosen in the same way.
+  - Fields without a counterpart are set to core.FieldDefaultValue, if present, in every entry of their group.
+
+Fields/groups that were removed from the metadata model are not carried over.
+
+Returns:
+  - object.Object containing the migrated record, or a gojsoncore.JsonArray of migrated records if sourceData is a slice/array or a pointer to one.
+  - Failures for values that could not be converted or set. Other values in the same record are still migrated.
+*/
+func (n *Migration) Migrate(sourceData *object.Object) (*object.Object, Failures) {
+	failures := make(Failures, 0)
+
+	source := sourceData.GetSourceReflected()
+	for (source.Kind() == reflect.Interface || source.Kind() == reflect.Pointer) && !source.IsNil() {
+		source = source.Elem()
+	}
+	if source.Kind() == reflect.Slice || source.Kind() == reflect.Array {
+		records := make(gojsoncore.JsonArray, 0, source.Len())
+		for recordIndex := 0; recordIndex < source.Len(); recordIndex++ {
+			records = append(records, n.migrateRecord(recordIndex, source.Index(recordIndex).Interface(), &failures))
+		}
+		return object.NewObject().WithSourceInterface(records), failures
+	}
+
+	return object.NewObject().WithSourceInterface(n.migrateRecord(0, sourceData.GetSourceInterface(), &failures)), failures
+}
+
+// migrateRecord returns record in the structure of newMetadataModel.
+func (n *Migration) migrateRecord(recordIndex int, record any, failures *Failures) any {
+	oldRecord := object.NewObject().WithSourceInterface(record)
+	newRecord := object.NewObject().WithSchema(n.schema)
+	if n.schema == nil {
+		newRecord.SetSourceInterface(make(gojsoncore.JsonObject))
+	}
+
+	fieldsWithoutSource := make([]path.JSONPath, 0)
+	for _, newFieldGroupJsonPathKey := range n.newReadOrder {
+		newFieldGroup := n.newFieldGroups[newFieldGroupJsonPathKey]
+
+		if core.IsFieldAGroup(newFieldGroup) {
+			if oldFieldGroupJsonPathKey, ok := n.oldGroup(newFieldGroupJsonPathKey); ok {
+				n.migrateGroupEntries(recordIndex, oldRecord, newRecord, oldFieldGroupJsonPathKey, newFieldGroupJsonPathKey, failures)
+			}
+			continue
+		}
+
+		if oldFieldGroupJsonPathKey, ok := n.oldField(newFieldGroupJsonPathKey); ok {
+			n.migrateFieldValues(recordIndex, oldRecord, newRecord, oldFieldGroupJsonPathKey, newFieldGroupJsonPathKey, failures)
+		} else {
+			fieldsWithoutSource = append(fieldsWithoutSource, newFieldGroupJsonPathKey)
+		}
+	}
+
+	for _, newFieldGroupJsonPathKey := range fieldsWithoutSource {
+		n.setFieldDefaultValue(recordIndex, newRecord, newFieldGroupJsonPathKey, failures)
+	}
+
+	return newRecord.GetSourceInterface()
+}
+
+// migrateGroupEntries creates an empty entry in the new group for each entry in the old group so that entry indexes are kept.
+func (n *Migration) migrateGroupEntries(recordIndex int, oldRecord *object.Object, newRecord *object.Object, oldFieldGroupJsonPathKey path.JSONPath, newFieldGroupJsonPathKey path.JSONPath, failures *Failures) {
+	newGroupEntryJsonPath := jsonPathToValue(newFieldGroupJsonPathKey) + path.JSONPath(core.ArrayPathPlaceholder)
+	oldGroups := append(parentGroups(oldFieldGroupJsonPathKey), oldFieldGroupJsonPathKey)
+	newGroups := append(parentGroups(newFieldGroupJsonPathKey), newFieldGroupJsonPathKey)
+
+	targets := make([]path.JSONPath, 0)
+	oldRecord.ForEach(jsonPathToValue(oldFieldGroupJsonPathKey)+path.JSONPath(core.ArrayPathPlaceholder), func(jsonPath path.RecursiveDescentSegment, value reflect.Value) bool {
+		if isNil(value) {
+			return false
+		}
+		target := replaceArrayPathPlaceholders(newGroupEntryJsonPath, n.newArrayIndexes(newGroups, oldGroups, arrayIndexes(jsonPath)))
+		for _, existingTarget := range targets {
+			if existingTarget == target {
+				return false
+			}
+		}
+		targets = append(targets, target)
+		return false
+	})
+
+	for _, target := range targets {
+		if _, err := newRecord.Set(target, make(gojsoncore.JsonObject)); err != nil {
+			*failures = append(*failures, Failure{RecordIndex: recordIndex, FieldGroupJsonPathKey: newFieldGroupJsonPathKey, Err: fmt.Errorf("set '%s' failed: %w: %w", target, ErrValueSetFailed, err)})
+		}
+	}
+}
+
+// migrateFieldValues copies values of the old field into the new field, converting them if core.FieldDataType changed.
+func (n *Migration) migrateFieldValues(recordIndex int, oldRecord *object.Object, newRecord *object.Object, oldFieldGroupJsonPathKey path.JSONPath, newFieldGroupJsonPathKey path.JSONPath, failures *Failures) {
+	oldFieldDataType, _ := n.oldFieldGroups[oldFieldGroupJsonPathKey][core.FieldDataType].(string)
+	newFieldDataType, _ := n.newFieldGroups[newFieldGroupJsonPathKey][core.FieldDataType].(string)
+	newFieldJsonPath := jsonPathToValue(newFieldGroupJsonPathKey)
+	oldGroups := parentGroups(oldFieldGroupJsonPathKey)
+	newGroups := parentGroups(newFieldGroupJsonPathKey)
+
+	targets := make([]path.JSONPath, 0)
+	targetValues := make(map[path.JSONPath][]any)
+	oldRecord.ForEach(jsonPathToValue(oldFieldGroupJsonPathKey), func(jsonPath path.RecursiveDescentSegment, value reflect.Value) bool {
+		if isNil(value) {
+			return false
+		}
+		target := replaceArrayPathPlaceholders(newFieldJsonPath, n.newArrayIndexes(newGroups, oldGroups, arrayIndexes(jsonPath)))
+		if _, ok := targetValues[target]; !ok {
+			targets = append(targets, target)
+			targetValues[target] = make([]any, 0)
+		}
+
+		for value.Kind() == reflect.Interface || value.Kind() == reflect.Pointer {
+			value = value.Elem()
+		}
+		values := []reflect.Value{value}
+		if value.Kind() == reflect.Slice || value.Kind() == reflect.Array {
+			values = make([]reflect.Value, 0, value.Len())
+			for index := 0; index < value.Len(); index++ {
+				values = append(values, value.Index(index))
+			}
+		}
+
+		for _, value := range values {
+			valueToSet := value.Interface()
+			if oldFieldDataType != newFieldDataType {
+				convertedValue, err := n.convertValue(valueToSet, newFieldDataType)
+				if err != nil {
+					*failures = append(*failures, Failure{RecordIndex: recordIndex, FieldGroupJsonPathKey: newFieldGroupJsonPathKey, Err: fmt.Errorf("convert %v from %s to %s failed: %w: %w", valueToSet, oldFieldDataType, newFieldDataType, ErrValueConversionFailed, err)})
+					continue
+				}
+				valueToSet = convertedValue
+			}
+			targetValues[target] = append(targetValues[target], valueToSet)
+		}
+		return false
+	})
+
+	for _, target := range targets {
+		if len(targetValues[target]) == 0 {
+			continue
+		}
+		if _, err := newRecord.Set(target, targetValues[target]); err != nil {
+			*failures = append(*failures, Failure{RecordIndex: recordIndex, FieldGroupJsonPathKey: newFieldGroupJsonPathKey, Err: fmt.Errorf("set '%s' failed: %w: %w", target, ErrValueSetFailed, err)})
+		}
+	}
+}
+
+// setFieldDefaultValue sets core.FieldDefaultValue of the new field, if present, in every entry of its group.
+func (n *Migration) setFieldDefaultValue(recordIndex int, newRecord *object.Object, newFieldGroupJsonPathKey path.JSONPath, failures *Failures) {
+	fieldDefaultValue, ok := n.newFieldGroups[newFieldGroupJsonPathKey][core.FieldDefaultValue]
+	if !ok || fieldDefaultValue == nil {
+		return
+	}
+	if kind := reflect.ValueOf(fieldDefaultValue).Kind(); kind != reflect.Slice && kind != reflect.Array {
+		fieldDefaultValue = []any{fieldDefaultValue}
+	}
+
+	newFieldJsonPath := jsonPathToValue(newFieldGroupJsonPathKey)
+	targets := make([]path.JSONPath, 0)
+	if parentJsonPathKey := parentFieldGroupJsonPathKey(newFieldGroupJsonPathKey); parentJsonPathKey == path.JSONPath(path.JsonpathKeyRoot) {
+		targets = append(targets, newFieldJsonPath)
+	} else {
+		newRecord.ForEach(jsonPathToValue(parentJsonPathKey)+path.JSONPath(core.ArrayPathPlaceholder), func(jsonPath path.RecursiveDescentSegment, value reflect.Value) bool {
+			if !isNil(value) {
+				targets = append(targets, replaceArrayPathPlaceholders(newFieldJsonPath, arrayIndexes(jsonPath)))
+			}
+			return false
+		})
+	}
+
+	for _, target := range targets {
+		if _, err := newRecord.Set(target, fieldDefaultValue); err != nil {
+			*failures = append(*failures, Failure{RecordIndex: recordIndex, FieldGroupJsonPathKey: newFieldGroupJsonPathKey, Err: fmt.Errorf("set '%s' failed: %w: %w", target, ErrValueSetFailed, err)})
+		}
+	}
+}
+
+// convertValue converts value into the Go type of fieldDataType. Values of core.FieldTypeTimestamp and core.FieldTypeAny are not converted.
+func (n *Migration) convertValue(value any, fieldDataType string) (any, error) {
+	var valueType reflect.Type
+	switch fieldDataType {
+	case core.FieldTypeText:
+		valueType = reflect.TypeOf("")
+	case core.FieldTypeNumber:
+		valueType = reflect.TypeOf(float64(0))
+	case core.FieldTypeBoolean:
+		valueType = reflect.TypeOf(false)
+	default:
+		return value, nil
+	}
+
+	destination := reflect.New(valueType)
+	if err := n.defaultConverter.Convert(value, &schema.DynamicSchemaNode{Type: valueType, Kind: valueType.Kind()}, destination.Interface()); err != nil {
+		return nil, err
+	}
+	return destination.Elem().Interface(), nil
+}
+
+/*
+newArrayIndexes returns the entry index to use for each group in newGroups.
+
+Parameters:
+  - newGroups - core.FieldGroupJsonPathKey of groups in the new metadata model, outermost first.
+  - oldGroups - core.FieldGroupJsonPathKey of groups in the old metadata model, outermost first.
+  - oldIndexes - Entry index in each group in oldGroups.
+
+A new group uses the entry index of its counterpart in oldGroups, or `0` if it does not have one.
+*/
+func (n *Migration) newArrayIndexes(newGroups []path.JSONPath, oldGroups []path.JSONPath, oldIndexes []int) []int {
+	newIndexes := make([]int, len(newGroups))
+	for newIndex, newGroup := range newGroups {
+		oldGroup, ok := n.oldGroup(newGroup)
+		if !ok {
+			continue
+		}
+		for oldIndex := range oldGroups {
+			if oldGroups[oldIndex] == oldGroup && oldIndex < len(oldIndexes) {
+				newIndexes[newIndex] = oldIndexes[oldIndex]
+				break
+			}
+		}
+	}
+	return newIndexes
+}
+
+// oldGroup returns core.FieldGroupJsonPathKey of the group in the old metadata model whose entries the new group takes over.
+func (n *Migration) oldGroup(newFieldGroupJsonPathKey path.JSONPath) (path.JSONPath, bool) {
+	oldFieldGroupJsonPathKey := n.oldFieldGroupJsonPathKey(newFieldGroupJsonPathKey)
+	if oldFieldGroup, ok := n.oldFieldGroups[oldFieldGroupJsonPathKey]; ok && core.IsFieldAGroup(oldFieldGroup) {
+		return oldFieldGroupJsonPathKey, true
+	}
+	return "", false
+}
+
+// oldField returns core.FieldGroupJsonPathKey of the field in the old metadata model whose values the new field takes over.
+func (n *Migration) oldField(newFieldGroupJsonPathKey path.JSONPath) (path.JSONPath, bool) {
+	oldFieldGroupJsonPathKey := n.oldFieldGroupJsonPathKey(newFieldGroupJsonPathKey)
+	if oldFieldGroup, ok := n.oldFieldGroups[oldFieldGroupJsonPathKey]; ok {
+		if !core.IsFieldAGroup(oldFieldGroup) {
+			return oldFieldGroupJsonPathKey, true
+		}
+		// Group became a field.
+		return wrappedField(oldFieldGroup)
+	}
+
+	// Field became a group.
+	newParentJsonPathKey := parentFieldGroupJsonPathKey(newFieldGroupJsonPathKey)
+	oldParentJsonPathKey := n.oldFieldGroupJsonPathKey(newParentJsonPathKey)
+	if oldParent, ok := n.oldFieldGroups[oldParentJsonPathKey]; ok && !core.IsFieldAGroup(oldParent) {
+		if fieldJsonPathKey, ok := wrappedField(n.newFieldGroups[newParentJsonPathKey]); ok && fieldJsonPathKey == newFieldGroupJsonPathKey {
+			return oldParentJsonPathKey, true
+		}
+	}
+
+	return "", false
+}
+
+// oldFieldGroupJsonPathKey returns where newFieldGroupJsonPathKey was in the old metadata model, taking into account diff.ChangeTypeMoved of itself or its parent groups.
+func (n *Migration) oldFieldGroupJsonPathKey(newFieldGroupJsonPathKey path.JSONPath) path.JSONPath {
+	var moved *diff.Change
+	for index, change := range n.changes {
+		if change.Type != diff.ChangeTypeMoved {
+			continue
+		}
+		if newFieldGroupJsonPathKey == change.FieldGroupJsonPathKey || strings.HasPrefix(string(newFieldGroupJsonPathKey), string(change.FieldGroupJsonPathKey)+path.JsonpathDotNotation) {
+			if moved == nil || len(change.FieldGroupJsonPathKey) > len(moved.FieldGroupJsonPathKey) {
+				moved = &n.changes[index]
+			}
+		}
+	}
+	if moved == nil {
+		return newFieldGroupJsonPathKey
+	}
+	return moved.OldFieldGroupJsonPathKey + newFieldGroupJsonPathKey[len(moved.FieldGroupJsonPathKey):]
+}
+
+// wrappedField returns core.FieldGroupJsonPathKey of the field in group with the same key suffix as group, otherwise the first field in core.GroupReadOrderOfFields.
+func wrappedField(group gojsoncore.JsonObject) (path.JSONPath, bool) {
+	groupReadOrderOfFields, err := core.GetGroupReadOrderOfFields(group)
+	if err != nil {
+		return "", false
+	}
+	groupFields, err := core.GetGroupFields(group)
+	if err != nil {
+		return "", false
+	}
+
+	groupJsonPathKey, _ := core.AsJSONPath(group[core.FieldGroupJsonPathKey])
+	groupKeySuffix := string(groupJsonPathKey[len(parentFieldGroupJsonPathKey(groupJsonPathKey)):])
+	groupKeySuffix = strings.TrimPrefix(groupKeySuffix, core.GroupJsonPathPrefix)
+	var firstField gojsoncore.JsonObject
+	for _, fieldGroupKeySuffix := range groupReadOrderOfFields {
+		fieldGroup, err := core.AsJsonObject(groupFields[fieldGroupKeySuffix])
+		if err != nil || core.IsFieldAGroup(fieldGroup) {
+			continue
+		}
+		if fieldGroupKeySuffix == groupKeySuffix {
+			firstField = fieldGroup
+			break
+		}
+		if firstField == nil {
+			firstField = fieldGroup
+		}
+	}
+	if firstField == nil {
+		return "", false
+	}
+
+	fieldJsonPathKey, err := core.AsJSONPath(firstField[core.FieldGroupJsonPathKey])
+	return fieldJsonPathKey, err == nil
+}
+
+// parentFieldGroupJsonPathKey returns core.FieldGroupJsonPathKey of the group containing the field/group.
+func parentFieldGroupJsonPathKey(fieldGroupJsonPathKey path.JSONPath) path.JSONPath {
+	if index := strings.LastIndex(string(fieldGroupJsonPathKey), core.GroupJsonPathPrefix); index >= 0 {
+		return fieldGroupJsonPathKey[:index]
+	}
+	return path.JSONPath(path.JsonpathKeyRoot)
+}
+
+// parentGroups returns core.FieldGroupJsonPathKey of the groups containing the field/group excluding the root group, outermost first.
+func parentGroups(fieldGroupJsonPathKey path.JSONPath) []path.JSONPath {
+	groups := make([]path.JSONPath, 0)
+	for parent := parentFieldGroupJsonPathKey(fieldGroupJsonPathKey); parent != path.JSONPath(path.JsonpathKeyRoot); parent = parentFieldGroupJsonPathKey(parent) {
+		groups = append([]path.JSONPath{parent}, groups...)
+	}
+	return groups
+}
+
+// jsonPathToValue converts core.FieldGroupJsonPathKey into the path to its value in a single record with core.ArrayPathPlaceholder for each group entry.
+func jsonPathToValue(fieldGroupJsonPathKey path.JSONPath) path.JSONPath {
+	jsonPath, _ := core.NewJsonPathToValue().WithRemoveGroupFields(true).WithReplaceArrayPathPlaceholderWithActualIndexes(false).Get(fieldGroupJsonPathKey, nil)
+	return jsonPath
+}
+
+// arrayIndexes returns the array indexes in jsonPath in order.
+func arrayIndexes(jsonPath path.RecursiveDescentSegment) []int {
+	indexes := make([]int, 0)
+	for _, segment := range jsonPath {
+		if segment.IsIndex {
+			indexes = append(indexes, segment.Index)
+		}
+	}
+	return indexes
+}
+
+// replaceArrayPathPlaceholders replaces each core.ArrayPathPlaceholder in jsonPath with the index at the same position in indexes, or `0` if indexes is shorter.
+func replaceArrayPathPlaceholders(jsonPath path.JSONPath, indexes []int) path.JSONPath {
+	jsonPathStr := string(jsonPath)
+	for index := 0; strings.Contains(jsonPathStr, core.ArrayPathPlaceholder); index++ {
+		arrayIndex := 0
+		if index < len(indexes) {
+			arrayIndex = indexes[index]
+		}
+		jsonPathStr = strings.Replace(jsonPathStr, core.ArrayPathPlaceholder, fmt.Sprintf("%s%d%s", path.JsonpathLeftBracket, arrayIndex, path.JsonpathRightBracket), 1)
+	}
+	return path.JSONPath(jsonPathStr)
+}
+
+// isNil checks if value is invalid or a nil pointer, interface, map, or slice.
+func isNil(value reflect.Value) bool {
+	if !value.IsValid() {
+		return true
+	}
+	switch value.Kind() {
+	case reflect.Pointer, reflect.Interface, reflect.Map, reflect.Slice:
+		return value.IsNil()
+	default:
+		return false
+	}
+}
+
+// Changes returns the differences between the old and new metadata model. Refer to diff.Diff.
+func (n *Migration) Changes() diff.Changes {
+	return n.changes
+}
+
+// WithSchema sets the schema of a single migrated record.
+func (n *Migration) WithSchema(value schema.Schema) *Migration {
+	n.SetSchema(value)
+	return n
+}
+
+// SetSchema sets the schema of a single migrated record.
+func (n *Migration) SetSchema(value schema.Schema) {
+	n.schema = value
+}
+
+// WithDefaultConverter sets the converter used when core.FieldDataType changes.
+func (n *Migration) WithDefaultConverter(value schema.DefaultConverter) *Migration {
+	n.SetDefaultConverter(value)
+	return n
+}
+
+// SetDefaultConverter sets the converter used when core.FieldDataType changes.
+func (n *Migration) SetDefaultConverter(value schema.DefaultConverter) {
+	n.defaultConverter = value
+}
+
+/*
+NewMigration
+
+Parameters:
+  - oldMetadataModel - Metadata model the source data was stored with.
+  - newMetadataModel - Metadata model to migrate the source data to.
+
+Each field/group in both metadata models must have core.FieldGroupJsonPathKey.
+
+Sets Migration.defaultConverter to schema.NewConversion.
+*/
+func NewMigration(oldMetadataModel any, newMetadataModel any) (*Migration, error) {
+	const FunctionName = "NewMigration"
+
+	changes, err := diff.Diff(oldMetadataModel, newMetadataModel)
+	if err != nil {
+		return nil, NewError().WithFunctionName(FunctionName).WithMessage("diff metadata models failed").WithNestedError(err)
+	}
+
+	n := &Migration{
+		changes:          changes,
+		defaultConverter: schema.NewConversion(),
+	}
+	n.oldFieldGroups, _ = indexFieldGroups(oldMetadataModel)
+	n.newFieldGroups, n.newReadOrder = indexFieldGroups(newMetadataModel)
+
+	return n, nil
+}
+
+// indexFieldGroups returns every field/group in metadataModel by core.FieldGroupJsonPathKey and the keys in read order.
+func indexFieldGroups(metadataModel any) (map[path.JSONPath]gojsoncore.JsonObject, []path.JSONPath) {
+	fieldGroups := make(map[path.JSONPath]gojsoncore.JsonObject)
+	readOrder := make([]path.JSONPath, 0)
+	iter.ForEach(metadataModel, func(fieldGroup gojsoncore.JsonObject) (bool, bool) {
+		if fieldGroupJsonPathKey, err := core.AsJSONPath(fieldGroup[core.FieldGroupJsonPathKey]); err == nil {
+			fieldGroups[fieldGroupJsonPathKey] = fieldGroup
+			readOrder = append(readOrder, fieldGroupJsonPathKey)
+		}
+		return false, false
+	})
+	return fieldGroups, readOrder
+}
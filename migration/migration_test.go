@@ -0,0 +1,247 @@
+package migration
+
+import (
+	"errors"
+	"reflect"
+	"slices"
+	"testing"
+
+	gojsoncore "github.com/rogonion/go-json/core"
+	"github.com/rogonion/go-json/object"
+	"github.com/rogonion/go-metadatamodel/builder"
+	"github.com/rogonion/go-metadatamodel/core"
+	"github.com/rogonion/go-metadatamodel/internal"
+	"github.com/rogonion/go-metadatamodel/testdata"
+)
+
+func TestMigration_Migrate(t *testing.T) {
+	for testData := range migrateTestData {
+		migration, err := NewMigration(testData.OldMetadataModel, testData.NewMetadataModel)
+		if err != nil {
+			t.Error(testData.TestTitle, "\n", "NewMigration failed:", err)
+			continue
+		}
+
+		res, failures := migration.Migrate(object.NewObject().WithSourceInterface(testData.SourceData))
+
+		if expected, actual := internal.AsJsonDecoded(t, testData.Expected), internal.AsJsonDecoded(t, res.GetSourceInterface()); !reflect.DeepEqual(expected, actual) {
+			t.Error(
+				testData.TestTitle, "\n",
+				"expected res to be equal to testData.Expected\n",
+				"Expected=", gojsoncore.JsonStringifyMust(expected), "\n",
+				"res=", gojsoncore.JsonStringifyMust(actual),
+			)
+		}
+
+		if !slices.Equal(failures.RecordIndexes(), testData.ExpectedFailedRecordIndexes) {
+			t.Error(testData.TestTitle, "\n", "expected failed record indexes", testData.ExpectedFailedRecordIndexes, "got", failures.RecordIndexes(), "\n", "failures=", failures)
+		}
+		if len(testData.ExpectedFailedRecordIndexes) > 0 && !errors.Is(failures.Err(), ErrValueConversionFailed) {
+			t.Error(testData.TestTitle, "\n", "expected failures to wrap", ErrValueConversionFailed, "got", failures.Err())
+		}
+	}
+}
+
+type migrateData struct {
+	internal.TestData
+	OldMetadataModel            gojsoncore.JsonObject
+	NewMetadataModel            gojsoncore.JsonObject
+	SourceData                  any
+	Expected                    any
+	ExpectedFailedRecordIndexes []int
+}
+
+func migrateTestData(yield func(data *migrateData) bool) {
+	testCaseIndex := 1
+	if !yield(
+		&migrateData{
+			TestData: internal.TestData{
+				TestTitle: "Move, convert, fill defaults, and remove",
+			},
+			OldMetadataModel: builder.Must(
+				builder.NewModel("User").
+					Field("ID", core.FieldTypeNumber).MaxEntries(1).
+					Field("Age", core.FieldTypeText).MaxEntries(1).
+					Field("City", core.FieldTypeText).
+					Field("Nickname", core.FieldTypeText).
+					Group("Address").
+					Field("Street", core.FieldTypeText).
+					End().
+					Build(),
+			),
+			NewMetadataModel: builder.Must(
+				builder.NewModel("User").
+					Field("ID", core.FieldTypeNumber).MaxEntries(1).
+					Field("Age", core.FieldTypeNumber).MaxEntries(1).
+					Group("Address").
+					Field("Street", core.FieldTypeText).
+					Field("City", core.FieldTypeText).
+					Field("Verified", core.FieldTypeBoolean).DefaultValue(false).
+					End().
+					Field("Country", core.FieldTypeText).DefaultValue("KE").
+					Build(),
+			),
+			SourceData: gojsoncore.JsonArray{
+				gojsoncore.JsonObject{
+					"ID":       []any{1},
+					"Age":      []any{"42"},
+					"City":     []any{"Nairobi"},
+					"Nickname": []any{"Jo"},
+					"Address": []any{
+						gojsoncore.JsonObject{"Street": []any{"Moi Avenue"}},
+						gojsoncore.JsonObject{"Street": []any{"Kenyatta Avenue"}},
+					},
+				},
+				gojsoncore.JsonObject{
+					"ID":  []any{2},
+					"Age": []any{"unknown"},
+				},
+			},
+			Expected: gojsoncore.JsonArray{
+				gojsoncore.JsonObject{
+					"ID":  []any{1},
+					"Age": []any{42},
+					"Address": []any{
+						gojsoncore.JsonObject{"Street": []any{"Moi Avenue"}, "City": []any{"Nairobi"}, "Verified": []any{false}},
+						gojsoncore.JsonObject{"Street": []any{"Kenyatta Avenue"}, "Verified": []any{false}},
+					},
+					"Country": []any{"KE"},
+				},
+				gojsoncore.JsonObject{
+					"ID":      []any{2},
+					"Country": []any{"KE"},
+				},
+			},
+			ExpectedFailedRecordIndexes: []int{1},
+		},
+	) {
+		return
+	}
+
+	testCaseIndex++
+	if !yield(
+		&migrateData{
+			TestData: internal.TestData{
+				TestTitle: "Wrap field into group and unwrap group into field",
+			},
+			OldMetadataModel: builder.Must(
+				builder.NewModel("User").
+					Field("Phone", core.FieldTypeText).
+					Group("Email").
+					Field("Address", core.FieldTypeText).
+					Field("Primary", core.FieldTypeBoolean).
+					End().
+					Build(),
+			),
+			NewMetadataModel: builder.Must(
+				builder.NewModel("User").
+					Group("Phone").
+					Field("Label", core.FieldTypeText).
+					Field("Phone", core.FieldTypeText).
+					End().
+					Field("Email", core.FieldTypeText).
+					Build(),
+			),
+			SourceData: gojsoncore.JsonObject{
+				"Phone": []any{"0700", "0711"},
+				"Email": []any{
+					gojsoncore.JsonObject{"Address": []any{"a@example.com"}, "Primary": []any{true}},
+					gojsoncore.JsonObject{"Address": []any{"b@example.com"}},
+				},
+			},
+			Expected: gojsoncore.JsonObject{
+				"Phone": []any{gojsoncore.JsonObject{"Phone": []any{"0700", "0711"}}},
+				"Email": []any{"a@example.com", "b@example.com"},
+			},
+		},
+	) {
+		return
+	}
+
+	testCaseIndex++
+	if !yield(
+		&migrateData{
+			TestData: internal.TestData{
+				TestTitle: "Group moved into a new group",
+			},
+			OldMetadataModel: builder.Must(
+				builder.NewModel("User").
+					Group("Contact").
+					Field("Phone", core.FieldTypeText).
+					End().
+					Build(),
+			),
+			NewMetadataModel: builder.Must(
+				builder.NewModel("User").
+					Group("Profile").
+					Group("Contact").
+					Field("Phone", core.FieldTypeNumber).
+					End().
+					End().
+					Build(),
+			),
+			SourceData: gojsoncore.JsonObject{
+				"Contact": []any{
+					gojsoncore.JsonObject{"Phone": []any{"700"}},
+					gojsoncore.JsonObject{"Phone": []any{"711"}},
+				},
+			},
+			Expected: gojsoncore.JsonObject{
+				"Profile": []any{gojsoncore.JsonObject{
+					"Contact": []any{
+						gojsoncore.JsonObject{"Phone": []any{700}},
+						gojsoncore.JsonObject{"Phone": []any{711}},
+					},
+				}},
+			},
+		},
+	) {
+		return
+	}
+
+	testCaseIndex++
+	if !yield(
+		&migrateData{
+			TestData: internal.TestData{
+				TestTitle: "Typed source data",
+			},
+			OldMetadataModel: testdata.ProductMetadataModel(nil),
+			NewMetadataModel: func() gojsoncore.JsonObject {
+				metadataModel := testdata.ProductMetadataModel(nil)
+				groupFields, _ := core.GetGroupFields(metadataModel)
+				groupFields["Price"].(gojsoncore.JsonObject)[core.FieldDataType] = core.FieldTypeText
+				return metadataModel
+			}(),
+			SourceData: &testdata.Product{ID: []int{1}, Name: []string{"Twinkies"}, Price: []float64{2.5}},
+			Expected:   gojsoncore.JsonObject{"ID": []any{1}, "Name": []any{"Twinkies"}, "Price": []any{"2.5"}},
+		},
+	) {
+		return
+	}
+
+	testCaseIndex++
+	if !yield(
+		&migrateData{
+			TestData: internal.TestData{
+				TestTitle: "Pointer to slice source data",
+			},
+			OldMetadataModel: testdata.ProductMetadataModel(nil),
+			NewMetadataModel: func() gojsoncore.JsonObject {
+				metadataModel := testdata.ProductMetadataModel(nil)
+				groupFields, _ := core.GetGroupFields(metadataModel)
+				groupFields["Price"].(gojsoncore.JsonObject)[core.FieldDataType] = core.FieldTypeText
+				return metadataModel
+			}(),
+			SourceData: &[]*testdata.Product{
+				{ID: []int{1}, Name: []string{"Twinkies"}, Price: []float64{2.5}},
+				{ID: []int{2}, Name: []string{"Ho Hos"}},
+			},
+			Expected: gojsoncore.JsonArray{
+				gojsoncore.JsonObject{"ID": []any{1}, "Name": []any{"Twinkies"}, "Price": []any{"2.5"}},
+				gojsoncore.JsonObject{"ID": []any{2}, "Name": []any{"Ho Hos"}},
+			},
+		},
+	) {
+		return
+	}
+}
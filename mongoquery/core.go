@@ -0,0 +1,42 @@
+package mongoquery
+
+import (
+	"errors"
+
+	"github.com/rogonion/go-metadatamodel/core"
+	"github.com/rogonion/go-metadatamodel/filter"
+)
+
+// Query operators used in generated filter documents.
+const (
+	OperatorAnd       string = "$and"
+	OperatorOr        string = "$or"
+	OperatorNor       string = "$nor"
+	OperatorEq        string = "$eq"
+	OperatorIn        string = "$in"
+	OperatorGt        string = "$gt"
+	OperatorGte       string = "$gte"
+	OperatorLt        string = "$lt"
+	OperatorRegex     string = "$regex"
+	OperatorOptions   string = "$options"
+	OperatorSize      string = "$size"
+	OperatorExists    string = "$exists"
+	OperatorElemMatch string = "$elemMatch"
+)
+
+var (
+	// ErrMongoQueryError default error for mongoquery module.
+	ErrMongoQueryError = errors.New("mongo query generation encountered an error")
+
+	// ErrQueryConditionInvalid for when a query condition consumed by filter.DataFilter is not valid structure-wise. Same as filter.ErrQueryConditionInvalid.
+	ErrQueryConditionInvalid = filter.ErrQueryConditionInvalid
+
+	// ErrFilterConditionUnsupported for when a filter condition cannot be translated into a filter document.
+	ErrFilterConditionUnsupported = errors.New("filter condition not supported")
+)
+
+// NewError creates a new core.Error with the default mongoquery error base.
+func NewError() *core.Error {
+	n := core.NewError().WithDefaultBaseError(ErrMongoQueryError)
+	return n
+}
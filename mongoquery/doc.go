@@ -0,0 +1,16 @@
+/*
+Package mongoquery translates query conditions consumed by filter.DataFilter into MongoDB filter documents.
+
+Documents are expected to have the structure of source data for the metadata model where every field/group is an array. Field paths are derived using core.JsonPathToValue with core.GroupFields removed and fields in nested groups are matched using `$elemMatch`.
+
+Generated documents are plain gojsoncore.JsonObject values that can be converted into the filter type of a MongoDB driver e.g. `bson.M`.
+
+# Usage
+
+	import (
+		"github.com/rogonion/go-metadatamodel/mongoquery"
+	)
+
+	document, err := mongoquery.Filter(queryCondition)
+*/
+package mongoquery
@@ -0,0 +1,344 @@
+package mongoquery
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	gojsoncore "github.com/rogonion/go-json/core"
+	"github.com/rogonion/go-json/path"
+	"github.com/rogonion/go-json/schema"
+	"github.com/rogonion/go-metadatamodel/core"
+	"github.com/rogonion/go-metadatamodel/filter"
+)
+
+/*
+Filter translates a query condition consumed by filter.DataFilter into a MongoDB filter document.
+
+Documents are expected to have the structure of source data for the metadata model i.e. every field/group is an array:
+  - Field paths are derived from core.FieldGroupJsonPathKey using core.JsonPathToValue with core.GroupFields removed.
+  - Fields in nested groups are matched using OperatorElemMatch so that, like filter.DataFilter, all filter conditions of a field must be true for the same group entry.
+  - filter.FilterConditionNoOfEntriesEqualTo uses OperatorSize. filter.FilterConditionNoOfEntriesGreaterThan and filter.FilterConditionNoOfEntriesLessThan check if an array index exists. Like filter.IsNumberOfEntriesConditionTrue, filter.FilterConditionNoOfEntriesGreaterThan is true if filter.FilterConditionValue is greater than the number of entries.
+  - Text conditions other than a case-sensitive filter.FilterConditionEqualTo use OperatorRegex.
+  - Timestamp conditions are compared to the period covered by filter.FilterConditionDateTimeFormat. Refer to filter.TimestampPeriod.
+  - Negated query conditions use OperatorNor.
+
+filter.FilterConditionFullTextSearchQuery is not supported.
+
+Returns an empty document, which matches all documents, if queryCondition is empty.
+*/
+func Filter(queryCondition gojsoncore.JsonObject) (gojsoncore.JsonObject, error) {
+	const FunctionName = "Filter"
+
+	if len(queryCondition) == 0 {
+		return gojsoncore.JsonObject{}, nil
+	}
+
+	document, err := queryConditionDocument(queryCondition)
+	if err != nil {
+		return nil, NewError().WithFunctionName(FunctionName).WithMessage("translate query condition failed").WithNestedError(err)
+	}
+	return document, nil
+}
+
+func queryConditionDocument(queryCondition gojsoncore.JsonObject) (gojsoncore.JsonObject, error) {
+	negate, _ := queryCondition[filter.QueryConditionNegate].(bool)
+
+	logicalOperator, err := filter.GetQuerySectionTypeLogicalOperator(queryCondition)
+	if err != nil {
+		return nil, fmt.Errorf("%v: %w", err, ErrQueryConditionInvalid)
+	}
+
+	documents := make(gojsoncore.JsonArray, 0)
+	switch queryCondition[filter.QueryConditionType] {
+	case filter.QuerySectionTypeLogicalOperator:
+		value, err := core.AsJsonArray(queryCondition[filter.QueryConditionValue])
+		if err != nil {
+			return nil, fmt.Errorf("key '%s' of '%s' query condition is not a JsonArray: %w", filter.QueryConditionValue, filter.QuerySectionTypeLogicalOperator, ErrQueryConditionInvalid)
+		}
+		for _, v := range value {
+			childQueryCondition, err := core.AsJsonObject(v)
+			if err != nil {
+				return nil, fmt.Errorf("query condition is not a JsonObject: %w", ErrQueryConditionInvalid)
+			}
+			document, err := queryConditionDocument(childQueryCondition)
+			if err != nil {
+				return nil, err
+			}
+			documents = append(documents, document)
+		}
+	case filter.QuerySectionTypeFieldGroup:
+		value, err := core.AsJsonObject(queryCondition[filter.QueryConditionValue])
+		if err != nil {
+			return nil, fmt.Errorf("key '%s' of '%s' query condition is not a JsonObject: %w", filter.QueryConditionValue, filter.QuerySectionTypeFieldGroup, ErrQueryConditionInvalid)
+		}
+		for _, jsonPathKey := range sortedKeys(value) {
+			filterConditions, err := core.AsJsonObject(value[jsonPathKey])
+			if err != nil {
+				return nil, fmt.Errorf("filter conditions of '%s' are not a JsonObject: %w", jsonPathKey, ErrQueryConditionInvalid)
+			}
+			document, err := fieldGroupDocument(path.JSONPath(jsonPathKey), filterConditions)
+			if err != nil {
+				return nil, err
+			}
+			documents = append(documents, document)
+		}
+	default:
+		return nil, fmt.Errorf("unknown query condition type '%v': %w", queryCondition[filter.QueryConditionType], ErrQueryConditionInvalid)
+	}
+
+	var document gojsoncore.JsonObject
+	switch {
+	case len(documents) == 0 && logicalOperator == filter.QuerySectionTypeLogicalOperatorOr:
+		document = matchNothing()
+	case len(documents) == 0:
+		document = gojsoncore.JsonObject{}
+	case len(documents) == 1:
+		document = documents[0].(gojsoncore.JsonObject)
+	case logicalOperator == filter.QuerySectionTypeLogicalOperatorOr:
+		document = gojsoncore.JsonObject{OperatorOr: documents}
+	default:
+		document = gojsoncore.JsonObject{OperatorAnd: documents}
+	}
+
+	if negate {
+		return gojsoncore.JsonObject{OperatorNor: gojsoncore.JsonArray{document}}, nil
+	}
+	return document, nil
+}
+
+// fieldGroupDocument returns the document for the filter conditions of a field/group nested in OperatorElemMatch for each parent group.
+func fieldGroupDocument(fieldGroupJsonPathKey path.JSONPath, filterConditions gojsoncore.JsonObject) (gojsoncore.JsonObject, error) {
+	if len(filterConditions) == 0 {
+		return nil, fmt.Errorf("filter conditions of '%s' are empty: %w", fieldGroupJsonPathKey, ErrQueryConditionInvalid)
+	}
+
+	jsonPathToValue, err := core.NewJsonPathToValue().WithRemoveGroupFields(true).WithReplaceArrayPathPlaceholderWithActualIndexes(false).Get(fieldGroupJsonPathKey, nil)
+	if err != nil {
+		return nil, fmt.Errorf("get json path to value of '%s' failed: %v: %w", fieldGroupJsonPathKey, err, ErrQueryConditionInvalid)
+	}
+	fieldPath, ok := strings.CutPrefix(string(jsonPathToValue), path.JsonpathKeyRoot+path.JsonpathDotNotation)
+	if !ok || len(fieldPath) == 0 {
+		return nil, fmt.Errorf("'%s' is not a field/group: %w", fieldGroupJsonPathKey, ErrQueryConditionInvalid)
+	}
+	segments := strings.Split(fieldPath, core.ArrayPathPlaceholder+path.JsonpathDotNotation)
+	field := segments[len(segments)-1]
+
+	documents := make(gojsoncore.JsonArray, 0)
+	for _, filterConditionKey := range sortedKeys(filterConditions) {
+		filterValue, err := core.AsJsonObject(filterConditions[filterConditionKey])
+		if err != nil {
+			return nil, fmt.Errorf("filter condition '%s' of '%s' is not a JsonObject: %w", filterConditionKey, fieldGroupJsonPathKey, ErrQueryConditionInvalid)
+		}
+
+		var document gojsoncore.JsonObject
+		switch filterConditionKey {
+		case filter.FilterConditionNoOfEntriesGreaterThan, filter.FilterConditionNoOfEntriesLessThan, filter.FilterConditionNoOfEntriesEqualTo:
+			document, err = noOfEntriesDocument(field, filterConditionKey, filterValue)
+		case filter.FilterConditionFullTextSearchQuery:
+			err = fmt.Errorf("filter condition '%s': %w", filterConditionKey, ErrFilterConditionUnsupported)
+		default:
+			document, err = valueDocument(field, filterConditionKey, filterValue)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("'%s': %w", fieldGroupJsonPathKey, err)
+		}
+		documents = append(documents, document)
+	}
+
+	var document gojsoncore.JsonObject
+	if len(documents) == 1 {
+		document = documents[0].(gojsoncore.JsonObject)
+	} else {
+		document = gojsoncore.JsonObject{OperatorAnd: documents}
+	}
+	for i := len(segments) - 2; i >= 0; i-- {
+		document = gojsoncore.JsonObject{segments[i]: gojsoncore.JsonObject{OperatorElemMatch: document}}
+	}
+	return document, nil
+}
+
+func noOfEntriesDocument(field string, filterConditionKey string, filterValue gojsoncore.JsonObject) (gojsoncore.JsonObject, error) {
+	values, err := filter.ConditionValues(filterValue)
+	if err != nil {
+		return nil, err
+	}
+
+	documents := make(gojsoncore.JsonArray, 0, len(values))
+	for _, value := range values {
+		var valueInt int
+		if err := schema.NewConversion().Convert(value, &schema.DynamicSchemaNode{Type: reflect.TypeOf(0), Kind: reflect.Int}, &valueInt); err != nil {
+			return nil, fmt.Errorf("convert '%v' to int failed: %w", value, ErrQueryConditionInvalid)
+		}
+
+		switch filterConditionKey {
+		case filter.FilterConditionNoOfEntriesEqualTo:
+			documents = append(documents, gojsoncore.JsonObject{field: gojsoncore.JsonObject{OperatorSize: valueInt}})
+		case filter.FilterConditionNoOfEntriesGreaterThan:
+			if valueInt <= 0 {
+				documents = append(documents, matchNothing())
+			} else {
+				documents = append(documents, gojsoncore.JsonObject{
+					field: gojsoncore.JsonObject{OperatorExists: true},
+					field + path.JsonpathDotNotation + strconv.Itoa(valueInt-1): gojsoncore.JsonObject{OperatorExists: false},
+				})
+			}
+		case filter.FilterConditionNoOfEntriesLessThan:
+			if valueInt < 0 {
+				documents = append(documents, gojsoncore.JsonObject{field: gojsoncore.JsonObject{OperatorExists: true}})
+			} else {
+				documents = append(documents, gojsoncore.JsonObject{field + path.JsonpathDotNotation + strconv.Itoa(valueInt): gojsoncore.JsonObject{OperatorExists: true}})
+			}
+		}
+	}
+	return orDocuments(documents), nil
+}
+
+// valueDocument returns the document for a field that matches if any value of the field matches any of the filter condition values.
+func valueDocument(field string, filterConditionKey string, filterValue gojsoncore.JsonObject) (gojsoncore.JsonObject, error) {
+	assumedFieldType, ok := filterValue[filter.FilterConditionAssumedFieldType].(string)
+	if !ok {
+		return nil, fmt.Errorf("filter condition property '%s' not found or not a string: %w", filter.FilterConditionAssumedFieldType, ErrQueryConditionInvalid)
+	}
+
+	values, err := filter.ConditionValues(filterValue)
+	if err != nil {
+		return nil, err
+	}
+
+	operators := make([]gojsoncore.JsonObject, 0, len(values))
+	switch assumedFieldType {
+	case core.FieldTypeText:
+		caseInsensitive, _ := filterValue[filter.FilterConditionCaseInsensitive].(bool)
+		valueStrings := make(gojsoncore.JsonArray, 0, len(values))
+		for _, value := range values {
+			valueString, ok := value.(string)
+			if !ok {
+				return nil, fmt.Errorf("filter condition value '%v' is not a string: %w", value, ErrQueryConditionInvalid)
+			}
+			valueStrings = append(valueStrings, valueString)
+		}
+
+		if filterConditionKey == filter.FilterConditionEqualTo && !caseInsensitive {
+			operators = append(operators, equalOperator(valueStrings))
+			break
+		}
+
+		for _, value := range valueStrings {
+			var pattern string
+			switch filterConditionKey {
+			case filter.FilterConditionEqualTo:
+				pattern = "^" + regexp.QuoteMeta(value.(string)) + "$"
+			case filter.FilterConditionBeginsWith:
+				pattern = "^" + regexp.QuoteMeta(value.(string))
+			case filter.FilterConditionEndsWith:
+				pattern = regexp.QuoteMeta(value.(string)) + "$"
+			case filter.FilterConditionContains:
+				pattern = regexp.QuoteMeta(value.(string))
+			default:
+				return nil, fmt.Errorf("filter condition '%s' for '%s': %w", filterConditionKey, assumedFieldType, ErrFilterConditionUnsupported)
+			}
+
+			operator := gojsoncore.JsonObject{OperatorRegex: pattern}
+			if caseInsensitive {
+				operator[OperatorOptions] = "i"
+			}
+			operators = append(operators, operator)
+		}
+	case core.FieldTypeNumber:
+		valueFloats := make(gojsoncore.JsonArray, 0, len(values))
+		for _, value := range values {
+			var valueFloat float64
+			if err := schema.NewConversion().Convert(value, &schema.DynamicSchemaNode{Type: reflect.TypeOf(0.0), Kind: reflect.Float64}, &valueFloat); err != nil {
+				return nil, fmt.Errorf("convert '%v' to float64 failed: %w", value, ErrQueryConditionInvalid)
+			}
+			valueFloats = append(valueFloats, valueFloat)
+		}
+
+		switch filterConditionKey {
+		case filter.FilterConditionEqualTo:
+			operators = append(operators, equalOperator(valueFloats))
+		case filter.FilterConditionGreaterThan:
+			for _, value := range valueFloats {
+				operators = append(operators, gojsoncore.JsonObject{OperatorGt: value})
+			}
+		case filter.FilterConditionLessThan:
+			for _, value := range valueFloats {
+				operators = append(operators, gojsoncore.JsonObject{OperatorLt: value})
+			}
+		default:
+			return nil, fmt.Errorf("filter condition '%s' for '%s': %w", filterConditionKey, assumedFieldType, ErrFilterConditionUnsupported)
+		}
+	case core.FieldTypeTimestamp:
+		dateTimeFormat, ok := filterValue[filter.FilterConditionDateTimeFormat].(string)
+		if !ok {
+			return nil, fmt.Errorf("filter condition property '%s' not found or not a string: %w", filter.FilterConditionDateTimeFormat, ErrQueryConditionInvalid)
+		}
+		for _, value := range values {
+			start, end, err := filter.TimestampPeriod(value, dateTimeFormat)
+			if err != nil {
+				if errors.Is(err, filter.ErrUnsupportedFilterConditionType) {
+					return nil, fmt.Errorf("%v: %w", err, ErrFilterConditionUnsupported)
+				}
+				return nil, fmt.Errorf("%v: %w", err, ErrQueryConditionInvalid)
+			}
+
+			switch filterConditionKey {
+			case filter.FilterConditionEqualTo:
+				operators = append(operators, gojsoncore.JsonObject{OperatorGte: start, OperatorLt: end})
+			case filter.FilterConditionGreaterThan:
+				operators = append(operators, gojsoncore.JsonObject{OperatorGte: end})
+			case filter.FilterConditionLessThan:
+				operators = append(operators, gojsoncore.JsonObject{OperatorLt: start})
+			default:
+				return nil, fmt.Errorf("filter condition '%s' for '%s': %w", filterConditionKey, assumedFieldType, ErrFilterConditionUnsupported)
+			}
+		}
+	default:
+		if filterConditionKey != filter.FilterConditionEqualTo {
+			return nil, fmt.Errorf("filter condition '%s' for '%s': %w", filterConditionKey, assumedFieldType, ErrFilterConditionUnsupported)
+		}
+		operators = append(operators, equalOperator(values))
+	}
+
+	documents := make(gojsoncore.JsonArray, 0, len(operators))
+	for _, operator := range operators {
+		documents = append(documents, gojsoncore.JsonObject{field: operator})
+	}
+	return orDocuments(documents), nil
+}
+
+// equalOperator returns OperatorEq for a single value or OperatorIn for multiple values.
+func equalOperator(values gojsoncore.JsonArray) gojsoncore.JsonObject {
+	if len(values) == 1 {
+		return gojsoncore.JsonObject{OperatorEq: values[0]}
+	}
+	return gojsoncore.JsonObject{OperatorIn: values}
+}
+
+// orDocuments joins documents using OperatorOr.
+func orDocuments(documents gojsoncore.JsonArray) gojsoncore.JsonObject {
+	if len(documents) == 1 {
+		return documents[0].(gojsoncore.JsonObject)
+	}
+	return gojsoncore.JsonObject{OperatorOr: documents}
+}
+
+// matchNothing returns a document that matches no documents.
+func matchNothing() gojsoncore.JsonObject {
+	return gojsoncore.JsonObject{OperatorNor: gojsoncore.JsonArray{gojsoncore.JsonObject{}}}
+}
+
+// sortedKeys returns the keys of value in ascending order so that generated documents are deterministic.
+func sortedKeys(value gojsoncore.JsonObject) []string {
+	keys := make([]string, 0, len(value))
+	for key := range value {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	return keys
+}
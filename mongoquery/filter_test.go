@@ -0,0 +1,340 @@
+package mongoquery
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	gojsoncore "github.com/rogonion/go-json/core"
+	"github.com/rogonion/go-metadatamodel/core"
+	"github.com/rogonion/go-metadatamodel/filter"
+	"github.com/rogonion/go-metadatamodel/internal"
+)
+
+func TestMongoQuery_Filter(t *testing.T) {
+	for testData := range filterTestData {
+		document, err := Filter(testData.QueryCondition)
+		if testData.ExpectedErr != nil {
+			if !errors.Is(err, testData.ExpectedErr) {
+				t.Error(testData.TestTitle, "\n", "expected error", testData.ExpectedErr, "got", err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Error(testData.TestTitle, "\n", "Filter failed:", err)
+			continue
+		}
+
+		if !reflect.DeepEqual(document, testData.Expected) {
+			t.Error(
+				testData.TestTitle, "\n",
+				"expected document to be equal to testData.Expected\n",
+				"Expected=", gojsoncore.JsonStringifyMust(testData.Expected), "\n",
+				"document=", gojsoncore.JsonStringifyMust(document),
+			)
+		}
+	}
+}
+
+type filterData struct {
+	internal.TestData
+	QueryCondition gojsoncore.JsonObject
+	Expected       gojsoncore.JsonObject
+	ExpectedErr    error
+}
+
+func filterTestData(yield func(data *filterData) bool) {
+	testCaseIndex := 1
+	if !yield(
+		&filterData{
+			TestData: internal.TestData{
+				TestTitle: "Nested groups with negated field group",
+			},
+			QueryCondition: gojsoncore.JsonObject{
+				filter.QueryConditionType:              filter.QuerySectionTypeLogicalOperator,
+				filter.QuerySectionTypeLogicalOperator: filter.QuerySectionTypeLogicalOperatorOr,
+				filter.QueryConditionValue: gojsoncore.JsonArray{
+					gojsoncore.JsonObject{
+						filter.QueryConditionType: filter.QuerySectionTypeFieldGroup,
+						filter.QueryConditionValue: gojsoncore.JsonObject{
+							"$.GroupFields[*].ID": gojsoncore.JsonObject{
+								filter.FilterConditionGreaterThan: gojsoncore.JsonObject{
+									filter.FilterConditionAssumedFieldType: core.FieldTypeNumber,
+									filter.FilterConditionValue:            3,
+								},
+							},
+						},
+					},
+					gojsoncore.JsonObject{
+						filter.QueryConditionType:   filter.QuerySectionTypeFieldGroup,
+						filter.QueryConditionNegate: true,
+						filter.QueryConditionValue: gojsoncore.JsonObject{
+							"$.GroupFields[*].Profile": gojsoncore.JsonObject{
+								filter.FilterConditionNoOfEntriesEqualTo: gojsoncore.JsonObject{
+									filter.FilterConditionValue: 2,
+								},
+							},
+							"$.GroupFields[*].Profile.GroupFields[*].Address.GroupFields[*].City": gojsoncore.JsonObject{
+								filter.FilterConditionBeginsWith: gojsoncore.JsonObject{
+									filter.FilterConditionAssumedFieldType: core.FieldTypeText,
+									filter.FilterConditionValue:            "Nai.",
+								},
+							},
+						},
+					},
+				},
+			},
+			Expected: gojsoncore.JsonObject{
+				OperatorOr: gojsoncore.JsonArray{
+					gojsoncore.JsonObject{"ID": gojsoncore.JsonObject{OperatorGt: 3.0}},
+					gojsoncore.JsonObject{
+						OperatorNor: gojsoncore.JsonArray{
+							gojsoncore.JsonObject{
+								OperatorAnd: gojsoncore.JsonArray{
+									gojsoncore.JsonObject{"Profile": gojsoncore.JsonObject{OperatorSize: 2}},
+									gojsoncore.JsonObject{
+										"Profile": gojsoncore.JsonObject{
+											OperatorElemMatch: gojsoncore.JsonObject{
+												"Address": gojsoncore.JsonObject{
+													OperatorElemMatch: gojsoncore.JsonObject{
+														"City": gojsoncore.JsonObject{OperatorRegex: `^Nai\.`},
+													},
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	) {
+		return
+	}
+
+	testCaseIndex++
+	if !yield(
+		&filterData{
+			TestData: internal.TestData{
+				TestTitle: "Multiple conditions on the same nested field",
+			},
+			QueryCondition: gojsoncore.JsonObject{
+				filter.QueryConditionType: filter.QuerySectionTypeFieldGroup,
+				filter.QueryConditionValue: gojsoncore.JsonObject{
+					"$.GroupFields[*].Profile.GroupFields[*].Age": gojsoncore.JsonObject{
+						filter.FilterConditionGreaterThan: gojsoncore.JsonObject{
+							filter.FilterConditionAssumedFieldType: core.FieldTypeNumber,
+							filter.FilterConditionValue:            18,
+						},
+						filter.FilterConditionLessThan: gojsoncore.JsonObject{
+							filter.FilterConditionAssumedFieldType: core.FieldTypeNumber,
+							filter.FilterConditionValue:            "30",
+						},
+					},
+				},
+			},
+			Expected: gojsoncore.JsonObject{
+				"Profile": gojsoncore.JsonObject{
+					OperatorElemMatch: gojsoncore.JsonObject{
+						OperatorAnd: gojsoncore.JsonArray{
+							gojsoncore.JsonObject{"Age": gojsoncore.JsonObject{OperatorGt: 18.0}},
+							gojsoncore.JsonObject{"Age": gojsoncore.JsonObject{OperatorLt: 30.0}},
+						},
+					},
+				},
+			},
+		},
+	) {
+		return
+	}
+
+	testCaseIndex++
+	if !yield(
+		&filterData{
+			TestData: internal.TestData{
+				TestTitle: "Text and any conditions with multiple values",
+			},
+			QueryCondition: gojsoncore.JsonObject{
+				filter.QueryConditionType:              filter.QuerySectionTypeFieldGroup,
+				filter.QuerySectionTypeLogicalOperator: filter.QuerySectionTypeLogicalOperatorOr,
+				filter.QueryConditionValue: gojsoncore.JsonObject{
+					"$.GroupFields[*].Name": gojsoncore.JsonObject{
+						filter.FilterConditionContains: gojsoncore.JsonObject{
+							filter.FilterConditionAssumedFieldType: core.FieldTypeText,
+							filter.FilterConditionCaseInsensitive:  true,
+							filter.FilterConditionValues:           gojsoncore.JsonArray{"cake", "(new)"},
+						},
+					},
+					"$.GroupFields[*].Skills": gojsoncore.JsonObject{
+						filter.FilterConditionEqualTo: gojsoncore.JsonObject{
+							filter.FilterConditionAssumedFieldType: core.FieldTypeText,
+							filter.FilterConditionValues:           gojsoncore.JsonArray{"Go", "SQL"},
+						},
+					},
+					"$.GroupFields[*].Public": gojsoncore.JsonObject{
+						filter.FilterConditionEqualTo: gojsoncore.JsonObject{
+							filter.FilterConditionAssumedFieldType: core.FieldTypeAny,
+							filter.FilterConditionValue:            true,
+						},
+					},
+				},
+			},
+			Expected: gojsoncore.JsonObject{
+				OperatorOr: gojsoncore.JsonArray{
+					gojsoncore.JsonObject{
+						OperatorOr: gojsoncore.JsonArray{
+							gojsoncore.JsonObject{"Name": gojsoncore.JsonObject{OperatorRegex: "cake", OperatorOptions: "i"}},
+							gojsoncore.JsonObject{"Name": gojsoncore.JsonObject{OperatorRegex: `\(new\)`, OperatorOptions: "i"}},
+						},
+					},
+					gojsoncore.JsonObject{"Public": gojsoncore.JsonObject{OperatorEq: true}},
+					gojsoncore.JsonObject{"Skills": gojsoncore.JsonObject{OperatorIn: gojsoncore.JsonArray{"Go", "SQL"}}},
+				},
+			},
+		},
+	) {
+		return
+	}
+
+	testCaseIndex++
+	if !yield(
+		&filterData{
+			TestData: internal.TestData{
+				TestTitle: "Timestamp and number of entries conditions",
+			},
+			QueryCondition: gojsoncore.JsonObject{
+				filter.QueryConditionType: filter.QuerySectionTypeFieldGroup,
+				filter.QueryConditionValue: gojsoncore.JsonObject{
+					"$.GroupFields[*].Starts": gojsoncore.JsonObject{
+						filter.FilterConditionEqualTo: gojsoncore.JsonObject{
+							filter.FilterConditionAssumedFieldType: core.FieldTypeTimestamp,
+							filter.FilterConditionDateTimeFormat:   core.FieldDatetimeFormatYYYY,
+							filter.FilterConditionValue:            "2024-02-14T10:30:00Z",
+						},
+					},
+					"$.GroupFields[*].Tickets": gojsoncore.JsonObject{
+						filter.FilterConditionNoOfEntriesGreaterThan: gojsoncore.JsonObject{
+							filter.FilterConditionValue: 3,
+						},
+						filter.FilterConditionNoOfEntriesLessThan: gojsoncore.JsonObject{
+							filter.FilterConditionValue: 1,
+						},
+					},
+				},
+			},
+			Expected: gojsoncore.JsonObject{
+				OperatorAnd: gojsoncore.JsonArray{
+					gojsoncore.JsonObject{
+						"Starts": gojsoncore.JsonObject{
+							OperatorGte: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+							OperatorLt:  time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+						},
+					},
+					gojsoncore.JsonObject{
+						OperatorAnd: gojsoncore.JsonArray{
+							gojsoncore.JsonObject{
+								"Tickets":   gojsoncore.JsonObject{OperatorExists: true},
+								"Tickets.2": gojsoncore.JsonObject{OperatorExists: false},
+							},
+							gojsoncore.JsonObject{"Tickets.1": gojsoncore.JsonObject{OperatorExists: true}},
+						},
+					},
+				},
+			},
+		},
+	) {
+		return
+	}
+
+	testCaseIndex++
+	if !yield(
+		&filterData{
+			TestData: internal.TestData{
+				TestTitle: "Empty logical operators",
+			},
+			QueryCondition: gojsoncore.JsonObject{
+				filter.QueryConditionType: filter.QuerySectionTypeLogicalOperator,
+				filter.QueryConditionValue: gojsoncore.JsonArray{
+					gojsoncore.JsonObject{
+						filter.QueryConditionType:              filter.QuerySectionTypeLogicalOperator,
+						filter.QuerySectionTypeLogicalOperator: filter.QuerySectionTypeLogicalOperatorOr,
+						filter.QueryConditionValue:             gojsoncore.JsonArray{},
+					},
+					gojsoncore.JsonObject{
+						filter.QueryConditionType:  filter.QuerySectionTypeLogicalOperator,
+						filter.QueryConditionValue: gojsoncore.JsonArray{},
+					},
+				},
+			},
+			Expected: gojsoncore.JsonObject{
+				OperatorAnd: gojsoncore.JsonArray{
+					gojsoncore.JsonObject{OperatorNor: gojsoncore.JsonArray{gojsoncore.JsonObject{}}},
+					gojsoncore.JsonObject{},
+				},
+			},
+		},
+	) {
+		return
+	}
+
+	testCaseIndex++
+	if !yield(
+		&filterData{
+			TestData: internal.TestData{
+				TestTitle: "Empty query condition",
+			},
+			QueryCondition: gojsoncore.JsonObject{},
+			Expected:       gojsoncore.JsonObject{},
+		},
+	) {
+		return
+	}
+
+	testCaseIndex++
+	if !yield(
+		&filterData{
+			TestData: internal.TestData{
+				TestTitle: "Full text search not supported",
+			},
+			QueryCondition: gojsoncore.JsonObject{
+				filter.QueryConditionType: filter.QuerySectionTypeFieldGroup,
+				filter.QueryConditionValue: gojsoncore.JsonObject{
+					"$.GroupFields[*].Name": gojsoncore.JsonObject{
+						filter.FilterConditionFullTextSearchQuery: gojsoncore.JsonObject{
+							filter.FilterConditionAssumedFieldType: core.FieldTypeText,
+							filter.FilterConditionValue:            "cake",
+						},
+					},
+				},
+			},
+			ExpectedErr: ErrFilterConditionUnsupported,
+		},
+	) {
+		return
+	}
+
+	testCaseIndex++
+	if !yield(
+		&filterData{
+			TestData: internal.TestData{
+				TestTitle: "Filter condition without assumed field type",
+			},
+			QueryCondition: gojsoncore.JsonObject{
+				filter.QueryConditionType: filter.QuerySectionTypeFieldGroup,
+				filter.QueryConditionValue: gojsoncore.JsonObject{
+					"$.GroupFields[*].Name": gojsoncore.JsonObject{
+						filter.FilterConditionEqualTo: gojsoncore.JsonObject{
+							filter.FilterConditionValue: "cake",
+						},
+					},
+				},
+			},
+			ExpectedErr: ErrQueryConditionInvalid,
+		},
+	) {
+		return
+	}
+}
@@ -0,0 +1,133 @@
+package sqlgen
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	gojsoncore "github.com/rogonion/go-json/core"
+	"github.com/rogonion/go-metadatamodel/core"
+	"github.com/rogonion/go-metadatamodel/filter"
+)
+
+// Dialect is the SQL dialect statements are generated for.
+type Dialect string
+
+const (
+	DialectPostgreSQL Dialect = "postgresql"
+	DialectSQLite     Dialect = "sqlite"
+)
+
+// Dialects returns a list of supported Dialect values.
+func Dialects() []Dialect {
+	return []Dialect{DialectPostgreSQL, DialectSQLite}
+}
+
+// QuoteIdentifier quotes a table or column name e.g. `"User"`.
+func (n Dialect) QuoteIdentifier(identifier string) string {
+	return `"` + strings.ReplaceAll(identifier, `"`, `""`) + `"`
+}
+
+// Placeholder returns the placeholder for the argument at position (1-based) e.g. `$1` for DialectPostgreSQL and `?` for DialectSQLite.
+func (n Dialect) Placeholder(position int) string {
+	if n == DialectPostgreSQL {
+		return fmt.Sprintf("$%d", position)
+	}
+	return "?"
+}
+
+/*
+ColumnType returns the column type for a field based on its core.FieldDataType.
+
+For DialectPostgreSQL, core.FieldTypeTimestamp is `DATE` or `TIME` if core.FieldDatetimeFormat only has a date or time part respectively.
+*/
+func (n Dialect) ColumnType(field gojsoncore.JsonObject) (string, error) {
+	fieldDataType, _ := field[core.FieldDataType].(string)
+
+	if n == DialectSQLite {
+		switch fieldDataType {
+		case core.FieldTypeText, core.FieldTypeTimestamp, core.FieldTypeAny:
+			return "TEXT", nil
+		case core.FieldTypeNumber:
+			return "NUMERIC", nil
+		case core.FieldTypeBoolean:
+			return "INTEGER", nil
+		}
+	} else {
+		switch fieldDataType {
+		case core.FieldTypeText:
+			return "TEXT", nil
+		case core.FieldTypeNumber:
+			return "NUMERIC", nil
+		case core.FieldTypeBoolean:
+			return "BOOLEAN", nil
+		case core.FieldTypeAny:
+			return "JSONB", nil
+		case core.FieldTypeTimestamp:
+			switch field[core.FieldDatetimeFormat] {
+			case core.FieldDatetimeFormatYYYYMMDD, core.FieldDatetimeFormatYYYYMM, core.FieldDatetimeFormatYYYY:
+				return "DATE", nil
+			case core.FieldDatetimeFormatHHMM:
+				return "TIME", nil
+			default:
+				return "TIMESTAMP", nil
+			}
+		}
+	}
+
+	return "", fmt.Errorf("'%s' '%v': %w", core.FieldDataType, field[core.FieldDataType], ErrFieldDataTypeUnsupported)
+}
+
+// isValid checks if n is one of Dialects.
+func (n Dialect) isValid() bool {
+	for _, dialect := range Dialects() {
+		if n == dialect {
+			return true
+		}
+	}
+	return false
+}
+
+var (
+	// ErrSqlGenError default error for sqlgen module.
+	ErrSqlGenError = errors.New("sql generation encountered an error")
+
+	// ErrDialectUnsupported for when a Dialect is not one of Dialects.
+	ErrDialectUnsupported = errors.New("sql dialect not supported")
+
+	// ErrFieldDataTypeUnsupported for when core.FieldDataType has no column type.
+	ErrFieldDataTypeUnsupported = errors.New("field data type has no column type")
+
+	// ErrTableCollectionInvalid for when the table collections of a metadata model are not valid e.g. the root group has no core.DatabaseTableCollectionUid or core.DatabaseTableCollectionName. Wraps errors from database.GetTableCollections.
+	ErrTableCollectionInvalid = errors.New("table collection not valid")
+
+	// ErrTableCollectionNotFound for when a table collection to generate statements for is not in the metadata model.
+	ErrTableCollectionNotFound = errors.New("table collection not found")
+
+	// ErrPrimaryKeyMissing for when a table collection with child table collections has no field with core.FieldGroupIsPrimaryKey.
+	ErrPrimaryKeyMissing = errors.New("primary key missing")
+
+	// ErrQueryConditionInvalid for when a query condition consumed by filter.DataFilter is not valid structure-wise. Same as filter.ErrQueryConditionInvalid.
+	ErrQueryConditionInvalid = filter.ErrQueryConditionInvalid
+
+	// ErrFilterConditionUnsupported for when a filter condition cannot be translated into SQL.
+	ErrFilterConditionUnsupported = errors.New("filter condition not supported")
+
+	// ErrFieldGroupNotFound for when a core.FieldGroupJsonPathKey in a query condition is neither a column nor a table/collection.
+	ErrFieldGroupNotFound = errors.New("field/group not found")
+
+	// ErrColumnDuplicate for when a foreign key column has the same name as an existing column.
+	ErrColumnDuplicate = errors.New("duplicate column")
+
+	// ErrTableCollectionConflict for when table collections with the same core.DatabaseTableCollectionName have columns with different types or different primary keys.
+	ErrTableCollectionConflict = errors.New("table collections with the same name conflict")
+
+	// ErrColumnValueInvalid for when a field in source data has more than one value for a column.
+	ErrColumnValueInvalid = errors.New("column value not valid")
+)
+
+// NewError creates a new core.Error with the default sqlgen error base.
+func NewError() *core.Error {
+	n := core.NewError().WithDefaultBaseError(ErrSqlGenError)
+	return n
+}
@@ -0,0 +1,188 @@
+package sqlgen
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+/*
+DDL generates `CREATE TABLE` statements from the database properties of a metadata model.
+
+Each table/collection becomes one table:
+  - Columns are the fields returned by database.GetColumnFields with the column type from Dialect.ColumnType.
+  - Fields with core.FieldGroupIsPrimaryKey form the `PRIMARY KEY`.
+  - A group with a different core.DatabaseTableCollectionUid, or core.DatabaseTableCollectionName and core.DatabaseJoinDepth, from its parent becomes a child table. The child table references the primary key of the parent table using the columns `<ParentTable>_<PrimaryKeyColumn>`.
+  - Table/collections with the same core.DatabaseTableCollectionName, like a self-referencing `Employee` table, are merged into one table with the columns and foreign keys of each. Their foreign key columns can be `NULL` since a row only belongs to one of them.
+*/
+type DDL struct {
+	dialect     Dialect
+	ifNotExists bool
+}
+
+// WithIfNotExists adds `IF NOT EXISTS` to each `CREATE TABLE` statement.
+func (n *DDL) WithIfNotExists(value bool) *DDL {
+	n.SetIfNotExists(value)
+	return n
+}
+
+// SetIfNotExists adds `IF NOT EXISTS` to each `CREATE TABLE` statement.
+func (n *DDL) SetIfNotExists(value bool) {
+	n.ifNotExists = value
+}
+
+/*
+CreateTables returns a `CREATE TABLE` statement for each table/collection in metadataModel.
+
+Parent tables come before their child tables. Table/collections with the same name produce one statement at the position of the first one.
+
+Returns ErrTableCollectionConflict if table/collections with the same name have columns with different types or different primary keys.
+*/
+func (n *DDL) CreateTables(metadataModel any) ([]string, error) {
+	const FunctionName = "CreateTables"
+
+	if !n.dialect.isValid() {
+		return nil, NewError().WithFunctionName(FunctionName).WithMessage(fmt.Sprintf("dialect '%s'", n.dialect)).WithNestedError(ErrDialectUnsupported)
+	}
+
+	tableCollections, err := getTableCollections(metadataModel)
+	if err != nil {
+		return nil, NewError().WithFunctionName(FunctionName).WithMessage("get table collections failed").WithNestedError(err)
+	}
+
+	names := make([]string, 0, len(tableCollections))
+	tableCollectionsByName := make(map[string][]*tableCollection)
+	for _, tableCollection := range tableCollections {
+		if _, ok := tableCollectionsByName[tableCollection.name]; !ok {
+			names = append(names, tableCollection.name)
+		}
+		tableCollectionsByName[tableCollection.name] = append(tableCollectionsByName[tableCollection.name], tableCollection)
+	}
+
+	statements := make([]string, 0, len(names))
+	for _, name := range names {
+		statement, err := n.createTable(name, tableCollectionsByName[name])
+		if err != nil {
+			return nil, NewError().WithFunctionName(FunctionName).WithMessage(fmt.Sprintf("create table '%s' failed", name)).WithNestedError(err)
+		}
+		statements = append(statements, statement)
+	}
+
+	return statements, nil
+}
+
+// createTable returns the `CREATE TABLE` statement for name merging the columns and foreign keys of tableCollections.
+func (n *DDL) createTable(name string, tableCollections []*tableCollection) (string, error) {
+	columnNames := make([]string, 0)
+	columnTypes := make(map[string]string)
+	notNull := make(map[string]bool)
+	addColumn := func(columnName string, columnType string) error {
+		if existingColumnType, ok := columnTypes[columnName]; ok {
+			if existingColumnType != columnType {
+				return fmt.Errorf("column '%s' has types '%s' and '%s': %w", columnName, existingColumnType, columnType, ErrTableCollectionConflict)
+			}
+			return nil
+		}
+		columnNames = append(columnNames, columnName)
+		columnTypes[columnName] = columnType
+		return nil
+	}
+
+	var primaryKeys []string
+	constraints := make([]string, 0)
+	for _, tableCollection := range tableCollections {
+		tableCollectionPrimaryKeys := tableCollection.primaryKeys()
+		if len(tableCollectionPrimaryKeys) > 0 {
+			if len(primaryKeys) > 0 && !slices.Equal(primaryKeys, tableCollectionPrimaryKeys) {
+				return "", fmt.Errorf("primary keys (%s) and (%s): %w", strings.Join(primaryKeys, ", "), strings.Join(tableCollectionPrimaryKeys, ", "), ErrTableCollectionConflict)
+			}
+			primaryKeys = tableCollectionPrimaryKeys
+		}
+
+		tableCollectionColumnNames := make(map[string]bool)
+		for _, columnName := range tableCollection.columnFields.ColumnFieldsReadOrder {
+			columnType, err := n.dialect.ColumnType(tableCollection.columnFields.Fields[columnName])
+			if err != nil {
+				return "", fmt.Errorf("column '%s': %w", columnName, err)
+			}
+			if err := addColumn(columnName, columnType); err != nil {
+				return "", err
+			}
+			tableCollectionColumnNames[columnName] = true
+		}
+
+		parent := tableCollection.parent
+		if parent == nil {
+			continue
+		}
+
+		parentForeignKeys, err := tableCollection.foreignKeys()
+		if err != nil {
+			return "", err
+		}
+
+		foreignKeys := make([]string, 0, len(parentForeignKeys))
+		references := make([]string, 0, len(parentForeignKeys))
+		for _, foreignKey := range parentForeignKeys {
+			if tableCollectionColumnNames[foreignKey.columnName] {
+				return "", fmt.Errorf("foreign key column '%s': %w", foreignKey.columnName, ErrColumnDuplicate)
+			}
+			columnType, err := n.dialect.ColumnType(parent.columnFields.Fields[foreignKey.referencedColumnName])
+			if err != nil {
+				return "", fmt.Errorf("foreign key column '%s': %w", foreignKey.columnName, err)
+			}
+			if err := addColumn(foreignKey.columnName, columnType); err != nil {
+				return "", err
+			}
+			if len(tableCollections) == 1 {
+				notNull[foreignKey.columnName] = true
+			}
+			foreignKeys = append(foreignKeys, n.dialect.QuoteIdentifier(foreignKey.columnName))
+			references = append(references, n.dialect.QuoteIdentifier(foreignKey.referencedColumnName))
+		}
+
+		constraint := fmt.Sprintf("FOREIGN KEY (%s) REFERENCES %s (%s) ON DELETE CASCADE", strings.Join(foreignKeys, ", "), n.dialect.QuoteIdentifier(parent.name), strings.Join(references, ", "))
+		if !slices.Contains(constraints, constraint) {
+			constraints = append(constraints, constraint)
+		}
+	}
+
+	for _, columnName := range primaryKeys {
+		notNull[columnName] = true
+	}
+
+	definitions := make([]string, 0, len(columnNames)+len(constraints)+1)
+	for _, columnName := range columnNames {
+		definition := n.dialect.QuoteIdentifier(columnName) + " " + columnTypes[columnName]
+		if notNull[columnName] {
+			definition += " NOT NULL"
+		}
+		definitions = append(definitions, definition)
+	}
+	if len(primaryKeys) > 0 {
+		definitions = append(definitions, "PRIMARY KEY ("+n.quoteIdentifiers(primaryKeys)+")")
+	}
+	definitions = append(definitions, constraints...)
+
+	createTable := "CREATE TABLE "
+	if n.ifNotExists {
+		createTable += "IF NOT EXISTS "
+	}
+	return createTable + n.dialect.QuoteIdentifier(name) + " (\n  " + strings.Join(definitions, ",\n  ") + "\n);", nil
+}
+
+func (n *DDL) quoteIdentifiers(identifiers []string) string {
+	quoted := make([]string, len(identifiers))
+	for i, identifier := range identifiers {
+		quoted[i] = n.dialect.QuoteIdentifier(identifier)
+	}
+	return strings.Join(quoted, ", ")
+}
+
+// NewDDL creates a new DDL for dialect.
+func NewDDL(dialect Dialect) *DDL {
+	n := &DDL{
+		dialect: dialect,
+	}
+	return n
+}
@@ -0,0 +1,276 @@
+package sqlgen
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	gojsoncore "github.com/rogonion/go-json/core"
+	"github.com/rogonion/go-metadatamodel/builder"
+	"github.com/rogonion/go-metadatamodel/core"
+	"github.com/rogonion/go-metadatamodel/database"
+	"github.com/rogonion/go-metadatamodel/internal"
+	"github.com/rogonion/go-metadatamodel/testdata"
+)
+
+func TestSqlGen_CreateTables(t *testing.T) {
+	for testData := range createTablesTestData {
+		statements, err := NewDDL(testData.Dialect).WithIfNotExists(testData.IfNotExists).CreateTables(testData.MetadataModel)
+		if testData.ExpectedErr != nil {
+			if !errors.Is(err, testData.ExpectedErr) {
+				t.Error(testData.TestTitle, "\n", "expected error", testData.ExpectedErr, "got", err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Error(testData.TestTitle, "\n", "CreateTables failed:", err)
+			continue
+		}
+
+		if !slices.Equal(statements, testData.Expected) {
+			t.Error(
+				testData.TestTitle, "\n",
+				"expected statements to be equal to testData.Expected\n",
+				"Expected=", gojsoncore.JsonStringifyMust(testData.Expected), "\n",
+				"statements=", gojsoncore.JsonStringifyMust(statements),
+			)
+		}
+	}
+}
+
+type createTablesData struct {
+	internal.TestData
+	Dialect       Dialect
+	IfNotExists   bool
+	MetadataModel any
+	Expected      []string
+	ExpectedErr   error
+}
+
+func createTablesTestData(yield func(data *createTablesData) bool) {
+	testCaseIndex := 1
+	if !yield(
+		&createTablesData{
+			TestData: internal.TestData{
+				TestTitle: "Employee with nested tables in PostgreSQL",
+			},
+			Dialect:       DialectPostgreSQL,
+			MetadataModel: testdata.EmployeeMetadataModel(nil),
+			Expected: []string{
+				"CREATE TABLE \"Employee\" (\n  \"ID\" NUMERIC NOT NULL,\n  \"Skills\" TEXT,\n  PRIMARY KEY (\"ID\")\n);",
+				"CREATE TABLE \"Profile\" (\n  \"Name\" TEXT NOT NULL,\n  \"Age\" NUMERIC,\n  \"Employee_ID\" NUMERIC NOT NULL,\n  PRIMARY KEY (\"Name\"),\n  FOREIGN KEY (\"Employee_ID\") REFERENCES \"Employee\" (\"ID\") ON DELETE CASCADE\n);",
+				"CREATE TABLE \"UserProfile\" (\n  \"Street\" TEXT,\n  \"City\" TEXT,\n  \"ZipCode\" TEXT,\n  \"Profile_Name\" TEXT NOT NULL,\n  FOREIGN KEY (\"Profile_Name\") REFERENCES \"Profile\" (\"Name\") ON DELETE CASCADE\n);",
+			},
+		},
+	) {
+		return
+	}
+
+	testCaseIndex++
+	if !yield(
+		&createTablesData{
+			TestData: internal.TestData{
+				TestTitle: "Product in SQLite",
+			},
+			Dialect:       DialectSQLite,
+			IfNotExists:   true,
+			MetadataModel: testdata.ProductMetadataModel(nil),
+			Expected: []string{
+				"CREATE TABLE IF NOT EXISTS \"Product\" (\n  \"ID\" NUMERIC NOT NULL,\n  \"Name\" TEXT,\n  \"Price\" NUMERIC,\n  PRIMARY KEY (\"ID\")\n);",
+			},
+		},
+	) {
+		return
+	}
+
+	testCaseIndex++
+	if !yield(
+		&createTablesData{
+			TestData: internal.TestData{
+				TestTitle: "Column types and composite primary key in PostgreSQL",
+			},
+			Dialect: DialectPostgreSQL,
+			MetadataModel: builder.Must(
+				builder.NewModel("Event").Table("Event", 0).
+					Field("Venue", core.FieldTypeText).PrimaryKey().
+					Field("Day", core.FieldTypeTimestamp).PrimaryKey().DatetimeFormat(core.FieldDatetimeFormatYYYYMMDD).
+					Field("Starts", core.FieldTypeTimestamp).DatetimeFormat(core.FieldDatetimeFormatHHMM).
+					Field("Created", core.FieldTypeTimestamp).
+					Field("Public", core.FieldTypeBoolean).
+					Field("Extra", core.FieldTypeAny).Column("extra_data").
+					Group("Tickets").Table("Ticket", 1).
+					Field("Code", core.FieldTypeText).PrimaryKey().
+					End().
+					Build(),
+			),
+			Expected: []string{
+				"CREATE TABLE \"Event\" (\n  \"Venue\" TEXT NOT NULL,\n  \"Day\" DATE NOT NULL,\n  \"Starts\" TIME,\n  \"Created\" TIMESTAMP,\n  \"Public\" BOOLEAN,\n  \"extra_data\" JSONB,\n  PRIMARY KEY (\"Venue\", \"Day\")\n);",
+				"CREATE TABLE \"Ticket\" (\n  \"Code\" TEXT NOT NULL,\n  \"Event_Venue\" TEXT NOT NULL,\n  \"Event_Day\" DATE NOT NULL,\n  PRIMARY KEY (\"Code\"),\n  FOREIGN KEY (\"Event_Venue\", \"Event_Day\") REFERENCES \"Event\" (\"Venue\", \"Day\") ON DELETE CASCADE\n);",
+			},
+		},
+	) {
+		return
+	}
+
+	testCaseIndex++
+	if !yield(
+		&createTablesData{
+			TestData: internal.TestData{
+				TestTitle: "Column types in SQLite",
+			},
+			Dialect: DialectSQLite,
+			MetadataModel: builder.Must(
+				builder.NewModel("Event").Table("Event", 0).
+					Field("Day", core.FieldTypeTimestamp).DatetimeFormat(core.FieldDatetimeFormatYYYYMMDD).
+					Field("Public", core.FieldTypeBoolean).
+					Field("Extra", core.FieldTypeAny).
+					Build(),
+			),
+			Expected: []string{
+				"CREATE TABLE \"Event\" (\n  \"Day\" TEXT,\n  \"Public\" INTEGER,\n  \"Extra\" TEXT\n);",
+			},
+		},
+	) {
+		return
+	}
+
+	testCaseIndex++
+	if !yield(
+		&createTablesData{
+			TestData: internal.TestData{
+				TestTitle: "Unsupported dialect",
+			},
+			Dialect:       "mysql",
+			MetadataModel: testdata.ProductMetadataModel(nil),
+			ExpectedErr:   ErrDialectUnsupported,
+		},
+	) {
+		return
+	}
+
+	testCaseIndex++
+	if !yield(
+		&createTablesData{
+			TestData: internal.TestData{
+				TestTitle: "Root group without table",
+			},
+			Dialect: DialectPostgreSQL,
+			MetadataModel: builder.Must(
+				builder.NewModel("Event").
+					Field("Venue", core.FieldTypeText).
+					Build(),
+			),
+			ExpectedErr: ErrTableCollectionInvalid,
+		},
+	) {
+		return
+	}
+
+	testCaseIndex++
+	if !yield(
+		&createTablesData{
+			TestData: internal.TestData{
+				TestTitle: "Root group without table wraps database error",
+			},
+			Dialect: DialectPostgreSQL,
+			MetadataModel: builder.Must(
+				builder.NewModel("Event").
+					Field("Venue", core.FieldTypeText).
+					Build(),
+			),
+			ExpectedErr: database.ErrDatabaseGetTableCollectionsError,
+		},
+	) {
+		return
+	}
+
+	testCaseIndex++
+	if !yield(
+		&createTablesData{
+			TestData: internal.TestData{
+				TestTitle: "Parent table without primary key",
+			},
+			Dialect: DialectPostgreSQL,
+			MetadataModel: builder.Must(
+				builder.NewModel("Event").Table("Event", 0).
+					Field("Venue", core.FieldTypeText).
+					Group("Tickets").Table("Ticket", 1).
+					Field("Code", core.FieldTypeText).
+					End().
+					Build(),
+			),
+			ExpectedErr: ErrPrimaryKeyMissing,
+		},
+	) {
+		return
+	}
+
+	testCaseIndex++
+	if !yield(
+		&createTablesData{
+			TestData: internal.TestData{
+				TestTitle: "Foreign key column clashes with column",
+			},
+			Dialect: DialectSQLite,
+			MetadataModel: builder.Must(
+				builder.NewModel("Event").Table("Event", 0).
+					Field("Venue", core.FieldTypeText).PrimaryKey().
+					Group("Tickets").Table("Ticket", 1).
+					Field("Event_Venue", core.FieldTypeText).
+					End().
+					Build(),
+			),
+			ExpectedErr: ErrColumnDuplicate,
+		},
+	) {
+		return
+	}
+
+	testCaseIndex++
+	if !yield(
+		&createTablesData{
+			TestData: internal.TestData{
+				TestTitle: "Self-referencing table in PostgreSQL",
+			},
+			Dialect: DialectPostgreSQL,
+			MetadataModel: builder.Must(
+				builder.NewModel("Employee").Table("Employee", 0).
+					Field("ID", core.FieldTypeNumber).PrimaryKey().
+					Field("Name", core.FieldTypeText).
+					Group("Reports").Table("Employee", 1).TableUid("Reports").
+					Field("ID", core.FieldTypeNumber).PrimaryKey().
+					Field("Title", core.FieldTypeText).
+					End().
+					Build(),
+			),
+			Expected: []string{
+				"CREATE TABLE \"Employee\" (\n  \"ID\" NUMERIC NOT NULL,\n  \"Name\" TEXT,\n  \"Title\" TEXT,\n  \"Employee_ID\" NUMERIC,\n  PRIMARY KEY (\"ID\"),\n  FOREIGN KEY (\"Employee_ID\") REFERENCES \"Employee\" (\"ID\") ON DELETE CASCADE\n);",
+			},
+		},
+	) {
+		return
+	}
+
+	testCaseIndex++
+	if !yield(
+		&createTablesData{
+			TestData: internal.TestData{
+				TestTitle: "Tables with the same name and different column types",
+			},
+			Dialect: DialectPostgreSQL,
+			MetadataModel: builder.Must(
+				builder.NewModel("Employee").Table("Employee", 0).
+					Field("ID", core.FieldTypeNumber).PrimaryKey().
+					Field("Name", core.FieldTypeText).
+					Group("Reports").Table("Employee", 1).TableUid("Reports").
+					Field("ID", core.FieldTypeNumber).PrimaryKey().
+					Field("Name", core.FieldTypeBoolean).
+					End().
+					Build(),
+			),
+			ExpectedErr: ErrTableCollectionConflict,
+		},
+	) {
+		return
+	}
+}
@@ -0,0 +1,307 @@
+package sqlgen
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/rogonion/go-json/object"
+	"github.com/rogonion/go-json/path"
+	"github.com/rogonion/go-metadatamodel/core"
+)
+
+// Operation is the kind of statement generated by DML.
+type Operation string
+
+const (
+	// OperationInsert generates `INSERT INTO ... VALUES ...`.
+	OperationInsert Operation = "Insert"
+	// OperationUpdate generates `UPDATE ... SET ... WHERE <primary key>`.
+	OperationUpdate Operation = "Update"
+	// OperationUpsert generates `INSERT INTO ... VALUES ... ON CONFLICT (<primary key>) DO UPDATE SET ...`.
+	OperationUpsert Operation = "Upsert"
+)
+
+// Statement is a parameterized statement generated by DML.
+type Statement struct {
+	// TableCollectionName is the table the statement writes to.
+	TableCollectionName string
+	// Query with placeholders from Dialect.Placeholder starting at 1.
+	Query string
+	// Arguments for the placeholders in Query.
+	Arguments []any
+}
+
+/*
+DML generates parameterized `INSERT`, `UPDATE`, and upsert statements that persist source data described by a metadata model.
+
+  - Columns are the fields returned by database.GetColumnFields followed by the foreign key columns generated by DDL if the table/collection is a child table.
+  - Fields with core.FieldGroupIsPrimaryKey identify the row to update.
+  - Each entry of a group that belongs to a child table becomes one statement for that table. The foreign key columns are set to the primary key values of the parent record.
+
+Statements are returned in write order: each record is followed by the records of its child tables in read order.
+
+Every field value is expected to be an array with at most one value, which becomes the column value. An empty or missing value becomes `NULL`. Nested groups in the same table/collection use the entry at index `0`.
+*/
+type DML struct {
+	dialect Dialect
+}
+
+// Insert returns `INSERT` statements for every record in sourceData. Refer to DML.Statements.
+func (n *DML) Insert(metadataModel any, sourceData *object.Object) ([]Statement, error) {
+	return n.Statements(OperationInsert, metadataModel, sourceData)
+}
+
+// Update returns `UPDATE` statements for every record in sourceData. Refer to DML.Statements.
+func (n *DML) Update(metadataModel any, sourceData *object.Object) ([]Statement, error) {
+	return n.Statements(OperationUpdate, metadataModel, sourceData)
+}
+
+// Upsert returns `INSERT ... ON CONFLICT` statements for every record in sourceData. Refer to DML.Statements.
+func (n *DML) Upsert(metadataModel any, sourceData *object.Object) ([]Statement, error) {
+	return n.Statements(OperationUpsert, metadataModel, sourceData)
+}
+
+/*
+Statements returns statements of operation for every record in sourceData.
+
+Parameters:
+  - operation - One of OperationInsert, OperationUpdate, or OperationUpsert.
+  - metadataModel - with database properties.
+  - sourceData - Refer to object.Object. A slice of records or a single record of the root table/collection.
+
+OperationUpdate and OperationUpsert require every table/collection to have a field with core.FieldGroupIsPrimaryKey. A table/collection whose columns are all primary keys is skipped by OperationUpdate and uses `DO NOTHING` with OperationUpsert.
+*/
+func (n *DML) Statements(operation Operation, metadataModel any, sourceData *object.Object) ([]Statement, error) {
+	const FunctionName = "Statements"
+
+	if !n.dialect.isValid() {
+		return nil, NewError().WithFunctionName(FunctionName).WithMessage(fmt.Sprintf("dialect '%s'", n.dialect)).WithNestedError(ErrDialectUnsupported)
+	}
+
+	if operation != OperationInsert && operation != OperationUpdate && operation != OperationUpsert {
+		return nil, NewError().WithFunctionName(FunctionName).WithMessage(fmt.Sprintf("operation '%s' not supported", operation)).WithNestedError(ErrSqlGenError)
+	}
+
+	if sourceData == nil {
+		return nil, NewError().WithFunctionName(FunctionName).WithMessage("source data is nil").WithNestedError(ErrSqlGenError)
+	}
+
+	tableCollections, err := getTableCollections(metadataModel)
+	if err != nil {
+		return nil, NewError().WithFunctionName(FunctionName).WithMessage("get table collections failed").WithNestedError(err)
+	}
+
+	statements := make([]Statement, 0)
+	for _, record := range dmlRecords(sourceData.GetSourceReflected()) {
+		if err := n.statements(operation, tableCollections, tableCollections[0], record, nil, &statements); err != nil {
+			return nil, NewError().WithFunctionName(FunctionName).WithMessage("generate statements failed").WithNestedError(err)
+		}
+	}
+
+	return statements, nil
+}
+
+// statements appends the statement for record in tableCollection followed by the statements of the records of its child tables.
+func (n *DML) statements(operation Operation, tableCollections []*tableCollection, tableCollection *tableCollection, record reflect.Value, parentPrimaryKeyValues map[string]any, statements *[]Statement) error {
+	recordObject := object.NewObject().WithSourceReflected(record)
+
+	columnNames := make([]string, 0)
+	columnValues := make(map[string]any)
+	for _, columnName := range tableCollection.columnFields.ColumnFieldsReadOrder {
+		jsonPathKey, _ := core.AsJSONPath(tableCollection.columnFields.Fields[columnName][core.FieldGroupJsonPathKey])
+		value, err := n.value(recordObject, tableCollection.fieldGroupJsonPathKey, jsonPathKey)
+		if err != nil {
+			return fmt.Errorf("table '%s' column '%s': %w", tableCollection.name, columnName, err)
+		}
+		columnNames = append(columnNames, columnName)
+		columnValues[columnName] = value
+	}
+
+	foreignKeys, err := tableCollection.foreignKeys()
+	if err != nil {
+		return err
+	}
+	for _, foreignKey := range foreignKeys {
+		columnNames = append(columnNames, foreignKey.columnName)
+		columnValues[foreignKey.columnName] = parentPrimaryKeyValues[foreignKey.referencedColumnName]
+	}
+
+	statement, err := n.statement(operation, tableCollection, columnNames, columnValues)
+	if err != nil {
+		return err
+	}
+	if statement != nil {
+		*statements = append(*statements, *statement)
+	}
+
+	for _, child := range tableCollections {
+		if child.parent != tableCollection {
+			continue
+		}
+
+		childPath, err := n.jsonPathToValue(tableCollection.fieldGroupJsonPathKey, child.fieldGroupJsonPathKey)
+		if err != nil {
+			return fmt.Errorf("table '%s': %w", child.name, err)
+		}
+		if noOfResults, err := recordObject.Get(childPath); err != nil || noOfResults == 0 {
+			continue
+		}
+		for _, childRecord := range dmlRecords(recordObject.GetValueFoundReflected()) {
+			if err := n.statements(operation, tableCollections, child, childRecord, columnValues, statements); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// statement returns the statement of operation that writes columnValues into tableCollection. nil if there is nothing to write.
+func (n *DML) statement(operation Operation, tableCollection *tableCollection, columnNames []string, columnValues map[string]any) (*Statement, error) {
+	primaryKeys := tableCollection.primaryKeys()
+	isPrimaryKey := make(map[string]bool)
+	for _, columnName := range primaryKeys {
+		isPrimaryKey[columnName] = true
+	}
+	if operation != OperationInsert && len(primaryKeys) == 0 {
+		return nil, fmt.Errorf("table '%s': %w", tableCollection.name, ErrPrimaryKeyMissing)
+	}
+
+	statement := &Statement{
+		TableCollectionName: tableCollection.name,
+		Arguments:           make([]any, 0, len(columnNames)),
+	}
+	placeholder := func(value any) string {
+		statement.Arguments = append(statement.Arguments, value)
+		return n.dialect.Placeholder(len(statement.Arguments))
+	}
+
+	if operation == OperationUpdate {
+		assignments := make([]string, 0)
+		for _, columnName := range columnNames {
+			if !isPrimaryKey[columnName] {
+				assignments = append(assignments, n.dialect.QuoteIdentifier(columnName)+" = "+placeholder(columnValues[columnName]))
+			}
+		}
+		if len(assignments) == 0 {
+			return nil, nil
+		}
+		conditions := make([]string, 0, len(primaryKeys))
+		for _, columnName := range primaryKeys {
+			conditions = append(conditions, n.dialect.QuoteIdentifier(columnName)+" = "+placeholder(columnValues[columnName]))
+		}
+		statement.Query = "UPDATE " + n.dialect.QuoteIdentifier(tableCollection.name) + "\nSET " + strings.Join(assignments, ", ") + "\nWHERE " + strings.Join(conditions, " AND ") + ";"
+		return statement, nil
+	}
+
+	columns := make([]string, 0, len(columnNames))
+	values := make([]string, 0, len(columnNames))
+	for _, columnName := range columnNames {
+		columns = append(columns, n.dialect.QuoteIdentifier(columnName))
+		values = append(values, placeholder(columnValues[columnName]))
+	}
+	statement.Query = "INSERT INTO " + n.dialect.QuoteIdentifier(tableCollection.name) + " (" + strings.Join(columns, ", ") + ")\nVALUES (" + strings.Join(values, ", ") + ")"
+
+	if operation == OperationUpsert {
+		conflictColumns := make([]string, 0, len(primaryKeys))
+		for _, columnName := range primaryKeys {
+			conflictColumns = append(conflictColumns, n.dialect.QuoteIdentifier(columnName))
+		}
+		assignments := make([]string, 0)
+		for _, columnName := range columnNames {
+			if !isPrimaryKey[columnName] {
+				assignments = append(assignments, n.dialect.QuoteIdentifier(columnName)+" = excluded."+n.dialect.QuoteIdentifier(columnName))
+			}
+		}
+		statement.Query += "\nON CONFLICT (" + strings.Join(conflictColumns, ", ") + ")"
+		if len(assignments) > 0 {
+			statement.Query += " DO UPDATE SET " + strings.Join(assignments, ", ")
+		} else {
+			statement.Query += " DO NOTHING"
+		}
+	}
+
+	statement.Query += ";"
+	return statement, nil
+}
+
+// value returns the column value of the field at fieldGroupJsonPathKey in recordObject, a record of the table/collection at tableCollectionJsonPathKey.
+func (n *DML) value(recordObject *object.Object, tableCollectionJsonPathKey path.JSONPath, fieldGroupJsonPathKey path.JSONPath) (any, error) {
+	jsonPathToValue, err := n.jsonPathToValue(tableCollectionJsonPathKey, fieldGroupJsonPathKey)
+	if err != nil {
+		return nil, err
+	}
+
+	if noOfResults, err := recordObject.Get(jsonPathToValue); err != nil || noOfResults == 0 {
+		return nil, nil
+	}
+
+	values := recordObject.GetValueFoundReflected()
+	for values.Kind() == reflect.Interface || values.Kind() == reflect.Pointer {
+		if values.IsNil() {
+			return nil, nil
+		}
+		values = values.Elem()
+	}
+	if !values.IsValid() {
+		return nil, nil
+	}
+	if values.Kind() != reflect.Slice && values.Kind() != reflect.Array {
+		return values.Interface(), nil
+	}
+
+	switch values.Len() {
+	case 0:
+		return nil, nil
+	case 1:
+		return values.Index(0).Interface(), nil
+	default:
+		return nil, fmt.Errorf("%d values found: %w", values.Len(), ErrColumnValueInvalid)
+	}
+}
+
+// jsonPathToValue returns the path to the value of the field/group at fieldGroupJsonPathKey in a record of the table/collection at tableCollectionJsonPathKey.
+func (n *DML) jsonPathToValue(tableCollectionJsonPathKey path.JSONPath, fieldGroupJsonPathKey path.JSONPath) (path.JSONPath, error) {
+	relativeJsonPathKey := path.JSONPath(path.JsonpathKeyRoot) + fieldGroupJsonPathKey[len(tableCollectionJsonPathKey):]
+	return core.NewJsonPathToValue().Get(relativeJsonPathKey, nil)
+}
+
+// dmlRecords returns the elements of value if it is a slice or array else value as the only record.
+func dmlRecords(value reflect.Value) []reflect.Value {
+	value = dmlRecord(value)
+	if !value.IsValid() {
+		return nil
+	}
+
+	if value.Kind() != reflect.Slice && value.Kind() != reflect.Array {
+		return []reflect.Value{value}
+	}
+
+	records := make([]reflect.Value, 0, value.Len())
+	for i := 0; i < value.Len(); i++ {
+		if record := dmlRecord(value.Index(i)); record.IsValid() {
+			records = append(records, record)
+		}
+	}
+	return records
+}
+
+// dmlRecord returns the value that value holds if it is an interface. Invalid if value is nil.
+func dmlRecord(value reflect.Value) reflect.Value {
+	for value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			return reflect.Value{}
+		}
+		value = value.Elem()
+	}
+	return value
+}
+
+// NewDML creates a new DML for dialect.
+func NewDML(dialect Dialect) *DML {
+	n := &DML{
+		dialect: dialect,
+	}
+	return n
+}
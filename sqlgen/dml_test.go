@@ -0,0 +1,212 @@
+package sqlgen
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	gojsoncore "github.com/rogonion/go-json/core"
+	"github.com/rogonion/go-json/object"
+	"github.com/rogonion/go-metadatamodel/builder"
+	"github.com/rogonion/go-metadatamodel/core"
+	"github.com/rogonion/go-metadatamodel/internal"
+	"github.com/rogonion/go-metadatamodel/testdata"
+)
+
+func TestSqlGen_DML(t *testing.T) {
+	for testData := range dmlTestData {
+		statements, err := NewDML(testData.Dialect).Statements(testData.Operation, testData.MetadataModel, object.NewObject().WithSourceInterface(testData.SourceData))
+		if testData.ExpectedErr != nil {
+			if !errors.Is(err, testData.ExpectedErr) {
+				t.Error(testData.TestTitle, "\n", "expected error", testData.ExpectedErr, "got", err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Error(testData.TestTitle, "\n", "Statements failed:", err)
+			continue
+		}
+
+		if !reflect.DeepEqual(statements, testData.Expected) {
+			t.Error(
+				testData.TestTitle, "\n",
+				"expected statements to be equal to testData.Expected\n",
+				"Expected=", gojsoncore.JsonStringifyMust(testData.Expected), "\n",
+				"statements=", gojsoncore.JsonStringifyMust(statements),
+			)
+		}
+	}
+}
+
+type dmlData struct {
+	internal.TestData
+	Dialect       Dialect
+	Operation     Operation
+	MetadataModel any
+	SourceData    any
+	Expected      []Statement
+	ExpectedErr   error
+}
+
+func dmlTestData(yield func(data *dmlData) bool) {
+	testCaseIndex := 1
+	employees := []*testdata.Employee{
+		{
+			ID: []int{1},
+			Profile: []*testdata.UserProfile{
+				{
+					Name:    []string{"Alice"},
+					Age:     []int{30},
+					Address: []testdata.Address{{City: []string{"Nairobi"}}, {City: []string{"Mombasa"}}},
+				},
+			},
+			Skills: []string{"Go"},
+		},
+		{
+			ID: []int{2},
+		},
+	}
+	if !yield(
+		&dmlData{
+			TestData: internal.TestData{
+				TestTitle: "Insert Employee with child tables in PostgreSQL",
+			},
+			Dialect:       DialectPostgreSQL,
+			Operation:     OperationInsert,
+			MetadataModel: testdata.EmployeeMetadataModel(nil),
+			SourceData:    employees,
+			Expected: []Statement{
+				{TableCollectionName: "Employee", Query: "INSERT INTO \"Employee\" (\"ID\", \"Skills\")\nVALUES ($1, $2);", Arguments: []any{1, "Go"}},
+				{TableCollectionName: "Profile", Query: "INSERT INTO \"Profile\" (\"Name\", \"Age\", \"Employee_ID\")\nVALUES ($1, $2, $3);", Arguments: []any{"Alice", 30, 1}},
+				{TableCollectionName: "UserProfile", Query: "INSERT INTO \"UserProfile\" (\"Street\", \"City\", \"ZipCode\", \"Profile_Name\")\nVALUES ($1, $2, $3, $4);", Arguments: []any{nil, "Nairobi", nil, "Alice"}},
+				{TableCollectionName: "UserProfile", Query: "INSERT INTO \"UserProfile\" (\"Street\", \"City\", \"ZipCode\", \"Profile_Name\")\nVALUES ($1, $2, $3, $4);", Arguments: []any{nil, "Mombasa", nil, "Alice"}},
+				{TableCollectionName: "Employee", Query: "INSERT INTO \"Employee\" (\"ID\", \"Skills\")\nVALUES ($1, $2);", Arguments: []any{2, nil}},
+			},
+		},
+	) {
+		return
+	}
+
+	testCaseIndex++
+	if !yield(
+		&dmlData{
+			TestData: internal.TestData{
+				TestTitle: "Update single Product record in SQLite",
+			},
+			Dialect:       DialectSQLite,
+			Operation:     OperationUpdate,
+			MetadataModel: testdata.ProductMetadataModel(nil),
+			SourceData: gojsoncore.JsonObject{
+				"ID":    gojsoncore.JsonArray{float64(3)},
+				"Name":  gojsoncore.JsonArray{"Twinkies"},
+				"Price": gojsoncore.JsonArray{},
+			},
+			Expected: []Statement{
+				{TableCollectionName: "Product", Query: "UPDATE \"Product\"\nSET \"Name\" = ?, \"Price\" = ?\nWHERE \"ID\" = ?;", Arguments: []any{"Twinkies", nil, float64(3)}},
+			},
+		},
+	) {
+		return
+	}
+
+	testCaseIndex++
+	if !yield(
+		&dmlData{
+			TestData: internal.TestData{
+				TestTitle: "Upsert Product records in PostgreSQL",
+			},
+			Dialect:       DialectPostgreSQL,
+			Operation:     OperationUpsert,
+			MetadataModel: testdata.ProductMetadataModel(nil),
+			SourceData: gojsoncore.JsonArray{
+				gojsoncore.JsonObject{
+					"ID":    gojsoncore.JsonArray{float64(3)},
+					"Price": gojsoncore.JsonArray{float64(1.5)},
+				},
+			},
+			Expected: []Statement{
+				{TableCollectionName: "Product", Query: "INSERT INTO \"Product\" (\"ID\", \"Name\", \"Price\")\nVALUES ($1, $2, $3)\nON CONFLICT (\"ID\") DO UPDATE SET \"Name\" = excluded.\"Name\", \"Price\" = excluded.\"Price\";", Arguments: []any{float64(3), nil, float64(1.5)}},
+			},
+		},
+	) {
+		return
+	}
+
+	testCaseIndex++
+	tagsMetadataModel, _ := builder.NewModel("Post").Table("Post", 0).
+		Field("ID", core.FieldTypeNumber).PrimaryKey().
+		Group("Tags").Table("Tag", 1).
+		Field("Name", core.FieldTypeText).PrimaryKey().
+		End().
+		Build()
+	if !yield(
+		&dmlData{
+			TestData: internal.TestData{
+				TestTitle: "Upsert child table whose columns are all primary keys in SQLite",
+			},
+			Dialect:       DialectSQLite,
+			Operation:     OperationUpsert,
+			MetadataModel: tagsMetadataModel,
+			SourceData: gojsoncore.JsonObject{
+				"ID":   gojsoncore.JsonArray{float64(1)},
+				"Tags": gojsoncore.JsonArray{gojsoncore.JsonObject{"Name": gojsoncore.JsonArray{"go"}}},
+			},
+			Expected: []Statement{
+				{TableCollectionName: "Post", Query: "INSERT INTO \"Post\" (\"ID\")\nVALUES (?)\nON CONFLICT (\"ID\") DO NOTHING;", Arguments: []any{float64(1)}},
+				{TableCollectionName: "Tag", Query: "INSERT INTO \"Tag\" (\"Name\", \"Post_ID\")\nVALUES (?, ?)\nON CONFLICT (\"Name\") DO UPDATE SET \"Post_ID\" = excluded.\"Post_ID\";", Arguments: []any{"go", float64(1)}},
+			},
+		},
+	) {
+		return
+	}
+
+	testCaseIndex++
+	if !yield(
+		&dmlData{
+			TestData: internal.TestData{
+				TestTitle: "Update table without primary key fails",
+			},
+			Dialect:       DialectPostgreSQL,
+			Operation:     OperationUpdate,
+			MetadataModel: testdata.EmployeeMetadataModel(nil),
+			SourceData:    employees,
+			ExpectedErr:   ErrPrimaryKeyMissing,
+		},
+	) {
+		return
+	}
+
+	testCaseIndex++
+	if !yield(
+		&dmlData{
+			TestData: internal.TestData{
+				TestTitle: "Field with more than one value fails",
+			},
+			Dialect:       DialectPostgreSQL,
+			Operation:     OperationInsert,
+			MetadataModel: testdata.ProductMetadataModel(nil),
+			SourceData: gojsoncore.JsonObject{
+				"ID": gojsoncore.JsonArray{float64(1), float64(2)},
+			},
+			ExpectedErr: ErrColumnValueInvalid,
+		},
+	) {
+		return
+	}
+
+	testCaseIndex++
+	if !yield(
+		&dmlData{
+			TestData: internal.TestData{
+				TestTitle: "Unsupported dialect fails",
+			},
+			Dialect:       Dialect("MySQL"),
+			Operation:     OperationInsert,
+			MetadataModel: testdata.ProductMetadataModel(nil),
+			SourceData:    gojsoncore.JsonObject{},
+			ExpectedErr:   ErrDialectUnsupported,
+		},
+	) {
+		return
+	}
+}
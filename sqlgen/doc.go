@@ -0,0 +1,33 @@
+/*
+Package sqlgen generates SQL statements for PostgreSQL and SQLite from the database properties of a metadata model.
+
+Tables are derived from core.DatabaseTableCollectionUid, core.DatabaseTableCollectionName, and core.DatabaseJoinDepth and their columns are retrieved using database.GetColumnFields:
+  - Column types are derived from core.FieldDataType. Refer to Dialect.ColumnType.
+  - Fields with core.FieldGroupIsPrimaryKey form the primary key.
+  - Groups that belong to a different table/collection from their parent group become child tables with a foreign key to the primary key of the parent table.
+  - Table/collections with the same name are merged into one table.
+
+Query conditions consumed by filter.DataFilter can be translated into parameterized `WHERE` conditions using Where. Conditions on fields in child tables become correlated `EXISTS` subqueries.
+
+Select generates a `SELECT` statement for a table/collection joined up to the root table/collection. core.DatabaseDistinct, core.DatabaseSortByAsc, core.DatabaseLimit, and core.DatabaseOffset are honored.
+
+DML generates `INSERT`, `UPDATE`, and upsert statements for source data. Entries of groups that belong to child tables become statements for those tables, ordered after the parent record, with the foreign key columns set to the primary key values of the parent record.
+
+# Usage
+
+	import (
+		"github.com/rogonion/go-metadatamodel/sqlgen"
+	)
+
+	statements, err := sqlgen.NewDDL(sqlgen.DialectPostgreSQL).WithIfNotExists(true).CreateTables(metadataModel)
+
+	// Condition without the `WHERE` keyword and the arguments for its placeholders.
+	condition, arguments, err := sqlgen.NewWhere(sqlgen.DialectPostgreSQL).Get(metadataModel, queryCondition)
+
+	// Select rows of a nested table whose root record matches queryCondition.
+	statement, arguments, err := sqlgen.NewSelect(sqlgen.DialectSQLite).WithTableCollectionUID("Address").Get(metadataModel, queryCondition)
+
+	// Statements of every record in sourceData and its child tables in write order.
+	statements, err := sqlgen.NewDML(sqlgen.DialectPostgreSQL).Insert(metadataModel, sourceData)
+*/
+package sqlgen
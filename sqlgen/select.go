@@ -0,0 +1,206 @@
+package sqlgen
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	gojsoncore "github.com/rogonion/go-json/core"
+	"github.com/rogonion/go-json/schema"
+	"github.com/rogonion/go-metadatamodel/core"
+)
+
+/*
+Select generates a `SELECT` statement for a table/collection in a metadata model.
+
+  - Columns are the fields returned by database.GetColumnFields followed by the foreign key columns generated by DDL if the table/collection is a child table.
+  - Parent tables are joined using `INNER JOIN` up to the root table/collection so that query conditions, which are relative to the root table/collection, can be applied using Where.
+  - Each table is referenced by an alias like `t1` from the position of its table/collection in read order so that self-referencing tables can be joined.
+  - `DISTINCT` is added if the group of the table/collection or any of its columns has core.DatabaseDistinct set to `true`.
+  - Columns with core.DatabaseSortByAsc are added to `ORDER BY` in read order. `ASC` if `true` and `DESC` if `false`.
+  - core.DatabaseLimit and core.DatabaseOffset of the group of the table/collection become `LIMIT` and `OFFSET`.
+*/
+type Select struct {
+	dialect Dialect
+
+	// Table/collection to select from. Defaults to the root table/collection.
+	tableCollectionUID  *string
+	tableCollectionName *string
+	joinDepth           *int64
+}
+
+// WithTableCollectionUID sets the core.DatabaseTableCollectionUid of the table/collection to select from.
+func (n *Select) WithTableCollectionUID(value string) *Select {
+	n.SetTableCollectionUID(value)
+	return n
+}
+
+// SetTableCollectionUID sets the core.DatabaseTableCollectionUid of the table/collection to select from.
+func (n *Select) SetTableCollectionUID(value string) {
+	n.tableCollectionUID = &value
+}
+
+// WithTableCollectionName sets the core.DatabaseTableCollectionName of the table/collection to select from. Used together with Select.WithJoinDepth.
+func (n *Select) WithTableCollectionName(value string) *Select {
+	n.SetTableCollectionName(value)
+	return n
+}
+
+// SetTableCollectionName sets the core.DatabaseTableCollectionName of the table/collection to select from. Used together with Select.SetJoinDepth.
+func (n *Select) SetTableCollectionName(value string) {
+	n.tableCollectionName = &value
+}
+
+// WithJoinDepth sets the core.DatabaseJoinDepth of the table/collection to select from. Used together with Select.WithTableCollectionName.
+func (n *Select) WithJoinDepth(value int64) *Select {
+	n.SetJoinDepth(value)
+	return n
+}
+
+// SetJoinDepth sets the core.DatabaseJoinDepth of the table/collection to select from. Used together with Select.SetTableCollectionName.
+func (n *Select) SetJoinDepth(value int64) {
+	n.joinDepth = &value
+}
+
+/*
+Get returns the `SELECT` statement and the arguments for its placeholders.
+
+Parameters:
+  - metadataModel - with database properties.
+  - queryCondition - Optional. Query condition consumed by filter.DataFilter. Refer to Where.
+*/
+func (n *Select) Get(metadataModel any, queryCondition gojsoncore.JsonObject) (string, []any, error) {
+	const FunctionName = "Get"
+
+	if !n.dialect.isValid() {
+		return "", nil, NewError().WithFunctionName(FunctionName).WithMessage(fmt.Sprintf("dialect '%s'", n.dialect)).WithNestedError(ErrDialectUnsupported)
+	}
+
+	tableCollections, err := getTableCollections(metadataModel)
+	if err != nil {
+		return "", nil, NewError().WithFunctionName(FunctionName).WithMessage("get table collections failed").WithNestedError(err)
+	}
+
+	var target *tableCollection
+	for _, tableCollection := range tableCollections {
+		if n.tableCollectionUID != nil {
+			if tableCollection.uid == *n.tableCollectionUID {
+				target = tableCollection
+				break
+			}
+		} else if n.tableCollectionName != nil && n.joinDepth != nil {
+			if tableCollection.name == *n.tableCollectionName && tableCollection.joinDepth == *n.joinDepth {
+				target = tableCollection
+				break
+			}
+		} else {
+			target = tableCollection
+			break
+		}
+	}
+	if target == nil {
+		return "", nil, NewError().WithFunctionName(FunctionName).WithMessage("table collection to select from not found").WithNestedError(ErrTableCollectionNotFound)
+	}
+
+	targetQualifier := target.alias("t")
+	distinct, _ := target.group[core.DatabaseDistinct].(bool)
+	columns := make([]string, 0)
+	orderBy := make([]string, 0)
+	for _, columnName := range target.columnFields.ColumnFieldsReadOrder {
+		field := target.columnFields.Fields[columnName]
+		columns = append(columns, qualifiedColumn(n.dialect, targetQualifier, columnName))
+		if value, ok := field[core.DatabaseDistinct].(bool); ok && value {
+			distinct = true
+		}
+		if sortByAsc, ok := field[core.DatabaseSortByAsc].(bool); ok {
+			if sortByAsc {
+				orderBy = append(orderBy, qualifiedColumn(n.dialect, targetQualifier, columnName)+" ASC")
+			} else {
+				orderBy = append(orderBy, qualifiedColumn(n.dialect, targetQualifier, columnName)+" DESC")
+			}
+		}
+	}
+	foreignKeys, err := target.foreignKeys()
+	if err != nil {
+		return "", nil, NewError().WithFunctionName(FunctionName).WithMessage("get foreign keys failed").WithNestedError(err)
+	}
+	for _, foreignKey := range foreignKeys {
+		columns = append(columns, qualifiedColumn(n.dialect, targetQualifier, foreignKey.columnName))
+	}
+
+	statement := "SELECT "
+	if distinct {
+		statement += "DISTINCT "
+	}
+	statement += strings.Join(columns, ", ") + "\nFROM " + n.dialect.QuoteIdentifier(target.name) + " AS " + targetQualifier
+
+	for tableCollection := target; tableCollection.parent != nil; tableCollection = tableCollection.parent {
+		joinCondition, err := tableCollection.joinCondition(n.dialect, tableCollection.alias("t"), tableCollection.parent.alias("t"))
+		if err != nil {
+			return "", nil, NewError().WithFunctionName(FunctionName).WithMessage("get join condition failed").WithNestedError(err)
+		}
+		statement += "\nINNER JOIN " + n.dialect.QuoteIdentifier(tableCollection.parent.name) + " AS " + tableCollection.parent.alias("t") + " ON " + joinCondition
+	}
+
+	condition, arguments, err := NewWhere(n.dialect).condition(tableCollections, queryCondition, tableCollections[0].alias("t"))
+	if err != nil {
+		return "", nil, NewError().WithFunctionName(FunctionName).WithMessage("translate query condition failed").WithNestedError(err)
+	}
+	if len(condition) > 0 {
+		statement += "\nWHERE " + condition
+	}
+
+	if len(orderBy) > 0 {
+		statement += "\nORDER BY " + strings.Join(orderBy, ", ")
+	}
+
+	limit, err := groupInt(target.group, core.DatabaseLimit)
+	if err != nil {
+		return "", nil, NewError().WithFunctionName(FunctionName).WithMessage("get limit failed").WithNestedError(err)
+	}
+	offset, err := groupInt(target.group, core.DatabaseOffset)
+	if err != nil {
+		return "", nil, NewError().WithFunctionName(FunctionName).WithMessage("get offset failed").WithNestedError(err)
+	}
+	if limit != nil || (offset != nil && n.dialect == DialectSQLite) {
+		// SQLite only supports OFFSET after LIMIT where -1 means no limit.
+		limitValue := -1
+		if limit != nil {
+			limitValue = *limit
+		}
+		statement += "\nLIMIT " + strconv.Itoa(limitValue)
+		if offset != nil {
+			statement += " OFFSET " + strconv.Itoa(*offset)
+		}
+	} else if offset != nil {
+		statement += "\nOFFSET " + strconv.Itoa(*offset)
+	}
+
+	return statement + ";", arguments, nil
+}
+
+// groupInt returns group[key] converted to a non-negative int or nil if not set.
+func groupInt(group gojsoncore.JsonObject, key string) (*int, error) {
+	value, ok := group[key]
+	if !ok || value == nil {
+		return nil, nil
+	}
+
+	var valueInt int
+	if err := schema.NewConversion().Convert(value, &schema.DynamicSchemaNode{Type: reflect.TypeOf(0), Kind: reflect.Int}, &valueInt); err != nil {
+		return nil, fmt.Errorf("convert '%s' to int failed: %w", key, err)
+	}
+	if valueInt < 0 {
+		return nil, fmt.Errorf("'%s' is %d: %w", key, valueInt, ErrTableCollectionInvalid)
+	}
+	return &valueInt, nil
+}
+
+// NewSelect creates a new Select for dialect.
+func NewSelect(dialect Dialect) *Select {
+	n := &Select{
+		dialect: dialect,
+	}
+	return n
+}
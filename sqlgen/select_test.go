@@ -0,0 +1,220 @@
+package sqlgen
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	gojsoncore "github.com/rogonion/go-json/core"
+	"github.com/rogonion/go-metadatamodel/builder"
+	"github.com/rogonion/go-metadatamodel/core"
+	"github.com/rogonion/go-metadatamodel/filter"
+	"github.com/rogonion/go-metadatamodel/internal"
+	"github.com/rogonion/go-metadatamodel/testdata"
+)
+
+func TestSqlGen_Select(t *testing.T) {
+	for testData := range selectTestData {
+		statement, arguments, err := testData.Select.Get(testData.MetadataModel, testData.QueryCondition)
+		if testData.ExpectedErr != nil {
+			if !errors.Is(err, testData.ExpectedErr) {
+				t.Error(testData.TestTitle, "\n", "expected error", testData.ExpectedErr, "got", err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Error(testData.TestTitle, "\n", "Get failed:", err)
+			continue
+		}
+
+		if statement != testData.Expected {
+			t.Error(
+				testData.TestTitle, "\n",
+				"expected statement to be equal to testData.Expected\n",
+				"Expected=", testData.Expected, "\n",
+				"statement=", statement,
+			)
+		}
+		if !reflect.DeepEqual(arguments, testData.ExpectedArguments) {
+			t.Error(
+				testData.TestTitle, "\n",
+				"expected arguments to be equal to testData.ExpectedArguments\n",
+				"Expected=", testData.ExpectedArguments, "\n",
+				"arguments=", arguments,
+			)
+		}
+	}
+}
+
+type selectData struct {
+	internal.TestData
+	Select            *Select
+	MetadataModel     any
+	QueryCondition    gojsoncore.JsonObject
+	Expected          string
+	ExpectedArguments []any
+	ExpectedErr       error
+}
+
+func selectTestData(yield func(data *selectData) bool) {
+	employeeIdQueryCondition := gojsoncore.JsonObject{
+		filter.QueryConditionType: filter.QuerySectionTypeFieldGroup,
+		filter.QueryConditionValue: gojsoncore.JsonObject{
+			"$.GroupFields[*].ID": gojsoncore.JsonObject{
+				filter.FilterConditionEqualTo: gojsoncore.JsonObject{
+					filter.FilterConditionAssumedFieldType: core.FieldTypeNumber,
+					filter.FilterConditionValue:            1,
+				},
+			},
+		},
+	}
+
+	testCaseIndex := 1
+	if !yield(
+		&selectData{
+			TestData: internal.TestData{
+				TestTitle: "Root table without query condition",
+			},
+			Select:            NewSelect(DialectPostgreSQL),
+			MetadataModel:     testdata.EmployeeMetadataModel(nil),
+			Expected:          "SELECT t0.\"ID\", t0.\"Skills\"\nFROM \"Employee\" AS t0;",
+			ExpectedArguments: []any{},
+		},
+	) {
+		return
+	}
+
+	testCaseIndex++
+	if !yield(
+		&selectData{
+			TestData: internal.TestData{
+				TestTitle: "Nested table joined to root table",
+			},
+			Select:            NewSelect(DialectPostgreSQL).WithTableCollectionUID("UserProfile"),
+			MetadataModel:     testdata.EmployeeMetadataModel(nil),
+			QueryCondition:    employeeIdQueryCondition,
+			Expected:          "SELECT t2.\"Street\", t2.\"City\", t2.\"ZipCode\", t2.\"Profile_Name\"\nFROM \"UserProfile\" AS t2\nINNER JOIN \"Profile\" AS t1 ON t2.\"Profile_Name\" = t1.\"Name\"\nINNER JOIN \"Employee\" AS t0 ON t1.\"Employee_ID\" = t0.\"ID\"\nWHERE t0.\"ID\" IS NOT NULL AND t0.\"ID\" = $1;",
+			ExpectedArguments: []any{1.0},
+		},
+	) {
+		return
+	}
+
+	testCaseIndex++
+	if !yield(
+		&selectData{
+			TestData: internal.TestData{
+				TestTitle: "Distinct, sort, limit, and offset in PostgreSQL",
+			},
+			Select: NewSelect(DialectPostgreSQL).WithTableCollectionName("Ticket").WithJoinDepth(1),
+			MetadataModel: builder.Must(
+				builder.NewModel("Event").Table("Event", 0).
+					Field("ID", core.FieldTypeNumber).PrimaryKey().
+					Group("Tickets").Table("Ticket", 1).Property(core.DatabaseDistinct, true).Property(core.DatabaseLimit, 10).Property(core.DatabaseOffset, 20).
+					Field("Code", core.FieldTypeText).PrimaryKey().Property(core.DatabaseSortByAsc, true).
+					Field("Price", core.FieldTypeNumber).Property(core.DatabaseSortByAsc, false).
+					End().
+					Build(),
+			),
+			Expected:          "SELECT DISTINCT t1.\"Code\", t1.\"Price\", t1.\"Event_ID\"\nFROM \"Ticket\" AS t1\nINNER JOIN \"Event\" AS t0 ON t1.\"Event_ID\" = t0.\"ID\"\nORDER BY t1.\"Code\" ASC, t1.\"Price\" DESC\nLIMIT 10 OFFSET 20;",
+			ExpectedArguments: []any{},
+		},
+	) {
+		return
+	}
+
+	testCaseIndex++
+	if !yield(
+		&selectData{
+			TestData: internal.TestData{
+				TestTitle: "Distinct column and offset without limit in SQLite",
+			},
+			Select: NewSelect(DialectSQLite),
+			MetadataModel: builder.Must(
+				builder.NewModel("Product").Table("Product", 0).Property(core.DatabaseOffset, 5).
+					Field("Name", core.FieldTypeText).Property(core.DatabaseDistinct, true).
+					Build(),
+			),
+			QueryCondition: gojsoncore.JsonObject{
+				filter.QueryConditionType: filter.QuerySectionTypeFieldGroup,
+				filter.QueryConditionValue: gojsoncore.JsonObject{
+					"$.GroupFields[*].Name": gojsoncore.JsonObject{
+						filter.FilterConditionEndsWith: gojsoncore.JsonObject{
+							filter.FilterConditionAssumedFieldType: core.FieldTypeText,
+							filter.FilterConditionValue:            "cake",
+						},
+					},
+				},
+			},
+			Expected:          "SELECT DISTINCT t0.\"Name\"\nFROM \"Product\" AS t0\nWHERE t0.\"Name\" IS NOT NULL AND t0.\"Name\" LIKE ? ESCAPE '\\'\nLIMIT -1 OFFSET 5;",
+			ExpectedArguments: []any{"%cake"},
+		},
+	) {
+		return
+	}
+
+	testCaseIndex++
+	if !yield(
+		&selectData{
+			TestData: internal.TestData{
+				TestTitle: "Offset without limit in PostgreSQL",
+			},
+			Select: NewSelect(DialectPostgreSQL),
+			MetadataModel: builder.Must(
+				builder.NewModel("Product").Table("Product", 0).Property(core.DatabaseOffset, 5).
+					Field("Name", core.FieldTypeText).
+					Build(),
+			),
+			Expected:          "SELECT t0.\"Name\"\nFROM \"Product\" AS t0\nOFFSET 5;",
+			ExpectedArguments: []any{},
+		},
+	) {
+		return
+	}
+
+	testCaseIndex++
+	if !yield(
+		&selectData{
+			TestData: internal.TestData{
+				TestTitle: "Self-referencing table joined to root table",
+			},
+			Select:        NewSelect(DialectPostgreSQL).WithTableCollectionUID("Reports"),
+			MetadataModel: selfReferencingMetadataModel(),
+			QueryCondition: gojsoncore.JsonObject{
+				filter.QueryConditionType: filter.QuerySectionTypeFieldGroup,
+				filter.QueryConditionValue: gojsoncore.JsonObject{
+					"$.GroupFields[*].Name": gojsoncore.JsonObject{
+						filter.FilterConditionEqualTo: gojsoncore.JsonObject{
+							filter.FilterConditionAssumedFieldType: core.FieldTypeText,
+							filter.FilterConditionValue:            "Ann",
+						},
+					},
+					"$.GroupFields[*].Reports.GroupFields[*].Title": gojsoncore.JsonObject{
+						filter.FilterConditionEqualTo: gojsoncore.JsonObject{
+							filter.FilterConditionAssumedFieldType: core.FieldTypeText,
+							filter.FilterConditionValue:            "Lead",
+						},
+					},
+				},
+			},
+			Expected:          "SELECT t1.\"ID\", t1.\"Title\", t1.\"Employee_ID\"\nFROM \"Employee\" AS t1\nINNER JOIN \"Employee\" AS t0 ON t1.\"Employee_ID\" = t0.\"ID\"\nWHERE (t0.\"Name\" IS NOT NULL AND t0.\"Name\" = $1) AND (EXISTS (SELECT 1 FROM \"Employee\" AS s1 WHERE s1.\"Employee_ID\" = t0.\"ID\" AND s1.\"Title\" IS NOT NULL AND s1.\"Title\" = $2));",
+			ExpectedArguments: []any{"Ann", "Lead"},
+		},
+	) {
+		return
+	}
+
+	testCaseIndex++
+	if !yield(
+		&selectData{
+			TestData: internal.TestData{
+				TestTitle: "Table not found",
+			},
+			Select:        NewSelect(DialectSQLite).WithTableCollectionUID("Department"),
+			MetadataModel: testdata.EmployeeMetadataModel(nil),
+			ExpectedErr:   ErrTableCollectionNotFound,
+		},
+	) {
+		return
+	}
+}
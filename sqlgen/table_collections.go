@@ -0,0 +1,127 @@
+package sqlgen
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	gojsoncore "github.com/rogonion/go-json/core"
+	"github.com/rogonion/go-json/path"
+	"github.com/rogonion/go-metadatamodel/core"
+	"github.com/rogonion/go-metadatamodel/database"
+)
+
+// tableCollection is a group in a metadata model that starts a new table/collection.
+type tableCollection struct {
+	// Position of the table/collection in read order. Used for aliases.
+	index int
+
+	uid       string
+	name      string
+	joinDepth int64
+
+	// core.FieldGroupJsonPathKey of the group.
+	fieldGroupJsonPathKey path.JSONPath
+
+	// Group that starts the table/collection.
+	group gojsoncore.JsonObject
+
+	// Table/collection of the nearest parent group with a different table/collection. nil for the root group.
+	parent *tableCollection
+
+	// Columns of the table/collection. Refer to database.GetColumnFields.
+	columnFields *database.ColumnFields
+}
+
+// primaryKeys returns core.DatabaseFieldColumnName of fields with core.FieldGroupIsPrimaryKey in read order.
+func (n *tableCollection) primaryKeys() []string {
+	primaryKeys := make([]string, 0)
+	for _, columnName := range n.columnFields.ColumnFieldsReadOrder {
+		if isPrimaryKey, ok := n.columnFields.Fields[columnName][core.FieldGroupIsPrimaryKey].(bool); ok && isPrimaryKey {
+			primaryKeys = append(primaryKeys, columnName)
+		}
+	}
+	return primaryKeys
+}
+
+// foreignKey is a column in a child table/collection that references a primary key column of its parent.
+type foreignKey struct {
+	columnName           string
+	referencedColumnName string
+}
+
+// foreignKeys returns the columns `<ParentTable>_<PrimaryKeyColumn>` that reference the primary key of n.parent. nil for the root table/collection.
+func (n *tableCollection) foreignKeys() ([]foreignKey, error) {
+	if n.parent == nil {
+		return nil, nil
+	}
+
+	parentPrimaryKeys := n.parent.primaryKeys()
+	if len(parentPrimaryKeys) == 0 {
+		return nil, fmt.Errorf("parent table '%s': %w", n.parent.name, ErrPrimaryKeyMissing)
+	}
+
+	foreignKeys := make([]foreignKey, 0, len(parentPrimaryKeys))
+	for _, parentColumnName := range parentPrimaryKeys {
+		foreignKeys = append(foreignKeys, foreignKey{columnName: n.parent.name + "_" + parentColumnName, referencedColumnName: parentColumnName})
+	}
+	return foreignKeys, nil
+}
+
+// alias returns the alias of the table/collection in a statement e.g. `t1` for prefix `t`.
+func (n *tableCollection) alias(prefix string) string {
+	return prefix + strconv.Itoa(n.index)
+}
+
+/*
+joinCondition returns the condition that joins n to n.parent through n.foreignKeys.
+
+qualifier and parentQualifier are the names or aliases that n and n.parent are referenced by in the statement.
+
+Example: `"Profile"."Employee_ID" = "Employee"."ID"`.
+*/
+func (n *tableCollection) joinCondition(dialect Dialect, qualifier string, parentQualifier string) (string, error) {
+	foreignKeys, err := n.foreignKeys()
+	if err != nil {
+		return "", err
+	}
+
+	conditions := make([]string, 0, len(foreignKeys))
+	for _, foreignKey := range foreignKeys {
+		conditions = append(conditions, qualifiedColumn(dialect, qualifier, foreignKey.columnName)+" = "+qualifiedColumn(dialect, parentQualifier, foreignKey.referencedColumnName))
+	}
+	return strings.Join(conditions, " AND "), nil
+}
+
+// qualifiedColumn returns columnName qualified by the name or alias of its table/collection e.g. `"User"."Name"`.
+func qualifiedColumn(dialect Dialect, qualifier string, columnName string) string {
+	return qualifier + "." + dialect.QuoteIdentifier(columnName)
+}
+
+/*
+getTableCollections returns every table/collection in metadataModel in read order, parents before children. Refer to database.GetTableCollections.
+*/
+func getTableCollections(metadataModel any) ([]*tableCollection, error) {
+	databaseTableCollections, err := database.NewGetTableCollections().Get(metadataModel)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrTableCollectionInvalid, err)
+	}
+
+	tableCollections := make([]*tableCollection, 0, len(databaseTableCollections))
+	tableCollectionsByDatabase := make(map[*database.TableCollection]*tableCollection)
+	for index, databaseTableCollection := range databaseTableCollections {
+		current := &tableCollection{
+			index:                 index,
+			uid:                   databaseTableCollection.UID,
+			name:                  databaseTableCollection.Name,
+			joinDepth:             databaseTableCollection.JoinDepth,
+			fieldGroupJsonPathKey: databaseTableCollection.FieldGroupJsonPathKey,
+			group:                 databaseTableCollection.Group,
+			parent:                tableCollectionsByDatabase[databaseTableCollection.Parent],
+			columnFields:          databaseTableCollection.ColumnFields,
+		}
+		tableCollectionsByDatabase[databaseTableCollection] = current
+		tableCollections = append(tableCollections, current)
+	}
+	return tableCollections, nil
+}
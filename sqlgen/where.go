@@ -0,0 +1,429 @@
+package sqlgen
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	gojsoncore "github.com/rogonion/go-json/core"
+	"github.com/rogonion/go-json/path"
+	"github.com/rogonion/go-json/schema"
+	"github.com/rogonion/go-metadatamodel/core"
+	"github.com/rogonion/go-metadatamodel/filter"
+)
+
+/*
+Where translates a query condition consumed by filter.DataFilter into a parameterized SQL `WHERE` condition against the root table/collection of a metadata model.
+
+The condition is true for a row if filter.DataFilter would keep the record the row belongs to:
+  - Columns are resolved through core.DatabaseFieldColumnName using database.GetColumnFields.
+  - Fields in child tables, for example groups at core.DatabaseJoinDepth > 0, are matched using correlated `EXISTS` subqueries joined through the foreign keys generated by DDL. A condition is true if any row in the child table matches. Child tables in subqueries are referenced by aliases like `s1` so that self-referencing tables stay correlated to the outer row.
+  - filter.FilterConditionNoOfEntriesGreaterThan, filter.FilterConditionNoOfEntriesLessThan, and filter.FilterConditionNoOfEntriesEqualTo on a group with its own table count the rows in the child table. Like filter.IsNumberOfEntriesConditionTrue, filter.FilterConditionNoOfEntriesGreaterThan is true if filter.FilterConditionValue is greater than the number of entries.
+  - Timestamp conditions are compared to the range of time covered by filter.FilterConditionDateTimeFormat e.g. the whole day for core.FieldDatetimeFormatYYYYMMDD.
+
+filter.FilterConditionFullTextSearchQuery, as well as the core.FieldDatetimeFormatHHMM and core.FieldDatetimeFormatMM date time formats, are not supported.
+*/
+type Where struct {
+	dialect Dialect
+
+	// Number of arguments that come before the arguments of the condition. Used to number DialectPostgreSQL placeholders.
+	argumentsOffset int
+}
+
+// WithArgumentsOffset sets the number of arguments that come before the arguments of the condition.
+func (n *Where) WithArgumentsOffset(value int) *Where {
+	n.SetArgumentsOffset(value)
+	return n
+}
+
+// SetArgumentsOffset sets the number of arguments that come before the arguments of the condition.
+func (n *Where) SetArgumentsOffset(value int) {
+	n.argumentsOffset = value
+}
+
+/*
+Get returns the `WHERE` condition, without the `WHERE` keyword, for queryCondition.
+
+Returns:
+ 1. The condition. Empty if queryCondition is empty.
+ 2. Arguments for the placeholders in the condition in order.
+ 3. An error if queryCondition is not valid or cannot be translated.
+*/
+func (n *Where) Get(metadataModel any, queryCondition gojsoncore.JsonObject) (string, []any, error) {
+	const FunctionName = "Get"
+
+	if !n.dialect.isValid() {
+		return "", nil, NewError().WithFunctionName(FunctionName).WithMessage(fmt.Sprintf("dialect '%s'", n.dialect)).WithNestedError(ErrDialectUnsupported)
+	}
+
+	tableCollections, err := getTableCollections(metadataModel)
+	if err != nil {
+		return "", nil, NewError().WithFunctionName(FunctionName).WithMessage("get table collections failed").WithNestedError(err)
+	}
+
+	condition, arguments, err := n.condition(tableCollections, queryCondition, n.dialect.QuoteIdentifier(tableCollections[0].name))
+	if err != nil {
+		return "", nil, NewError().WithFunctionName(FunctionName).WithMessage("translate query condition failed").WithNestedError(err)
+	}
+	return condition, arguments, nil
+}
+
+// condition translates queryCondition against the root of tableCollections which is referenced by rootQualifier. Refer to Where.Get.
+func (n *Where) condition(tableCollections []*tableCollection, queryCondition gojsoncore.JsonObject, rootQualifier string) (string, []any, error) {
+	if len(queryCondition) == 0 {
+		return "", make([]any, 0), nil
+	}
+
+	w := &whereBuilder{
+		dialect:          n.dialect,
+		rootQualifier:    rootQualifier,
+		argumentsOffset:  n.argumentsOffset,
+		tableCollections: tableCollections,
+		columns:          make(map[path.JSONPath]whereColumn),
+		arguments:        make([]any, 0),
+	}
+	for _, tableCollection := range tableCollections {
+		for columnName, field := range tableCollection.columnFields.Fields {
+			if fieldGroupJsonPathKey, err := core.AsJSONPath(field[core.FieldGroupJsonPathKey]); err == nil {
+				w.columns[fieldGroupJsonPathKey] = whereColumn{tableCollection: tableCollection, columnName: columnName, field: field}
+			}
+		}
+	}
+
+	condition, err := w.queryCondition(queryCondition)
+	if err != nil {
+		return "", nil, err
+	}
+	return condition, w.arguments, nil
+}
+
+// whereColumn is the column of a field in a table/collection.
+type whereColumn struct {
+	tableCollection *tableCollection
+	columnName      string
+	field           gojsoncore.JsonObject
+}
+
+// whereBuilder holds the state of a single Where.Get call.
+type whereBuilder struct {
+	dialect Dialect
+	// Name or alias of the root table/collection in the outer statement.
+	rootQualifier    string
+	argumentsOffset  int
+	tableCollections []*tableCollection
+	columns          map[path.JSONPath]whereColumn
+	arguments        []any
+}
+
+func (n *whereBuilder) queryCondition(queryCondition gojsoncore.JsonObject) (string, error) {
+	negate, _ := queryCondition[filter.QueryConditionNegate].(bool)
+
+	logicalOperator, err := filter.GetQuerySectionTypeLogicalOperator(queryCondition)
+	if err != nil {
+		return "", fmt.Errorf("%v: %w", err, ErrQueryConditionInvalid)
+	}
+
+	conditions := make([]string, 0)
+	switch queryCondition[filter.QueryConditionType] {
+	case filter.QuerySectionTypeLogicalOperator:
+		value, err := core.AsJsonArray(queryCondition[filter.QueryConditionValue])
+		if err != nil {
+			return "", fmt.Errorf("key '%s' of '%s' query condition is not a JsonArray: %w", filter.QueryConditionValue, filter.QuerySectionTypeLogicalOperator, ErrQueryConditionInvalid)
+		}
+		for _, v := range value {
+			childQueryCondition, err := core.AsJsonObject(v)
+			if err != nil {
+				return "", fmt.Errorf("query condition is not a JsonObject: %w", ErrQueryConditionInvalid)
+			}
+			condition, err := n.queryCondition(childQueryCondition)
+			if err != nil {
+				return "", err
+			}
+			conditions = append(conditions, condition)
+		}
+	case filter.QuerySectionTypeFieldGroup:
+		value, err := core.AsJsonObject(queryCondition[filter.QueryConditionValue])
+		if err != nil {
+			return "", fmt.Errorf("key '%s' of '%s' query condition is not a JsonObject: %w", filter.QueryConditionValue, filter.QuerySectionTypeFieldGroup, ErrQueryConditionInvalid)
+		}
+		for _, jsonPathKey := range sortedKeys(value) {
+			filterConditions, err := core.AsJsonObject(value[jsonPathKey])
+			if err != nil {
+				return "", fmt.Errorf("filter conditions of '%s' are not a JsonObject: %w", jsonPathKey, ErrQueryConditionInvalid)
+			}
+			condition, err := n.fieldGroupCondition(path.JSONPath(jsonPathKey), filterConditions)
+			if err != nil {
+				return "", err
+			}
+			conditions = append(conditions, condition)
+		}
+	default:
+		return "", fmt.Errorf("unknown query condition type '%v': %w", queryCondition[filter.QueryConditionType], ErrQueryConditionInvalid)
+	}
+
+	var condition string
+	switch {
+	case len(conditions) == 0 && logicalOperator == filter.QuerySectionTypeLogicalOperatorOr:
+		condition = "1 = 0"
+	case len(conditions) == 0:
+		condition = "1 = 1"
+	case len(conditions) == 1:
+		condition = conditions[0]
+	default:
+		condition = "(" + strings.Join(conditions, ") "+strings.ToUpper(logicalOperator)+" (") + ")"
+	}
+
+	if negate {
+		return "NOT (" + condition + ")", nil
+	}
+	return condition, nil
+}
+
+// fieldGroupCondition returns the condition for the filter conditions of a field/group, nested in `EXISTS` subqueries if the field/group is not in the root table/collection.
+func (n *whereBuilder) fieldGroupCondition(fieldGroupJsonPathKey path.JSONPath, filterConditions gojsoncore.JsonObject) (string, error) {
+	if len(filterConditions) == 0 {
+		return "", fmt.Errorf("filter conditions of '%s' are empty: %w", fieldGroupJsonPathKey, ErrQueryConditionInvalid)
+	}
+
+	var conditionTableCollection *tableCollection
+	var countTableCollection *tableCollection
+	column, isColumn := n.columns[fieldGroupJsonPathKey]
+	if isColumn {
+		conditionTableCollection = column.tableCollection
+	} else {
+		for _, tableCollection := range n.tableCollections[1:] {
+			if tableCollection.fieldGroupJsonPathKey == fieldGroupJsonPathKey {
+				countTableCollection = tableCollection
+				conditionTableCollection = tableCollection.parent
+				break
+			}
+		}
+		if countTableCollection == nil {
+			return "", fmt.Errorf("'%s' is not a column or a table: %w", fieldGroupJsonPathKey, ErrFieldGroupNotFound)
+		}
+	}
+
+	conditions := make([]string, 0)
+	for _, filterConditionKey := range sortedKeys(filterConditions) {
+		filterValue, err := core.AsJsonObject(filterConditions[filterConditionKey])
+		if err != nil {
+			return "", fmt.Errorf("filter condition '%s' of '%s' is not a JsonObject: %w", filterConditionKey, fieldGroupJsonPathKey, ErrQueryConditionInvalid)
+		}
+
+		var condition string
+		switch filterConditionKey {
+		case filter.FilterConditionNoOfEntriesGreaterThan, filter.FilterConditionNoOfEntriesLessThan, filter.FilterConditionNoOfEntriesEqualTo:
+			var noOfEntries string
+			if isColumn {
+				noOfEntries = "(CASE WHEN " + qualifiedColumn(n.dialect, n.qualifier(column.tableCollection), column.columnName) + " IS NULL THEN 0 ELSE 1 END)"
+			} else {
+				joinCondition, err := countTableCollection.joinCondition(n.dialect, n.qualifier(countTableCollection), n.qualifier(countTableCollection.parent))
+				if err != nil {
+					return "", err
+				}
+				noOfEntries = "(SELECT COUNT(*) FROM " + n.from(countTableCollection) + " WHERE " + joinCondition + ")"
+			}
+			condition, err = n.noOfEntriesCondition(noOfEntries, filterConditionKey, filterValue)
+		case filter.FilterConditionFullTextSearchQuery:
+			err = fmt.Errorf("filter condition '%s': %w", filterConditionKey, ErrFilterConditionUnsupported)
+		default:
+			if !isColumn {
+				err = fmt.Errorf("filter condition '%s' on table '%s': %w", filterConditionKey, countTableCollection.name, ErrFilterConditionUnsupported)
+				break
+			}
+			condition, err = n.valueCondition(qualifiedColumn(n.dialect, n.qualifier(column.tableCollection), column.columnName), filterConditionKey, filterValue)
+		}
+		if err != nil {
+			return "", fmt.Errorf("'%s': %w", fieldGroupJsonPathKey, err)
+		}
+		conditions = append(conditions, condition)
+	}
+
+	condition := strings.Join(conditions, " AND ")
+	for tableCollection := conditionTableCollection; tableCollection.parent != nil; tableCollection = tableCollection.parent {
+		joinCondition, err := tableCollection.joinCondition(n.dialect, n.qualifier(tableCollection), n.qualifier(tableCollection.parent))
+		if err != nil {
+			return "", err
+		}
+		condition = "EXISTS (SELECT 1 FROM " + n.from(tableCollection) + " WHERE " + joinCondition + " AND " + condition + ")"
+	}
+	return condition, nil
+}
+
+/*
+qualifier returns how columns of tableCollection are qualified in the condition.
+
+The root table/collection is referenced by rootQualifier. Other tables/collections are only used in subqueries where they are referenced by an alias like `s1` so that they are not confused with the root table/collection of the outer statement, for example in a self-referencing table.
+*/
+func (n *whereBuilder) qualifier(tableCollection *tableCollection) string {
+	if tableCollection.parent == nil {
+		return n.rootQualifier
+	}
+	return tableCollection.alias("s")
+}
+
+// from returns the aliased table of tableCollection for a subquery e.g. `"Profile" AS s1`.
+func (n *whereBuilder) from(tableCollection *tableCollection) string {
+	return n.dialect.QuoteIdentifier(tableCollection.name) + " AS " + n.qualifier(tableCollection)
+}
+
+func (n *whereBuilder) noOfEntriesCondition(noOfEntries string, filterConditionKey string, filterValue gojsoncore.JsonObject) (string, error) {
+	values, err := filter.ConditionValues(filterValue)
+	if err != nil {
+		return "", err
+	}
+
+	conditions := make([]string, 0, len(values))
+	for _, value := range values {
+		var valueInt int
+		if err := schema.NewConversion().Convert(value, &schema.DynamicSchemaNode{Type: reflect.TypeOf(0), Kind: reflect.Int}, &valueInt); err != nil {
+			return "", fmt.Errorf("convert '%v' to int failed: %w", value, ErrQueryConditionInvalid)
+		}
+
+		switch filterConditionKey {
+		case filter.FilterConditionNoOfEntriesEqualTo:
+			conditions = append(conditions, noOfEntries+" = "+n.argument(valueInt))
+		case filter.FilterConditionNoOfEntriesGreaterThan:
+			conditions = append(conditions, noOfEntries+" < "+n.argument(valueInt))
+		case filter.FilterConditionNoOfEntriesLessThan:
+			conditions = append(conditions, noOfEntries+" > "+n.argument(valueInt))
+		}
+	}
+	return orConditions(conditions), nil
+}
+
+// valueCondition returns the condition for a column that is true if the column is not NULL and matches any of the filter condition values.
+func (n *whereBuilder) valueCondition(column string, filterConditionKey string, filterValue gojsoncore.JsonObject) (string, error) {
+	assumedFieldType, ok := filterValue[filter.FilterConditionAssumedFieldType].(string)
+	if !ok {
+		return "", fmt.Errorf("filter condition property '%s' not found or not a string: %w", filter.FilterConditionAssumedFieldType, ErrQueryConditionInvalid)
+	}
+
+	values, err := filter.ConditionValues(filterValue)
+	if err != nil {
+		return "", err
+	}
+
+	conditions := make([]string, 0, len(values))
+	switch assumedFieldType {
+	case core.FieldTypeText:
+		caseInsensitive, _ := filterValue[filter.FilterConditionCaseInsensitive].(bool)
+		textColumn := column
+		if caseInsensitive {
+			textColumn = "LOWER(" + column + ")"
+		}
+		for _, value := range values {
+			valueString, ok := value.(string)
+			if !ok {
+				return "", fmt.Errorf("filter condition value '%v' is not a string: %w", value, ErrQueryConditionInvalid)
+			}
+			if caseInsensitive {
+				valueString = strings.ToLower(valueString)
+			}
+
+			switch filterConditionKey {
+			case filter.FilterConditionEqualTo:
+				conditions = append(conditions, textColumn+" = "+n.argument(valueString))
+			case filter.FilterConditionBeginsWith:
+				conditions = append(conditions, textColumn+" LIKE "+n.argument(escapeLike(valueString)+"%")+` ESCAPE '\'`)
+			case filter.FilterConditionEndsWith:
+				conditions = append(conditions, textColumn+" LIKE "+n.argument("%"+escapeLike(valueString))+` ESCAPE '\'`)
+			case filter.FilterConditionContains:
+				conditions = append(conditions, textColumn+" LIKE "+n.argument("%"+escapeLike(valueString)+"%")+` ESCAPE '\'`)
+			default:
+				return "", fmt.Errorf("filter condition '%s' for '%s': %w", filterConditionKey, assumedFieldType, ErrFilterConditionUnsupported)
+			}
+		}
+	case core.FieldTypeNumber:
+		for _, value := range values {
+			var valueFloat float64
+			if err := schema.NewConversion().Convert(value, &schema.DynamicSchemaNode{Type: reflect.TypeOf(0.0), Kind: reflect.Float64}, &valueFloat); err != nil {
+				return "", fmt.Errorf("convert '%v' to float64 failed: %w", value, ErrQueryConditionInvalid)
+			}
+
+			switch filterConditionKey {
+			case filter.FilterConditionEqualTo:
+				conditions = append(conditions, column+" = "+n.argument(valueFloat))
+			case filter.FilterConditionGreaterThan:
+				conditions = append(conditions, column+" > "+n.argument(valueFloat))
+			case filter.FilterConditionLessThan:
+				conditions = append(conditions, column+" < "+n.argument(valueFloat))
+			default:
+				return "", fmt.Errorf("filter condition '%s' for '%s': %w", filterConditionKey, assumedFieldType, ErrFilterConditionUnsupported)
+			}
+		}
+	case core.FieldTypeTimestamp:
+		dateTimeFormat, ok := filterValue[filter.FilterConditionDateTimeFormat].(string)
+		if !ok {
+			return "", fmt.Errorf("filter condition property '%s' not found or not a string: %w", filter.FilterConditionDateTimeFormat, ErrQueryConditionInvalid)
+		}
+		for _, value := range values {
+			start, end, err := filter.TimestampPeriod(value, dateTimeFormat)
+			if err != nil {
+				if errors.Is(err, filter.ErrUnsupportedFilterConditionType) {
+					return "", fmt.Errorf("%v: %w", err, ErrFilterConditionUnsupported)
+				}
+				return "", fmt.Errorf("%v: %w", err, ErrQueryConditionInvalid)
+			}
+
+			switch filterConditionKey {
+			case filter.FilterConditionEqualTo:
+				conditions = append(conditions, "("+column+" >= "+n.argument(start)+" AND "+column+" < "+n.argument(end)+")")
+			case filter.FilterConditionGreaterThan:
+				conditions = append(conditions, column+" >= "+n.argument(end))
+			case filter.FilterConditionLessThan:
+				conditions = append(conditions, column+" < "+n.argument(start))
+			default:
+				return "", fmt.Errorf("filter condition '%s' for '%s': %w", filterConditionKey, assumedFieldType, ErrFilterConditionUnsupported)
+			}
+		}
+	default:
+		if filterConditionKey != filter.FilterConditionEqualTo {
+			return "", fmt.Errorf("filter condition '%s' for '%s': %w", filterConditionKey, assumedFieldType, ErrFilterConditionUnsupported)
+		}
+		for _, value := range values {
+			conditions = append(conditions, column+" = "+n.argument(value))
+		}
+	}
+
+	return column + " IS NOT NULL AND " + orConditions(conditions), nil
+}
+
+// argument adds value to whereBuilder.arguments and returns its placeholder.
+func (n *whereBuilder) argument(value any) string {
+	n.arguments = append(n.arguments, value)
+	return n.dialect.Placeholder(n.argumentsOffset + len(n.arguments))
+}
+
+// escapeLike escapes `\`, `%`, and `_` in value for use in a `LIKE` pattern with `ESCAPE '\'`.
+func escapeLike(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(value)
+}
+
+// orConditions joins conditions using `OR`.
+func orConditions(conditions []string) string {
+	if len(conditions) == 1 {
+		return conditions[0]
+	}
+	return "(" + strings.Join(conditions, " OR ") + ")"
+}
+
+// sortedKeys returns the keys of value in ascending order so that generated statements are deterministic.
+func sortedKeys(value gojsoncore.JsonObject) []string {
+	keys := make([]string, 0, len(value))
+	for key := range value {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+// NewWhere creates a new Where for dialect.
+func NewWhere(dialect Dialect) *Where {
+	n := &Where{
+		dialect: dialect,
+	}
+	return n
+}
@@ -0,0 +1,357 @@
+package sqlgen
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	gojsoncore "github.com/rogonion/go-json/core"
+	"github.com/rogonion/go-metadatamodel/builder"
+	"github.com/rogonion/go-metadatamodel/core"
+	"github.com/rogonion/go-metadatamodel/filter"
+	"github.com/rogonion/go-metadatamodel/internal"
+	"github.com/rogonion/go-metadatamodel/testdata"
+)
+
+func TestSqlGen_Where(t *testing.T) {
+	for testData := range whereTestData {
+		condition, arguments, err := NewWhere(testData.Dialect).WithArgumentsOffset(testData.ArgumentsOffset).Get(testData.MetadataModel, testData.QueryCondition)
+		if testData.ExpectedErr != nil {
+			if !errors.Is(err, testData.ExpectedErr) {
+				t.Error(testData.TestTitle, "\n", "expected error", testData.ExpectedErr, "got", err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Error(testData.TestTitle, "\n", "Get failed:", err)
+			continue
+		}
+
+		if condition != testData.Expected {
+			t.Error(
+				testData.TestTitle, "\n",
+				"expected condition to be equal to testData.Expected\n",
+				"Expected=", testData.Expected, "\n",
+				"condition=", condition,
+			)
+		}
+		if !reflect.DeepEqual(arguments, testData.ExpectedArguments) {
+			t.Error(
+				testData.TestTitle, "\n",
+				"expected arguments to be equal to testData.ExpectedArguments\n",
+				"Expected=", testData.ExpectedArguments, "\n",
+				"arguments=", arguments,
+			)
+		}
+	}
+}
+
+type whereData struct {
+	internal.TestData
+	Dialect           Dialect
+	ArgumentsOffset   int
+	MetadataModel     any
+	QueryCondition    gojsoncore.JsonObject
+	Expected          string
+	ExpectedArguments []any
+	ExpectedErr       error
+}
+
+func whereTestData(yield func(data *whereData) bool) {
+	eventMetadataModel := builder.Must(
+		builder.NewModel("Event").Table("Event", 0).
+			Field("ID", core.FieldTypeNumber).PrimaryKey().
+			Field("Starts", core.FieldTypeTimestamp).Column("starts_at").
+			Field("Public", core.FieldTypeBoolean).
+			Build(),
+	)
+
+	testCaseIndex := 1
+	if !yield(
+		&whereData{
+			TestData: internal.TestData{
+				TestTitle: "Employee with conditions on nested tables in PostgreSQL",
+			},
+			Dialect:       DialectPostgreSQL,
+			MetadataModel: testdata.EmployeeMetadataModel(nil),
+			QueryCondition: gojsoncore.JsonObject{
+				filter.QueryConditionType:              filter.QuerySectionTypeLogicalOperator,
+				filter.QuerySectionTypeLogicalOperator: filter.QuerySectionTypeLogicalOperatorOr,
+				filter.QueryConditionValue: gojsoncore.JsonArray{
+					gojsoncore.JsonObject{
+						filter.QueryConditionType: filter.QuerySectionTypeFieldGroup,
+						filter.QueryConditionValue: gojsoncore.JsonObject{
+							"$.GroupFields[*].ID": gojsoncore.JsonObject{
+								filter.FilterConditionGreaterThan: gojsoncore.JsonObject{
+									filter.FilterConditionAssumedFieldType: core.FieldTypeNumber,
+									filter.FilterConditionValue:            3,
+								},
+							},
+						},
+					},
+					gojsoncore.JsonObject{
+						filter.QueryConditionType:   filter.QuerySectionTypeFieldGroup,
+						filter.QueryConditionNegate: true,
+						filter.QueryConditionValue: gojsoncore.JsonObject{
+							"$.GroupFields[*].Profile": gojsoncore.JsonObject{
+								filter.FilterConditionNoOfEntriesEqualTo: gojsoncore.JsonObject{
+									filter.FilterConditionValue: 2,
+								},
+							},
+							"$.GroupFields[*].Profile.GroupFields[*].Address.GroupFields[*].City": gojsoncore.JsonObject{
+								filter.FilterConditionBeginsWith: gojsoncore.JsonObject{
+									filter.FilterConditionAssumedFieldType: core.FieldTypeText,
+									filter.FilterConditionValue:            "Nai_",
+								},
+							},
+						},
+					},
+				},
+			},
+			Expected:          `("Employee"."ID" IS NOT NULL AND "Employee"."ID" > $1) OR (NOT (((SELECT COUNT(*) FROM "Profile" AS s1 WHERE s1."Employee_ID" = "Employee"."ID") = $2) AND (EXISTS (SELECT 1 FROM "Profile" AS s1 WHERE s1."Employee_ID" = "Employee"."ID" AND EXISTS (SELECT 1 FROM "UserProfile" AS s2 WHERE s2."Profile_Name" = s1."Name" AND s2."City" IS NOT NULL AND s2."City" LIKE $3 ESCAPE '\')))))`,
+			ExpectedArguments: []any{3.0, 2, `Nai\_%`},
+		},
+	) {
+		return
+	}
+
+	testCaseIndex++
+	if !yield(
+		&whereData{
+			TestData: internal.TestData{
+				TestTitle: "Self-referencing table in PostgreSQL",
+			},
+			Dialect:       DialectPostgreSQL,
+			MetadataModel: selfReferencingMetadataModel(),
+			QueryCondition: gojsoncore.JsonObject{
+				filter.QueryConditionType: filter.QuerySectionTypeFieldGroup,
+				filter.QueryConditionValue: gojsoncore.JsonObject{
+					"$.GroupFields[*].Reports.GroupFields[*].Title": gojsoncore.JsonObject{
+						filter.FilterConditionEqualTo: gojsoncore.JsonObject{
+							filter.FilterConditionAssumedFieldType: core.FieldTypeText,
+							filter.FilterConditionValue:            "Lead",
+						},
+					},
+				},
+			},
+			Expected:          `EXISTS (SELECT 1 FROM "Employee" AS s1 WHERE s1."Employee_ID" = "Employee"."ID" AND s1."Title" IS NOT NULL AND s1."Title" = $1)`,
+			ExpectedArguments: []any{"Lead"},
+		},
+	) {
+		return
+	}
+
+	testCaseIndex++
+	if !yield(
+		&whereData{
+			TestData: internal.TestData{
+				TestTitle: "Product text and number conditions in SQLite",
+			},
+			Dialect:       DialectSQLite,
+			MetadataModel: testdata.ProductMetadataModel(nil),
+			QueryCondition: gojsoncore.JsonObject{
+				filter.QueryConditionType: filter.QuerySectionTypeFieldGroup,
+				filter.QueryConditionValue: gojsoncore.JsonObject{
+					"$.GroupFields[*].Name": gojsoncore.JsonObject{
+						filter.FilterConditionContains: gojsoncore.JsonObject{
+							filter.FilterConditionAssumedFieldType: core.FieldTypeText,
+							filter.FilterConditionCaseInsensitive:  true,
+							filter.FilterConditionValues:           gojsoncore.JsonArray{"Cake", "100%"},
+						},
+					},
+					"$.GroupFields[*].Price": gojsoncore.JsonObject{
+						filter.FilterConditionLessThan: gojsoncore.JsonObject{
+							filter.FilterConditionAssumedFieldType: core.FieldTypeNumber,
+							filter.FilterConditionValue:            "9.5",
+						},
+					},
+				},
+			},
+			Expected:          `("Product"."Name" IS NOT NULL AND (LOWER("Product"."Name") LIKE ? ESCAPE '\' OR LOWER("Product"."Name") LIKE ? ESCAPE '\')) AND ("Product"."Price" IS NOT NULL AND "Product"."Price" < ?)`,
+			ExpectedArguments: []any{"%cake%", `%100\%%`, 9.5},
+		},
+	) {
+		return
+	}
+
+	testCaseIndex++
+	if !yield(
+		&whereData{
+			TestData: internal.TestData{
+				TestTitle: "Timestamp and any conditions with arguments offset",
+			},
+			Dialect:         DialectPostgreSQL,
+			ArgumentsOffset: 2,
+			MetadataModel:   eventMetadataModel,
+			QueryCondition: gojsoncore.JsonObject{
+				filter.QueryConditionType: filter.QuerySectionTypeFieldGroup,
+				filter.QueryConditionValue: gojsoncore.JsonObject{
+					"$.GroupFields[*].Public": gojsoncore.JsonObject{
+						filter.FilterConditionEqualTo: gojsoncore.JsonObject{
+							filter.FilterConditionAssumedFieldType: core.FieldTypeAny,
+							filter.FilterConditionValue:            true,
+						},
+					},
+					"$.GroupFields[*].Starts": gojsoncore.JsonObject{
+						filter.FilterConditionEqualTo: gojsoncore.JsonObject{
+							filter.FilterConditionAssumedFieldType: core.FieldTypeTimestamp,
+							filter.FilterConditionDateTimeFormat:   core.FieldDatetimeFormatYYYYMM,
+							filter.FilterConditionValue:            "2024-02-14T10:30:00Z",
+						},
+						filter.FilterConditionGreaterThan: gojsoncore.JsonObject{
+							filter.FilterConditionAssumedFieldType: core.FieldTypeTimestamp,
+							filter.FilterConditionDateTimeFormat:   core.FieldDatetimeFormatYYYYMMDD,
+							filter.FilterConditionValue:            time.Date(2024, 2, 10, 8, 0, 0, 0, time.UTC),
+						},
+					},
+				},
+			},
+			Expected: `("Event"."Public" IS NOT NULL AND "Event"."Public" = $3) AND ("Event"."starts_at" IS NOT NULL AND ("Event"."starts_at" >= $4 AND "Event"."starts_at" < $5) AND "Event"."starts_at" IS NOT NULL AND "Event"."starts_at" >= $6)`,
+			ExpectedArguments: []any{
+				true,
+				time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC),
+				time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+				time.Date(2024, 2, 11, 0, 0, 0, 0, time.UTC),
+			},
+		},
+	) {
+		return
+	}
+
+	testCaseIndex++
+	if !yield(
+		&whereData{
+			TestData: internal.TestData{
+				TestTitle: "Empty logical operators",
+			},
+			Dialect:       DialectSQLite,
+			MetadataModel: eventMetadataModel,
+			QueryCondition: gojsoncore.JsonObject{
+				filter.QueryConditionType: filter.QuerySectionTypeLogicalOperator,
+				filter.QueryConditionValue: gojsoncore.JsonArray{
+					gojsoncore.JsonObject{
+						filter.QueryConditionType:              filter.QuerySectionTypeLogicalOperator,
+						filter.QuerySectionTypeLogicalOperator: filter.QuerySectionTypeLogicalOperatorOr,
+						filter.QueryConditionValue:             gojsoncore.JsonArray{},
+					},
+					gojsoncore.JsonObject{
+						filter.QueryConditionType:  filter.QuerySectionTypeLogicalOperator,
+						filter.QueryConditionValue: gojsoncore.JsonArray{},
+					},
+				},
+			},
+			Expected:          `(1 = 0) AND (1 = 1)`,
+			ExpectedArguments: []any{},
+		},
+	) {
+		return
+	}
+
+	testCaseIndex++
+	if !yield(
+		&whereData{
+			TestData: internal.TestData{
+				TestTitle: "Field not in metadata model",
+			},
+			Dialect:       DialectSQLite,
+			MetadataModel: eventMetadataModel,
+			QueryCondition: gojsoncore.JsonObject{
+				filter.QueryConditionType: filter.QuerySectionTypeFieldGroup,
+				filter.QueryConditionValue: gojsoncore.JsonObject{
+					"$.GroupFields[*].Venue": gojsoncore.JsonObject{
+						filter.FilterConditionEqualTo: gojsoncore.JsonObject{
+							filter.FilterConditionAssumedFieldType: core.FieldTypeText,
+							filter.FilterConditionValue:            "Hall",
+						},
+					},
+				},
+			},
+			ExpectedErr: ErrFieldGroupNotFound,
+		},
+	) {
+		return
+	}
+
+	testCaseIndex++
+	if !yield(
+		&whereData{
+			TestData: internal.TestData{
+				TestTitle: "Full text search not supported",
+			},
+			Dialect:       DialectSQLite,
+			MetadataModel: testdata.ProductMetadataModel(nil),
+			QueryCondition: gojsoncore.JsonObject{
+				filter.QueryConditionType: filter.QuerySectionTypeFieldGroup,
+				filter.QueryConditionValue: gojsoncore.JsonObject{
+					"$.GroupFields[*].Name": gojsoncore.JsonObject{
+						filter.FilterConditionFullTextSearchQuery: gojsoncore.JsonObject{
+							filter.FilterConditionAssumedFieldType: core.FieldTypeText,
+							filter.FilterConditionValue:            "cake",
+						},
+					},
+				},
+			},
+			ExpectedErr: ErrFilterConditionUnsupported,
+		},
+	) {
+		return
+	}
+
+	testCaseIndex++
+	if !yield(
+		&whereData{
+			TestData: internal.TestData{
+				TestTitle: "Time of day not supported",
+			},
+			Dialect:       DialectSQLite,
+			MetadataModel: eventMetadataModel,
+			QueryCondition: gojsoncore.JsonObject{
+				filter.QueryConditionType: filter.QuerySectionTypeFieldGroup,
+				filter.QueryConditionValue: gojsoncore.JsonObject{
+					"$.GroupFields[*].Starts": gojsoncore.JsonObject{
+						filter.FilterConditionLessThan: gojsoncore.JsonObject{
+							filter.FilterConditionAssumedFieldType: core.FieldTypeTimestamp,
+							filter.FilterConditionDateTimeFormat:   core.FieldDatetimeFormatHHMM,
+							filter.FilterConditionValue:            "2024-02-14T10:30:00Z",
+						},
+					},
+				},
+			},
+			ExpectedErr: ErrFilterConditionUnsupported,
+		},
+	) {
+		return
+	}
+
+	testCaseIndex++
+	if !yield(
+		&whereData{
+			TestData: internal.TestData{
+				TestTitle: "Unknown query condition type",
+			},
+			Dialect:       DialectPostgreSQL,
+			MetadataModel: eventMetadataModel,
+			QueryCondition: gojsoncore.JsonObject{
+				filter.QueryConditionType: "Field",
+			},
+			ExpectedErr: ErrQueryConditionInvalid,
+		},
+	) {
+		return
+	}
+}
+
+// selfReferencingMetadataModel returns an `Employee` table whose `Reports` group is also stored in the `Employee` table.
+func selfReferencingMetadataModel() gojsoncore.JsonObject {
+	return builder.Must(
+		builder.NewModel("Employee").Table("Employee", 0).
+			Field("ID", core.FieldTypeNumber).PrimaryKey().
+			Field("Name", core.FieldTypeText).
+			Group("Reports").Table("Employee", 1).TableUid("Reports").
+			Field("ID", core.FieldTypeNumber).PrimaryKey().
+			Field("Title", core.FieldTypeText).
+			End().
+			Build(),
+	)
+}
@@ -12,6 +12,15 @@ var (
 
 	// ErrNoGroupFields for when RecursiveGroupIndexTree.MmPropGroupFields is empty if field is a group.
 	ErrNoGroupFields = errors.New("no group fields to extract found")
+
+	// ErrHeaderInvalid for when a column in the header row of a CSV or spreadsheet does not match a field.
+	ErrHeaderInvalid = errors.New("header not valid")
+
+	// ErrCellValueInvalid for when a cell value cannot be parsed according to its field.
+	ErrCellValueInvalid = errors.New("cell value not valid")
+
+	// ErrDestinationInvalid for when the destination is not set or its source is not a linear collection.
+	ErrDestinationInvalid = errors.New("destination not valid")
 )
 
 // NewError creates a new core.Error with the default unflatten error base.
@@ -0,0 +1,331 @@
+package unflattener
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	gojsoncore "github.com/rogonion/go-json/core"
+	"github.com/rogonion/go-json/path"
+	"github.com/rogonion/go-json/schema"
+	"github.com/rogonion/go-metadatamodel/core"
+	"github.com/rogonion/go-metadatamodel/fieldcolumns"
+	"github.com/rogonion/go-metadatamodel/flattener"
+)
+
+/*
+CSVReader reads CSV records into flattener.FlattenedRow for Unflattener.Unflatten. It is the inverse of flattener.CSVWriter.
+
+  - The header row is matched against core.FieldGroupName of each fieldcolumns.ColumnField. Columns of the resulting rows are in fieldcolumns.ColumnFields.UnskippedReadOrderOfColumnFields order.
+  - Cells are split on core.FieldMultipleValuesJoinSymbol of the field or a comma by default using core.SplitFieldValues. Escaped join symbols are kept in the value, a lone backslash is a single empty value, and cells of fields with core.FieldGroupMaxEntries set to 1 are not split. Join symbols that contain a backslash are rejected with ErrCellValueInvalid. Empty cells are left out.
+  - Each value is parsed according to core.FieldDataType. core.FieldTypeTimestamp values are parsed using core.FieldDatetimeFormat and checkbox values using core.FieldCheckboxValueIfTrue and core.FieldCheckboxValueIfFalse if core.FieldCheckboxValuesUseInView is `true`.
+  - Parsed values are converted using fieldcolumns.ColumnField.Schema if set. Otherwise, they are returned as a slice of their type e.g. []float64 for core.FieldTypeNumber.
+*/
+type CSVReader struct {
+	columnFields *fieldcolumns.ColumnFields
+
+	reader *csv.Reader
+
+	converter *schema.Conversion
+
+	// columnIndexes index in fieldcolumns.ColumnFields.UnskippedReadOrderOfColumnFields of each column in the CSV.
+	columnIndexes []int
+
+	// recordIndex of the last record read including the header row.
+	recordIndex int
+}
+
+// WithComma sets the field delimiter. Defaults to ','.
+func (n *CSVReader) WithComma(value rune) *CSVReader {
+	n.SetComma(value)
+	return n
+}
+
+// SetComma sets the field delimiter. Defaults to ','.
+func (n *CSVReader) SetComma(value rune) {
+	n.reader.Comma = value
+}
+
+// ReadHeader reads the header row if it has not been read yet and matches each column to a fieldcolumns.ColumnField.
+//
+// Returns ErrHeaderInvalid if a column does not match the core.FieldGroupName of any column field.
+func (n *CSVReader) ReadHeader() error {
+	const FunctionName = "ReadHeader"
+
+	if n.columnIndexes != nil {
+		return nil
+	}
+
+	header, err := n.reader.Read()
+	if err != nil {
+		return NewError().WithFunctionName(FunctionName).WithMessage("read header failed").WithNestedError(err)
+	}
+	n.recordIndex = 0
+
+	columnIndexes, err := matchHeader(n.columnFields, header)
+	if err != nil {
+		return NewError().WithFunctionName(FunctionName).WithMessage("match header failed").WithNestedError(err)
+	}
+	n.columnIndexes = columnIndexes
+
+	return nil
+}
+
+// ReadRow reads the next record as a flattener.FlattenedRow. The header row is read first if it has not been read yet.
+//
+// Returns io.EOF if there are no more records and ErrCellValueInvalid if a cell could not be parsed.
+func (n *CSVReader) ReadRow() (flattener.FlattenedRow, error) {
+	const FunctionName = "ReadRow"
+
+	if err := n.ReadHeader(); err != nil {
+		return nil, err
+	}
+
+	record, err := n.reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, io.EOF
+		}
+		return nil, NewError().WithFunctionName(FunctionName).WithMessage("read row failed").WithNestedError(err)
+	}
+	n.recordIndex++
+
+	row := make(flattener.FlattenedRow, len(n.columnFields.UnskippedReadOrderOfColumnFields))
+	for csvColumnIndex, cell := range record {
+		if csvColumnIndex >= len(n.columnIndexes) {
+			break
+		}
+
+		columnField, ok := n.columnFields.GetColumnFieldByIndexInUnskippedReadOrder(n.columnIndexes[csvColumnIndex])
+		if !ok {
+			continue
+		}
+
+		value, err := parseCell(n.converter, columnField, cell)
+		if err != nil {
+			return nil, NewError().WithFunctionName(FunctionName).
+				WithMessage(fmt.Sprintf("row %d column '%s' value '%s'", n.recordIndex, core.GetFieldGroupName(columnField.Property, ""), cell)).
+				WithNestedError(err).
+				WithData(gojsoncore.JsonObject{"Row": n.recordIndex, "Column": csvColumnIndex + 1})
+		}
+		row[n.columnIndexes[csvColumnIndex]] = value
+	}
+
+	return row, nil
+}
+
+// ReadTable reads every remaining record. Refer to CSVReader.ReadRow.
+func (n *CSVReader) ReadTable() (flattener.FlattenedTable, error) {
+	table := make(flattener.FlattenedTable, 0)
+	for {
+		row, err := n.ReadRow()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return table, nil
+			}
+			return nil, err
+		}
+		table = append(table, row)
+	}
+}
+
+// NewCSVReader creates a new CSVReader that reads rows whose columns are described by columnFields from reader.
+func NewCSVReader(columnFields *fieldcolumns.ColumnFields, reader io.Reader) *CSVReader {
+	n := &CSVReader{
+		columnFields: columnFields,
+		reader:       csv.NewReader(reader),
+		converter:    newCellConverter(),
+	}
+	return n
+}
+
+// UnflattenCSV reads CSV records from reader using CSVReader then passes them to Unflattener.Unflatten.
+func (n *Unflattener) UnflattenCSV(reader io.Reader) error {
+	const FunctionName = "UnflattenCSV"
+
+	if err := n.initColumnFields(); err != nil {
+		return err
+	}
+
+	table, err := NewCSVReader(n.columnFields, reader).ReadTable()
+	if err != nil {
+		return NewError().WithFunctionName(FunctionName).WithMessage("read csv failed").WithNestedError(err)
+	}
+
+	return n.Unflatten(table)
+}
+
+// parseCell splits cell using core.SplitFieldValues and parses each value. Returns an invalid reflect.Value for empty cells.
+func parseCell(converter *schema.Conversion, columnField *fieldcolumns.ColumnField, cell string) (reflect.Value, error) {
+	if len(cell) == 0 {
+		return reflect.Value{}, nil
+	}
+
+	cellValues, err := core.SplitFieldValues(columnField.Property, cell)
+	if err != nil {
+		return reflect.Value{}, fmt.Errorf("%w: %w", err, ErrCellValueInvalid)
+	}
+
+	values := make([]any, 0, len(cellValues))
+	for _, cellValue := range cellValues {
+		value, err := parseCellValue(columnField.Property, cellValue)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		values = append(values, value)
+	}
+
+	return convertCellValues(converter, columnField, values)
+}
+
+// convertCellValues converts values parsed from a cell using fieldcolumns.ColumnField.Schema if set. Refer to typedCellValues.
+func convertCellValues(converter *schema.Conversion, columnField *fieldcolumns.ColumnField, values []any) (reflect.Value, error) {
+	if columnField.Schema == nil {
+		return typedCellValues(values), nil
+	}
+
+	result, err := converter.RecursiveConvert(reflect.ValueOf(values), columnField.Schema, path.RecursiveDescentSegment{{Key: path.JsonpathKeyRoot, IsKeyRoot: true}})
+	if err != nil {
+		return reflect.Value{}, fmt.Errorf("convert using schema failed: %w: %w", err, ErrCellValueInvalid)
+	}
+	return result, nil
+}
+
+// matchHeader returns the index in fieldcolumns.ColumnFields.UnskippedReadOrderOfColumnFields of the column field whose core.FieldGroupName matches each column in header. Column fields with the same name are matched in read order.
+func matchHeader(columnFields *fieldcolumns.ColumnFields, header []string) ([]int, error) {
+	matched := make(map[int]bool)
+	columnIndexes := make([]int, 0, len(header))
+	for headerColumnIndex, name := range header {
+		columnIndex := -1
+		for unskippedIndex := range columnFields.UnskippedReadOrderOfColumnFields {
+			if matched[unskippedIndex] {
+				continue
+			}
+			if columnField, ok := columnFields.GetColumnFieldByIndexInUnskippedReadOrder(unskippedIndex); ok && core.GetFieldGroupName(columnField.Property, "") == name {
+				columnIndex = unskippedIndex
+				break
+			}
+		}
+		if columnIndex < 0 {
+			return nil, fmt.Errorf("column %d '%s' does not match a field: %w", headerColumnIndex+1, name, ErrHeaderInvalid)
+		}
+		matched[columnIndex] = true
+		columnIndexes = append(columnIndexes, columnIndex)
+	}
+	return columnIndexes, nil
+}
+
+// newCellConverter returns the schema.Conversion used to convert values parsed from cells. Refer to timestampConverter.
+func newCellConverter() *schema.Conversion {
+	return schema.NewConversion().WithCustomConverters(schema.Converters{
+		reflect.TypeOf(time.Time{}): timestampConverter{},
+	})
+}
+
+// typedCellValues returns values as a slice of the type of its elements e.g. []float64 if they all have the same type. Otherwise, values is returned as is.
+func typedCellValues(values []any) reflect.Value {
+	var elementType reflect.Type
+	for _, value := range values {
+		if value == nil || (elementType != nil && reflect.TypeOf(value) != elementType) {
+			return reflect.ValueOf(values)
+		}
+		elementType = reflect.TypeOf(value)
+	}
+	if elementType == nil {
+		return reflect.ValueOf(values)
+	}
+
+	typedValues := reflect.MakeSlice(reflect.SliceOf(elementType), 0, len(values))
+	for _, value := range values {
+		typedValues = reflect.Append(typedValues, reflect.ValueOf(value))
+	}
+	return typedValues
+}
+
+// parseCellValue parses a single value in a cell according to the core.FieldDataType of field.
+func parseCellValue(field gojsoncore.JsonObject, value string) (any, error) {
+	fieldDataType, _ := field[core.FieldDataType].(string)
+
+	if fieldUi, _ := field[core.FieldUI].(string); fieldUi == core.FieldUiCheckbox || fieldDataType == core.FieldTypeBoolean {
+		checked, err := parseCheckboxValue(field, value)
+		if err != nil {
+			return nil, err
+		}
+		if useInStorage, ok := field[core.FieldCheckboxValuesUseInStorage].(bool); ok && useInStorage {
+			property := core.FieldCheckboxValueIfFalse
+			if checked {
+				property = core.FieldCheckboxValueIfTrue
+			}
+			if checkboxValue, err := core.AsJsonObject(field[property]); err == nil {
+				return checkboxValue[core.Value], nil
+			}
+		}
+		return checked, nil
+	}
+
+	switch fieldDataType {
+	case core.FieldTypeNumber:
+		number, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil {
+			return nil, fmt.Errorf("'%s' is not a number: %w", value, ErrCellValueInvalid)
+		}
+		return number, nil
+	case core.FieldTypeTimestamp:
+		layout := time.RFC3339
+		if fieldDatetimeFormat, ok := field[core.FieldDatetimeFormat].(string); ok {
+			if fieldDatetimeLayout, ok := core.FieldDatetimeFormatLayout(fieldDatetimeFormat); ok {
+				layout = fieldDatetimeLayout
+			}
+		}
+		timestamp, err := time.Parse(layout, strings.TrimSpace(value))
+		if err != nil {
+			return nil, fmt.Errorf("'%s' does not match the layout '%s': %w", value, layout, ErrCellValueInvalid)
+		}
+		return timestamp, nil
+	case core.FieldTypeAny:
+		var anyValue any
+		if err := json.Unmarshal([]byte(value), &anyValue); err == nil {
+			return anyValue, nil
+		}
+		return value, nil
+	default:
+		return value, nil
+	}
+}
+
+// parseCheckboxValue returns `true` or `false` if value matches core.FieldCheckboxValueIfTrue or core.FieldCheckboxValueIfFalse respectively and core.FieldCheckboxValuesUseInView is `true`. Otherwise, value is parsed using strconv.ParseBool.
+func parseCheckboxValue(field gojsoncore.JsonObject, value string) (bool, error) {
+	if useInView, ok := field[core.FieldCheckboxValuesUseInView].(bool); ok && useInView {
+		for _, checked := range []bool{true, false} {
+			property := core.FieldCheckboxValueIfFalse
+			if checked {
+				property = core.FieldCheckboxValueIfTrue
+			}
+			if checkboxValue, err := core.AsJsonObject(field[property]); err == nil && fmt.Sprint(checkboxValue[core.Value]) == value {
+				return checked, nil
+			}
+		}
+	}
+
+	checked, err := strconv.ParseBool(strings.TrimSpace(value))
+	if err != nil {
+		return false, fmt.Errorf("'%s' is not a checkbox value: %w", value, ErrCellValueInvalid)
+	}
+	return checked, nil
+}
+
+// timestampConverter converts time.Time values parsed from cells to strings in time.RFC3339 format when a fieldcolumns.ColumnField.Schema expects a string.
+type timestampConverter struct{}
+
+func (timestampConverter) Convert(data reflect.Value, sch schema.Schema, pathSegments path.RecursiveDescentSegment) (reflect.Value, error) {
+	if node, ok := sch.(*schema.DynamicSchemaNode); ok && node.Kind == reflect.String {
+		return reflect.ValueOf(data.Interface().(time.Time).Format(time.RFC3339)).Convert(node.Type), nil
+	}
+	return reflect.Value{}, fmt.Errorf("convert timestamp at '%v' failed: %w", pathSegments, schema.ErrDataConversionFailed)
+}
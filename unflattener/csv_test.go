@@ -0,0 +1,336 @@
+package unflattener
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/brunoga/deep"
+	gojsoncore "github.com/rogonion/go-json/core"
+	"github.com/rogonion/go-json/object"
+	"github.com/rogonion/go-json/path"
+	"github.com/rogonion/go-json/schema"
+	"github.com/rogonion/go-metadatamodel/core"
+	"github.com/rogonion/go-metadatamodel/fieldcolumns"
+	"github.com/rogonion/go-metadatamodel/flattener"
+	"github.com/rogonion/go-metadatamodel/testdata"
+)
+
+func TestUnflattener_UnflattenCSV(t *testing.T) {
+	for data := range unflattenCSVTestData {
+		t.Run(data.TestTitle, func(t *testing.T) {
+			destination := object.NewObject().WithSourceInterface(data.Destination)
+
+			u := NewUnflattener(data.MetadataModel, NewSignature()).WithDestination(destination)
+			if data.Schema != nil {
+				columnFields, err := fieldcolumns.NewColumnFieldsExtraction(data.MetadataModel).WithSchema(data.Schema).Extract()
+				if err != nil {
+					t.Fatalf("Extract() unexpected error: %v", err)
+				}
+				columnFields.Reposition()
+				columnFields.Skip(nil, nil)
+				u.WithColumnFields(columnFields)
+			}
+
+			err := u.UnflattenCSV(strings.NewReader(data.CSV))
+			if data.ExpectedErr != nil {
+				if !errors.Is(err, data.ExpectedErr) {
+					t.Fatalf("expected error %v, got %v", data.ExpectedErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("UnflattenCSV() unexpected error: %v", err)
+			}
+
+			actualResult := destination.GetSourceInterface()
+			if !reflect.DeepEqual(actualResult, data.ExpectedResult) {
+				t.Errorf("Result mismatch.\nExpected:\n%#v\nGot:\n%#v",
+					data.ExpectedResult,
+					actualResult,
+				)
+			}
+		})
+	}
+}
+
+func TestUnflattener_UnflattenCSV_RoundTrip(t *testing.T) {
+	for data := range roundTripCSVTestData {
+		t.Run(data.TestTitle, func(t *testing.T) {
+			f := flattener.NewFlattener(data.MetadataModel)
+			if err := f.Flatten(object.NewObject().WithSourceInterface(data.Source)); err != nil {
+				t.Fatalf("Flatten() unexpected error: %v", err)
+			}
+
+			var buffer bytes.Buffer
+			if err := f.WriteCSV(&buffer); data.ExpectedErr != nil {
+				if !errors.Is(err, data.ExpectedErr) {
+					t.Fatalf("expected error %v, got %v", data.ExpectedErr, err)
+				}
+				return
+			} else if err != nil {
+				t.Fatalf("WriteCSV() unexpected error: %v", err)
+			}
+
+			destination := object.NewObject().WithSourceInterface([]any{})
+			if err := NewUnflattener(data.MetadataModel, NewSignature()).WithDestination(destination).UnflattenCSV(&buffer); err != nil {
+				t.Fatalf("UnflattenCSV() unexpected error: %v", err)
+			}
+
+			actualResult := destination.GetSourceInterface()
+			if !reflect.DeepEqual(actualResult, data.Source) {
+				t.Errorf("Result mismatch.\nExpected:\n%#v\nGot:\n%#v",
+					data.Source,
+					actualResult,
+				)
+			}
+		})
+	}
+}
+
+type roundTripCSVData struct {
+	TestTitle     string
+	MetadataModel gojsoncore.JsonObject
+	Source        any
+	ExpectedErr   error
+}
+
+func roundTripCSVTestData(yield func(data *roundTripCSVData) bool) {
+	if !yield(&roundTripCSVData{
+		TestTitle:     "Join Symbol in Text Value",
+		MetadataModel: roundTripCSVMetadataModel(),
+		Source: []any{
+			map[string]any{
+				"Name":  []string{"Smith, John"},
+				"Notes": []string{"a, b"},
+			},
+		},
+	}) {
+		return
+	}
+
+	if !yield(&roundTripCSVData{
+		TestTitle:     "Join Symbol and Backslash in Multiple Text Values",
+		MetadataModel: roundTripCSVMetadataModel(),
+		Source: []any{
+			map[string]any{
+				"Name":  []string{"Smith, John", `C:\temp\`, "Doe"},
+				"Notes": []string{`x\, y`},
+			},
+		},
+	}) {
+		return
+	}
+
+	if !yield(&roundTripCSVData{
+		TestTitle:     "Single Empty Text Value",
+		MetadataModel: roundTripCSVMetadataModel(),
+		Source: []any{
+			map[string]any{
+				"Name": []string{""},
+			},
+			map[string]any{
+				"Name": []string{"", `\`, ""},
+			},
+		},
+	}) {
+		return
+	}
+
+	backslashJoinSymbolMetadataModel := roundTripCSVMetadataModel()
+	backslashJoinSymbolMetadataModel[core.GroupFields].(gojsoncore.JsonArray)[0].(gojsoncore.JsonObject)["Name"].(gojsoncore.JsonObject)[core.FieldMultipleValuesJoinSymbol] = `\|`
+	if !yield(&roundTripCSVData{
+		TestTitle:     "Join Symbol with Backslash",
+		MetadataModel: backslashJoinSymbolMetadataModel,
+		Source: []any{
+			map[string]any{
+				"Name": []string{"a", "b"},
+			},
+		},
+		ExpectedErr: core.ErrFieldMultipleValuesJoinSymbolInvalid,
+	}) {
+		return
+	}
+}
+
+func roundTripCSVMetadataModel() gojsoncore.JsonObject {
+	return gojsoncore.JsonObject{
+		core.FieldGroupJsonPathKey: path.JsonpathKeyRoot,
+		core.GroupFields: gojsoncore.JsonArray{
+			gojsoncore.JsonObject{
+				"Name": gojsoncore.JsonObject{
+					core.FieldGroupJsonPathKey: path.JsonpathKeyRoot + core.GroupJsonPathPrefix + "Name",
+					core.FieldGroupName:        "Name",
+					core.FieldDataType:         core.FieldTypeText,
+					core.FieldUI:               core.FieldUiText,
+				},
+				"Notes": gojsoncore.JsonObject{
+					core.FieldGroupJsonPathKey: path.JsonpathKeyRoot + core.GroupJsonPathPrefix + "Notes",
+					core.FieldGroupName:        "Notes",
+					core.FieldDataType:         core.FieldTypeText,
+					core.FieldUI:               core.FieldUiText,
+					core.FieldGroupMaxEntries:  1,
+				},
+			},
+		},
+		core.GroupReadOrderOfFields: gojsoncore.JsonArray{"Name", "Notes"},
+	}
+}
+
+type unflattenCSVData struct {
+	TestTitle      string
+	CSV            string
+	MetadataModel  gojsoncore.JsonObject
+	Schema         schema.Schema
+	Destination    any
+	ExpectedResult any
+	ExpectedErr    error
+}
+
+func unflattenCSVTestData(yield func(data *unflattenCSVData) bool) {
+	// -------------------------------------------------------------------------
+	// Case 1: Deep Nested Object (Employee -> Profile -> Address)
+	// -------------------------------------------------------------------------
+	if !yield(&unflattenCSVData{
+		TestTitle: "Deep Nested Employee",
+		CSV: "ID,Name,Age,Street,City,ZipCode,Skills\n" +
+			"500,Bob,30,123 Tech Ln,Silicon Valley,94000,\"Go,Rust\"\n" +
+			"500,Bob,30,456 Tech Ln,Silicon Valley,94000,\"Go,Rust\"\n" +
+			"600,Doe,35,,,,HTML\n",
+		MetadataModel: testdata.EmployeeMetadataModel(nil),
+		Schema:        testdata.EmployeeSchema(),
+		Destination:   []*testdata.Employee{},
+		ExpectedResult: []*testdata.Employee{
+			{
+				ID:     []int{500},
+				Skills: []string{"Go", "Rust"},
+				Profile: []*testdata.UserProfile{
+					{
+						Name: []string{"Bob"},
+						Age:  []int{30},
+						Address: []testdata.Address{
+							{Street: []string{"123 Tech Ln"}, City: []string{"Silicon Valley"}, ZipCode: []*string{gojsoncore.Ptr("94000")}},
+							{Street: []string{"456 Tech Ln"}, City: []string{"Silicon Valley"}, ZipCode: []*string{gojsoncore.Ptr("94000")}},
+						},
+					},
+				},
+			},
+			{
+				ID:      []int{600},
+				Skills:  []string{"HTML"},
+				Profile: []*testdata.UserProfile{{Name: []string{"Doe"}, Age: []int{35}}},
+			},
+		},
+	}) {
+		return
+	}
+
+	// -------------------------------------------------------------------------
+	// Case 2: Join symbol, timestamps, and checkboxes
+	// -------------------------------------------------------------------------
+	if !yield(&unflattenCSVData{
+		TestTitle: "Join Symbol, Timestamps, and Checkboxes",
+		CSV: "Verified,Tags,Published,Active\n" +
+			"true,a | b,2024-03-05,yes\n",
+		MetadataModel: csvMetadataModel(),
+		Destination:   []any{},
+		ExpectedResult: []any{
+			map[string]any{
+				"Tags":      []string{"a", "b"},
+				"Published": []time.Time{time.Date(2024, 3, 5, 0, 0, 0, 0, time.UTC)},
+				"Active":    []bool{true},
+				"Verified":  []bool{true},
+			},
+		},
+	}) {
+		return
+	}
+
+	// -------------------------------------------------------------------------
+	// Case 3: Errors
+	// -------------------------------------------------------------------------
+	if !yield(&unflattenCSVData{
+		TestTitle:     "Unknown Header",
+		CSV:           "Tags,Unknown\na,b\n",
+		MetadataModel: csvMetadataModel(),
+		Destination:   []any{},
+		ExpectedErr:   ErrHeaderInvalid,
+	}) {
+		return
+	}
+
+	if !yield(&unflattenCSVData{
+		TestTitle:     "Invalid Timestamp",
+		CSV:           "Published\n05/03/2024\n",
+		MetadataModel: csvMetadataModel(),
+		Destination:   []any{},
+		ExpectedErr:   ErrCellValueInvalid,
+	}) {
+		return
+	}
+
+	invalidJoinSymbolMetadataModel := csvMetadataModel()
+	invalidJoinSymbolMetadataModel[core.GroupFields].(gojsoncore.JsonArray)[0].(gojsoncore.JsonObject)["Tags"].(gojsoncore.JsonObject)[core.FieldMultipleValuesJoinSymbol] = `\|`
+	if !yield(&unflattenCSVData{
+		TestTitle:     "Join Symbol with Backslash",
+		CSV:           "Tags\na\\|b\n",
+		MetadataModel: invalidJoinSymbolMetadataModel,
+		Destination:   []any{},
+		ExpectedErr:   ErrCellValueInvalid,
+	}) {
+		return
+	}
+
+	if !yield(&unflattenCSVData{
+		TestTitle:     "Invalid Checkbox",
+		CSV:           "Active\nmaybe\n",
+		MetadataModel: csvMetadataModel(),
+		Destination:   []any{},
+		ExpectedErr:   ErrCellValueInvalid,
+	}) {
+		return
+	}
+}
+
+func csvMetadataModel() gojsoncore.JsonObject {
+	return deep.MustCopy(gojsoncore.JsonObject{
+		core.FieldGroupJsonPathKey: path.JsonpathKeyRoot,
+		core.GroupFields: gojsoncore.JsonArray{
+			gojsoncore.JsonObject{
+				"Tags": gojsoncore.JsonObject{
+					core.FieldGroupJsonPathKey:         path.JsonpathKeyRoot + core.GroupJsonPathPrefix + "Tags",
+					core.FieldGroupName:                "Tags",
+					core.FieldDataType:                 core.FieldTypeText,
+					core.FieldUI:                       core.FieldUiText,
+					core.FieldMultipleValuesJoinSymbol: " | ",
+				},
+				"Published": gojsoncore.JsonObject{
+					core.FieldGroupJsonPathKey: path.JsonpathKeyRoot + core.GroupJsonPathPrefix + "Published",
+					core.FieldGroupName:        "Published",
+					core.FieldDataType:         core.FieldTypeTimestamp,
+					core.FieldUI:               core.FieldUiDatetime,
+					core.FieldDatetimeFormat:   core.FieldDatetimeFormatYYYYMMDD,
+				},
+				"Active": gojsoncore.JsonObject{
+					core.FieldGroupJsonPathKey:        path.JsonpathKeyRoot + core.GroupJsonPathPrefix + "Active",
+					core.FieldGroupName:               "Active",
+					core.FieldDataType:                core.FieldTypeBoolean,
+					core.FieldUI:                      core.FieldUiCheckbox,
+					core.FieldCheckboxValuesUseInView: true,
+					core.FieldCheckboxValueIfTrue:     gojsoncore.JsonObject{core.Type: core.FieldTypeText, core.Value: "yes"},
+					core.FieldCheckboxValueIfFalse:    gojsoncore.JsonObject{core.Type: core.FieldTypeText, core.Value: "no"},
+				},
+				"Verified": gojsoncore.JsonObject{
+					core.FieldGroupJsonPathKey: path.JsonpathKeyRoot + core.GroupJsonPathPrefix + "Verified",
+					core.FieldGroupName:        "Verified",
+					core.FieldDataType:         core.FieldTypeBoolean,
+					core.FieldUI:               core.FieldUiCheckbox,
+				},
+			},
+		},
+		core.GroupReadOrderOfFields: gojsoncore.JsonArray{"Tags", "Published", "Active", "Verified"},
+	})
+}
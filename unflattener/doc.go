@@ -8,6 +8,9 @@ It can perform the following tasks:
   - Handle one-to-many relationships by grouping rows that share the same parent key.
   - Handle pivoted columns (horizontal expansion) by mapping them back to their array representation.
   - Write the reconstructed objects into a destination `object.Object`.
+  - Read CSV records into a `flattener.FlattenedTable`.
+  - Read .xlsx worksheet rows into a `flattener.FlattenedTable` with cell-level error reporting.
+  - Reconstruct records one at a time from rows sorted by the root primary key with bounded memory.
 
 # Usage
 
@@ -41,5 +44,28 @@ It can perform the following tasks:
 	err := unflattener.Unflatten(sourceTable)
 
 	// dest now contains the reconstructed object graph.
+
+## Unflattening CSV
+
+Use `UnflattenCSV` to read a CSV whose header columns match the `core.FieldGroupName` of the column fields. Cells are split on unescaped `core.FieldMultipleValuesJoinSymbol` and each value is parsed according to the field's `core.FieldDataType`, `core.FieldDatetimeFormat`, and `fieldcolumns.ColumnField.Schema`. Use `NewCSVReader` to read rows individually.
+
+	err := unflattener.UnflattenCSV(csvFile)
+
+## Unflattening XLSX
+
+Use `UnflattenXLSX` to read the first worksheet of an .xlsx workbook. Cells are parsed like `UnflattenCSV`, except that number cells of timestamp fields are read as serial dates and option labels are mapped back to their `core.FieldSelectOptions` value without splitting a cell that matches a label as a whole. Every cell that could not be read is returned as a `CellError`. Use `NewXLSXReader` to read rows individually.
+
+	cellErrors, err := unflattener.UnflattenXLSX(file, fileSize)
+
+## Streaming Records
+
+For large imports sorted (grouped) by the root primary key, use `NewStreamUnflattener` to write rows one at a time with `WriteRow`. Each root record is passed to the record handler as soon as a row with a different root signature is written and its index is dropped. Call `Close` to pass the last record.
+
+	stream := unflattener.NewStreamUnflattener(u, func(record any) error {
+		// ... save record
+		return nil
+	})
+	err := stream.WriteRow(row)
+	err = stream.Close()
 */
 package unflattener
@@ -0,0 +1,139 @@
+package unflattener
+
+import (
+	"reflect"
+
+	gojsoncore "github.com/rogonion/go-json/core"
+	"github.com/rogonion/go-metadatamodel/flattener"
+)
+
+/*
+StreamUnflattener reconstructs root records from rows one at a time using an Unflattener.
+
+Rows must be sorted (grouped) by the primary key of the root group. A root record is finished once a row with a different root signature is written, at which point it is passed to onRecord and its index subtree is dropped. Only the record being reconstructed is held in memory.
+
+A root signature that reappears after its record was finished starts a new record.
+
+The destination of the Unflattener must be a linear collection like `[]*MyStruct{}` or `gojsoncore.JsonArray{}`. Its type is used for each record. The Unflattener must not be used for anything else until StreamUnflattener.Close is called.
+*/
+type StreamUnflattener struct {
+	unflattener *Unflattener
+
+	// onRecord called with each finished root record.
+	onRecord func(record any) error
+
+	// recordsType type of the destination source. Each record is reconstructed in a new empty linear collection of this type.
+	recordsType reflect.Type
+
+	// currentSignature root signature of the record being reconstructed.
+	currentSignature string
+
+	// currentRecordStarted is true if at least one row of the record being reconstructed has been written.
+	currentRecordStarted bool
+
+	// noOfRecords number of records passed to onRecord.
+	noOfRecords uint64
+}
+
+/*
+WriteRow writes row into the record being reconstructed.
+
+If the root signature of row is different from that of the record being reconstructed, the record is first passed to onRecord.
+*/
+func (n *StreamUnflattener) WriteRow(row flattener.FlattenedRow) error {
+	if err := n.init(); err != nil {
+		return err
+	}
+
+	n.unflattener.currentSourceRow = row
+	signature := n.unflattener.groupSignature(n.unflattener.recursiveIndexTree)
+
+	if n.currentRecordStarted && signature != n.currentSignature {
+		if err := n.emitRecord(); err != nil {
+			return err
+		}
+		n.unflattener.currentSourceRow = row
+	}
+
+	n.currentSignature = signature
+	n.currentRecordStarted = true
+
+	return n.unflattener.recursiveConvert(n.unflattener.recursiveIndexTree, n.unflattener.index, []int{})
+}
+
+// Close passes the record being reconstructed, if any, to onRecord.
+func (n *StreamUnflattener) Close() error {
+	if !n.currentRecordStarted {
+		return nil
+	}
+
+	return n.emitRecord()
+}
+
+// GetNoOfRecords returns the number of records passed to onRecord.
+func (n *StreamUnflattener) GetNoOfRecords() uint64 {
+	return n.noOfRecords
+}
+
+// emitRecord passes the record being reconstructed to onRecord then resets the index and destination for the next record.
+func (n *StreamUnflattener) emitRecord() error {
+	const FunctionName = "emitRecord"
+
+	records := n.unflattener.destination.GetSourceReflected()
+	if records.Len() > 0 {
+		if err := n.onRecord(records.Index(0).Interface()); err != nil {
+			return NewError().WithFunctionName(FunctionName).WithMessage("handle record failed").WithNestedError(err).WithData(gojsoncore.JsonObject{"Record": n.noOfRecords})
+		}
+		n.noOfRecords++
+	}
+
+	n.currentRecordStarted = false
+	n.reset()
+	return nil
+}
+
+// reset drops the index of the previous record and replaces the destination source with a new empty linear collection.
+func (n *StreamUnflattener) reset() {
+	n.unflattener.index = &GroupCollection{
+		Instances: make(GroupCollectionInstances),
+	}
+	n.unflattener.destination.SetSourceReflected(reflect.MakeSlice(n.recordsType, 0, 0))
+}
+
+// init prepares the Unflattener on the first call.
+func (n *StreamUnflattener) init() error {
+	const FunctionName = "init"
+
+	if n.recordsType != nil {
+		return nil
+	}
+
+	if n.unflattener.destination == nil {
+		return NewError().WithFunctionName(FunctionName).WithMessage("destination not set").WithNestedError(ErrDestinationInvalid)
+	}
+
+	records := n.unflattener.destination.GetSourceReflected()
+	for records.Kind() == reflect.Pointer || records.Kind() == reflect.Interface {
+		records = records.Elem()
+	}
+	if records.Kind() != reflect.Slice {
+		return NewError().WithFunctionName(FunctionName).WithMessage("destination source not a slice").WithNestedError(ErrDestinationInvalid).WithData(gojsoncore.JsonObject{"Kind": records.Kind().String()})
+	}
+
+	if err := n.unflattener.initRecursiveIndexTree(); err != nil {
+		return err
+	}
+
+	n.recordsType = records.Type()
+	n.reset()
+	return nil
+}
+
+// NewStreamUnflattener creates a new StreamUnflattener that reconstructs records using unflattener and passes each finished record to onRecord.
+func NewStreamUnflattener(unflattener *Unflattener, onRecord func(record any) error) *StreamUnflattener {
+	n := &StreamUnflattener{
+		unflattener: unflattener,
+		onRecord:    onRecord,
+	}
+	return n
+}
@@ -0,0 +1,79 @@
+package unflattener
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/rogonion/go-json/object"
+	"github.com/rogonion/go-metadatamodel/testdata"
+)
+
+func TestStreamUnflattener_WriteRow(t *testing.T) {
+	for data := range unflattenerTestData {
+		t.Run(data.TestTitle, func(t *testing.T) {
+			// Each record is emitted on its own so the destination only needs to describe the type of the records.
+			destination := object.NewObject().WithSourceInterface(reflect.MakeSlice(reflect.TypeOf(data.ExpectedResult), 0, 0).Interface())
+			if data.Schema != nil {
+				destination = destination.WithSchema(data.Schema)
+			}
+
+			u := NewUnflattener(data.MetadataModel, NewSignature()).WithDestination(destination)
+			if data.ColumnFields != nil {
+				u.WithColumnFields(data.ColumnFields)
+			}
+
+			records := reflect.MakeSlice(reflect.TypeOf(data.ExpectedResult), 0, 0)
+			stream := NewStreamUnflattener(u, func(record any) error {
+				// The previous record must have been dropped from the destination.
+				if noOfRecordsInDestination := destination.GetSourceReflected().Len(); noOfRecordsInDestination != 1 {
+					t.Errorf("expected 1 record in destination, got %d", noOfRecordsInDestination)
+				}
+				records = reflect.Append(records, reflect.ValueOf(record))
+				return nil
+			})
+
+			for _, row := range toFlattenedTable(data.SourceTable) {
+				if err := stream.WriteRow(row); err != nil {
+					t.Fatalf("WriteRow() unexpected error: %v", err)
+				}
+			}
+			if err := stream.Close(); err != nil {
+				t.Fatalf("Close() unexpected error: %v", err)
+			}
+
+			if !reflect.DeepEqual(records.Interface(), data.ExpectedResult) {
+				t.Errorf("Result mismatch.\nExpected:\n%#v\nGot:\n%#v",
+					data.ExpectedResult,
+					records.Interface(),
+				)
+			}
+			if stream.GetNoOfRecords() != uint64(records.Len()) {
+				t.Errorf("expected GetNoOfRecords() %d, got %d", records.Len(), stream.GetNoOfRecords())
+			}
+		})
+	}
+}
+
+func TestStreamUnflattener_Errors(t *testing.T) {
+	row := toFlattenedTable([][]any{{[]int{1}, []string{"Laptop"}, []float64{999.99}}})[0]
+
+	t.Run("Destination Not A Slice", func(t *testing.T) {
+		u := NewUnflattener(testdata.ProductMetadataModel(nil), NewSignature()).WithDestination(object.NewObject().WithSourceInterface(testdata.Product{}))
+		if err := NewStreamUnflattener(u, func(record any) error { return nil }).WriteRow(row); !errors.Is(err, ErrDestinationInvalid) {
+			t.Errorf("expected error %v, got %v", ErrDestinationInvalid, err)
+		}
+	})
+
+	t.Run("Record Handler Error", func(t *testing.T) {
+		errRecord := errors.New("record rejected")
+		u := NewUnflattener(testdata.ProductMetadataModel(nil), NewSignature()).WithDestination(object.NewObject().WithSourceInterface([]*testdata.Product{}))
+		stream := NewStreamUnflattener(u, func(record any) error { return errRecord })
+		if err := stream.WriteRow(row); err != nil {
+			t.Fatalf("WriteRow() unexpected error: %v", err)
+		}
+		if err := stream.Close(); !errors.Is(err, errRecord) {
+			t.Errorf("expected error %v, got %v", errRecord, err)
+		}
+	})
+}
@@ -18,16 +18,7 @@ func (n *Unflattener) recursiveConvert(groupIndexTree *RecursiveGroupIndexTree,
 	const FunctionName = "recursiveConvert"
 
 	// 1. Identify Instance (Signature)
-	var pkColumns []int
-	if groupIndexTree.GroupColumnIndexes != nil {
-		if len(groupIndexTree.GroupColumnIndexes.Primary) > 0 {
-			pkColumns = groupIndexTree.GroupColumnIndexes.Primary
-		} else {
-			pkColumns = groupIndexTree.GroupColumnIndexes.All
-		}
-	}
-
-	signature := n.signature.GenerateSignature(n.currentSourceRow, pkColumns)
+	signature := n.groupSignature(groupIndexTree)
 
 	// 2. Get/Create Instance
 	node, instanceIndex := parentCollection.GetOrCreateInstance(signature, groupIndexTree.FieldColumnPosition.FieldGroupJsonPathKey)
@@ -76,6 +67,10 @@ func (n *Unflattener) recursiveConvert(groupIndexTree *RecursiveGroupIndexTree,
 				// Target Path end is slice/array, unwrap
 				if targetPathSuffixIsLinearCollection {
 					val = val.Index(0)
+					// Elements of cells like []any are interfaces.
+					if val.Kind() == reflect.Interface && !val.IsNil() {
+						val = val.Elem()
+					}
 				}
 			}
 
@@ -99,26 +94,24 @@ func (n *Unflattener) recursiveConvert(groupIndexTree *RecursiveGroupIndexTree,
 	return nil
 }
 
-// Unflatten processes the source FlattenedTable and reconstructs the object graph into the destination.
-func (n *Unflattener) Unflatten(source flattener.FlattenedTable) error {
-	const FunctionName = "Unflatten"
-
-	if n.columnFields == nil {
-		if columnFields, err := fieldcolumns.NewColumnFieldsExtraction(n.metadataModel).Extract(); err != nil {
-			return NewError().WithFunctionName(FunctionName).WithMessage("extract default columnFields failed").WithNestedError(err)
+// groupSignature returns the signature of the instance of groupIndexTree in Unflattener.currentSourceRow using the primary key columns of the group or all its columns if it has no primary key.
+func (n *Unflattener) groupSignature(groupIndexTree *RecursiveGroupIndexTree) string {
+	var pkColumns []int
+	if groupIndexTree.GroupColumnIndexes != nil {
+		if len(groupIndexTree.GroupColumnIndexes.Primary) > 0 {
+			pkColumns = groupIndexTree.GroupColumnIndexes.Primary
 		} else {
-			columnFields.Reposition()
-			columnFields.Skip(nil, nil)
-			n.columnFields = columnFields
+			pkColumns = groupIndexTree.GroupColumnIndexes.All
 		}
 	}
 
-	if n.recursiveIndexTree == nil {
-		if value, err := n.recursiveInitGroupIndexTree(n.metadataModel, path.JSONPath(path.JsonpathKeyRoot)); err != nil {
-			return err
-		} else {
-			n.recursiveIndexTree = value
-		}
+	return n.signature.GenerateSignature(n.currentSourceRow, pkColumns)
+}
+
+// Unflatten processes the source FlattenedTable and reconstructs the object graph into the destination.
+func (n *Unflattener) Unflatten(source flattener.FlattenedTable) error {
+	if err := n.initRecursiveIndexTree(); err != nil {
+		return err
 	}
 
 	if n.index == nil {
@@ -137,6 +130,41 @@ func (n *Unflattener) Unflatten(source flattener.FlattenedTable) error {
 	return nil
 }
 
+// initRecursiveIndexTree initializes Unflattener.columnFields if not set and builds Unflattener.recursiveIndexTree.
+func (n *Unflattener) initRecursiveIndexTree() error {
+	if err := n.initColumnFields(); err != nil {
+		return err
+	}
+
+	if n.recursiveIndexTree == nil {
+		if value, err := n.recursiveInitGroupIndexTree(n.metadataModel, path.JSONPath(path.JsonpathKeyRoot)); err != nil {
+			return err
+		} else {
+			n.recursiveIndexTree = value
+		}
+	}
+
+	return nil
+}
+
+// initColumnFields extracts the default Unflattener.columnFields from Unflattener.metadataModel if not set.
+func (n *Unflattener) initColumnFields() error {
+	const FunctionName = "initColumnFields"
+
+	if n.columnFields != nil {
+		return nil
+	}
+
+	columnFields, err := fieldcolumns.NewColumnFieldsExtraction(n.metadataModel).Extract()
+	if err != nil {
+		return NewError().WithFunctionName(FunctionName).WithMessage("extract default columnFields failed").WithNestedError(err)
+	}
+	columnFields.Reposition()
+	columnFields.Skip(nil, nil)
+	n.columnFields = columnFields
+	return nil
+}
+
 func (n *Unflattener) recursiveInitGroupIndexTree(group any, groupJsonPathKey path.JSONPath) (*RecursiveGroupIndexTree, error) {
 	const FunctionName = "recursiveInitGroupIndexTree"
 
@@ -242,6 +270,11 @@ func NewUnflattener(metadataModel gojsoncore.JsonObject, signature *Signature) *
 	}
 }
 
+// NewUnflattenerFromModel creates a new Unflattener instance with the provided typed metadata model converted with core.Model.JsonObject.
+func NewUnflattenerFromModel(model *core.Model, signature *Signature) *Unflattener {
+	return NewUnflattener(model.JsonObject(), signature)
+}
+
 // Unflattener converts a 2 dimension linear collection (like a 2D array) into a deeply nested mix of associative collections (like an array of objects).
 type Unflattener struct {
 	metadataModel gojsoncore.JsonObject
@@ -0,0 +1,327 @@
+package unflattener
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+
+	gojsoncore "github.com/rogonion/go-json/core"
+	"github.com/rogonion/go-json/schema"
+	"github.com/rogonion/go-metadatamodel/core"
+	"github.com/rogonion/go-metadatamodel/fieldcolumns"
+	"github.com/rogonion/go-metadatamodel/flattener"
+	"github.com/rogonion/go-metadatamodel/internal/xlsx"
+)
+
+/*
+CellError represents a single cell in a spreadsheet that could not be read.
+*/
+type CellError struct {
+	// Cell reference e.g. `B3`.
+	Cell string
+
+	// Row of the cell (1-based).
+	Row int
+
+	// Column of the cell (1-based).
+	Column int
+
+	// Value of the cell as text.
+	Value string
+
+	// Err classifies the problem e.g. ErrCellValueInvalid. Can be checked using errors.Is.
+	Err error
+}
+
+// String returns a human-readable representation of the CellError.
+func (n CellError) String() string {
+	return fmt.Sprintf("cell %s value '%s': %v", n.Cell, n.Value, n.Err)
+}
+
+// CellErrors is a list of CellError in the order they were found.
+type CellErrors []CellError
+
+/*
+Err returns nil if there are no cell errors.
+
+Otherwise, returns an error that wraps ErrCellValueInvalid and the CellError.Err of each cell error.
+*/
+func (n CellErrors) Err() error {
+	if len(n) == 0 {
+		return nil
+	}
+
+	errs := make([]error, 0, len(n)+1)
+	errs = append(errs, ErrCellValueInvalid)
+	for _, cellError := range n {
+		errs = append(errs, fmt.Errorf("%s: %w", cellError.Cell, cellError.Err))
+	}
+	return errors.Join(errs...)
+}
+
+/*
+XLSXReader reads the rows of the first worksheet of an .xlsx workbook into flattener.FlattenedRow for Unflattener.Unflatten. It is the inverse of flattener.XLSXWriter.
+
+  - The first row is the header row and is matched against core.FieldGroupName of each fieldcolumns.ColumnField. Refer to CSVReader.
+  - Number cells of core.FieldTypeTimestamp fields are read as serial dates.
+  - Text cells are parsed the same way as CSVReader. Labels of core.FieldSelectOptions are replaced with their Value. A text cell that matches a label as a whole is not split.
+  - Rows without values are skipped.
+  - Cells that could not be read are reported as CellErrors instead of stopping at the first one.
+*/
+type XLSXReader struct {
+	columnFields *fieldcolumns.ColumnFields
+
+	reader *xlsx.Reader
+
+	converter *schema.Conversion
+
+	// columnIndexes index in fieldcolumns.ColumnFields.UnskippedReadOrderOfColumnFields of each column in the worksheet.
+	columnIndexes []int
+}
+
+// ReadHeader reads the header row if it has not been read yet and matches each column to a fieldcolumns.ColumnField.
+//
+// Returns ErrHeaderInvalid if a column does not match the core.FieldGroupName of any column field.
+func (n *XLSXReader) ReadHeader() error {
+	const FunctionName = "ReadHeader"
+
+	if n.columnIndexes != nil {
+		return nil
+	}
+
+	row, err := n.readRow()
+	if err != nil {
+		return NewError().WithFunctionName(FunctionName).WithMessage("read header failed").WithNestedError(err)
+	}
+
+	header := make([]string, 0, len(row.Cells))
+	for _, cell := range row.Cells {
+		header = append(header, cell.Value)
+	}
+	// Trailing empty header cells are not columns.
+	for len(header) > 0 && len(header[len(header)-1]) == 0 {
+		header = header[:len(header)-1]
+	}
+
+	columnIndexes, err := matchHeader(n.columnFields, header)
+	if err != nil {
+		return NewError().WithFunctionName(FunctionName).WithMessage("match header failed").WithNestedError(err)
+	}
+	n.columnIndexes = columnIndexes
+
+	return nil
+}
+
+/*
+ReadRow reads the next row as a flattener.FlattenedRow. The header row is read first if it has not been read yet.
+
+Returns io.EOF if there are no more rows. Cells that could not be read are left out of the row and returned as CellErrors.
+*/
+func (n *XLSXReader) ReadRow() (flattener.FlattenedRow, CellErrors, error) {
+	const FunctionName = "ReadRow"
+
+	if err := n.ReadHeader(); err != nil {
+		return nil, nil, err
+	}
+
+	row, err := n.readRow()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, nil, io.EOF
+		}
+		return nil, nil, NewError().WithFunctionName(FunctionName).WithMessage("read row failed").WithNestedError(err)
+	}
+
+	flattenedRow := make(flattener.FlattenedRow, len(n.columnFields.UnskippedReadOrderOfColumnFields))
+	var cellErrors CellErrors
+	for column, cell := range row.Cells {
+		if column >= len(n.columnIndexes) || cell.Type == xlsx.CellTypeEmpty {
+			continue
+		}
+
+		columnField, ok := n.columnFields.GetColumnFieldByIndexInUnskippedReadOrder(n.columnIndexes[column])
+		if !ok {
+			continue
+		}
+
+		value, err := n.parseCell(columnField, cell)
+		if err != nil {
+			cellErrors = append(cellErrors, CellError{
+				Cell:   xlsx.CellReference(column, row.Index),
+				Row:    row.Index,
+				Column: column + 1,
+				Value:  cell.Value,
+				Err:    err,
+			})
+			continue
+		}
+		flattenedRow[n.columnIndexes[column]] = value
+	}
+
+	return flattenedRow, cellErrors, nil
+}
+
+// ReadTable reads every remaining row. Refer to XLSXReader.ReadRow.
+func (n *XLSXReader) ReadTable() (flattener.FlattenedTable, CellErrors, error) {
+	table := make(flattener.FlattenedTable, 0)
+	var cellErrors CellErrors
+	for {
+		row, rowCellErrors, err := n.ReadRow()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return table, cellErrors, nil
+			}
+			return nil, cellErrors, err
+		}
+		table = append(table, row)
+		cellErrors = append(cellErrors, rowCellErrors...)
+	}
+}
+
+// Close closes the worksheet.
+func (n *XLSXReader) Close() error {
+	return n.reader.Close()
+}
+
+// readRow returns the next row with at least one value.
+func (n *XLSXReader) readRow() (xlsx.Row, error) {
+	for {
+		row, err := n.reader.ReadRow()
+		if err != nil {
+			return xlsx.Row{}, err
+		}
+		for _, cell := range row.Cells {
+			if cell.Type != xlsx.CellTypeEmpty && (cell.Type != xlsx.CellTypeString || len(cell.Value) > 0) {
+				return row, nil
+			}
+		}
+	}
+}
+
+// parseCell parses the value of cell according to columnField. Returns an invalid reflect.Value for empty text cells.
+func (n *XLSXReader) parseCell(columnField *fieldcolumns.ColumnField, cell xlsx.Cell) (reflect.Value, error) {
+	fieldDataType, _ := columnField.Property[core.FieldDataType].(string)
+
+	var values []any
+	switch cell.Type {
+	case xlsx.CellTypeError:
+		return reflect.Value{}, fmt.Errorf("formula error: %w", ErrCellValueInvalid)
+	case xlsx.CellTypeNumber:
+		if fieldDataType == core.FieldTypeTimestamp {
+			serial, err := strconv.ParseFloat(cell.Value, 64)
+			if err != nil {
+				return reflect.Value{}, fmt.Errorf("'%s' is not a serial date: %w", cell.Value, ErrCellValueInvalid)
+			}
+			values = []any{xlsx.TimeFromSerial(serial)}
+			break
+		}
+		value, err := parseCellValue(columnField.Property, cell.Value)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		values = []any{value}
+	case xlsx.CellTypeBoolean:
+		value, err := parseCellValue(columnField.Property, strconv.FormatBool(cell.Value == "1"))
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		values = []any{value}
+	default:
+		if len(cell.Value) == 0 {
+			return reflect.Value{}, nil
+		}
+
+		// A label may contain the join symbol.
+		if selectOptionValue, ok := selectOptionValueByLabel(columnField.Property, cell.Value); ok {
+			values = []any{selectOptionValue}
+			break
+		}
+
+		cellValues, err := core.SplitFieldValues(columnField.Property, cell.Value)
+		if err != nil {
+			return reflect.Value{}, fmt.Errorf("%w: %w", err, ErrCellValueInvalid)
+		}
+
+		for _, cellValue := range cellValues {
+			if selectOptionValue, ok := selectOptionValueByLabel(columnField.Property, cellValue); ok {
+				values = append(values, selectOptionValue)
+				continue
+			}
+			value, err := parseCellValue(columnField.Property, cellValue)
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			values = append(values, value)
+		}
+	}
+
+	return convertCellValues(n.converter, columnField, values)
+}
+
+// NewXLSXReader opens the first worksheet of the .xlsx workbook in readerAt for reading rows whose columns are described by columnFields. Call XLSXReader.Close once done.
+func NewXLSXReader(columnFields *fieldcolumns.ColumnFields, readerAt io.ReaderAt, size int64) (*XLSXReader, error) {
+	const FunctionName = "NewXLSXReader"
+
+	reader, err := xlsx.NewReader(readerAt, size)
+	if err != nil {
+		return nil, NewError().WithFunctionName(FunctionName).WithMessage("open workbook failed").WithNestedError(err)
+	}
+
+	n := &XLSXReader{
+		columnFields: columnFields,
+		reader:       reader,
+		converter:    newCellConverter(),
+	}
+	return n, nil
+}
+
+/*
+UnflattenXLSX reads the rows of the first worksheet of the .xlsx workbook in readerAt using XLSXReader then passes them to Unflattener.Unflatten.
+
+If any cell could not be read, nothing is unflattened and the CellErrors are returned together with an error that wraps ErrCellValueInvalid.
+*/
+func (n *Unflattener) UnflattenXLSX(readerAt io.ReaderAt, size int64) (CellErrors, error) {
+	const FunctionName = "UnflattenXLSX"
+
+	if err := n.initColumnFields(); err != nil {
+		return nil, err
+	}
+
+	reader, err := NewXLSXReader(n.columnFields, readerAt, size)
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	table, cellErrors, err := reader.ReadTable()
+	if err != nil {
+		return cellErrors, NewError().WithFunctionName(FunctionName).WithMessage("read xlsx failed").WithNestedError(err)
+	}
+	if len(cellErrors) > 0 {
+		return cellErrors, NewError().WithFunctionName(FunctionName).
+			WithMessage(fmt.Sprintf("%d cells not valid", len(cellErrors))).
+			WithNestedError(cellErrors.Err()).
+			WithData(gojsoncore.JsonObject{"CellErrors": len(cellErrors)})
+	}
+
+	return nil, n.Unflatten(table)
+}
+
+// selectOptionValueByLabel returns the Value of the entry in core.FieldSelectOptions of field whose core.Label is label.
+func selectOptionValueByLabel(field gojsoncore.JsonObject, label string) (any, bool) {
+	selectOptions, err := core.AsJsonArray(field[core.FieldSelectOptions])
+	if err != nil {
+		return nil, false
+	}
+
+	for _, selectOption := range selectOptions {
+		if selectOptionObject, err := core.AsJsonObject(selectOption); err == nil {
+			if optionLabel, ok := selectOptionObject[core.Label].(string); ok && optionLabel == label {
+				return selectOptionObject[core.Value], true
+			}
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,287 @@
+package unflattener
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/brunoga/deep"
+	gojsoncore "github.com/rogonion/go-json/core"
+	"github.com/rogonion/go-json/object"
+	"github.com/rogonion/go-json/path"
+	"github.com/rogonion/go-metadatamodel/core"
+	"github.com/rogonion/go-metadatamodel/flattener"
+	"github.com/rogonion/go-metadatamodel/internal/xlsx"
+)
+
+func TestUnflattener_UnflattenXLSX(t *testing.T) {
+	for data := range unflattenXLSXTestData {
+		t.Run(data.TestTitle, func(t *testing.T) {
+			workbook := data.Workbook()
+			destination := object.NewObject().WithSourceInterface(data.Destination)
+
+			u := NewUnflattener(data.MetadataModel, NewSignature()).WithDestination(destination)
+			cellErrors, err := u.UnflattenXLSX(bytes.NewReader(workbook), int64(len(workbook)))
+
+			var actualCells []string
+			for _, cellError := range cellErrors {
+				actualCells = append(actualCells, cellError.Cell)
+			}
+			if !reflect.DeepEqual(actualCells, data.ExpectedCellErrors) {
+				t.Errorf("CellErrors mismatch.\nExpected: %v\nGot:      %v", data.ExpectedCellErrors, cellErrors)
+			}
+
+			if data.ExpectedErr != nil {
+				if !errors.Is(err, data.ExpectedErr) {
+					t.Fatalf("expected error %v, got %v", data.ExpectedErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("UnflattenXLSX() unexpected error: %v", err)
+			}
+
+			actualResult := destination.GetSourceInterface()
+			if !reflect.DeepEqual(actualResult, data.ExpectedResult) {
+				t.Errorf("Result mismatch.\nExpected:\n%#v\nGot:\n%#v",
+					data.ExpectedResult,
+					actualResult,
+				)
+			}
+		})
+	}
+}
+
+type unflattenXLSXData struct {
+	TestTitle          string
+	Workbook           func() []byte
+	MetadataModel      gojsoncore.JsonObject
+	Destination        any
+	ExpectedResult     any
+	ExpectedCellErrors []string
+	ExpectedErr        error
+}
+
+func unflattenXLSXTestData(yield func(data *unflattenXLSXData) bool) {
+	// -------------------------------------------------------------------------
+	// Case 1: Round trip through flattener.XLSXWriter
+	// -------------------------------------------------------------------------
+	if !yield(&unflattenXLSXData{
+		TestTitle: "Round Trip",
+		Workbook: func() []byte {
+			f := flattener.NewFlattener(xlsxMetadataModel())
+			if err := f.Flatten(object.NewObject().WithSourceInterface([]any{
+				gojsoncore.JsonObject{
+					"Code":      gojsoncore.JsonArray{7},
+					"Status":    gojsoncore.JsonArray{"A"},
+					"Published": gojsoncore.JsonArray{time.Date(2024, 3, 5, 12, 0, 0, 0, time.UTC)},
+					"Active":    gojsoncore.JsonArray{true},
+					"Verified":  gojsoncore.JsonArray{false},
+				},
+			})); err != nil {
+				panic(err)
+			}
+			var buffer bytes.Buffer
+			if err := f.WriteXLSX(&buffer); err != nil {
+				panic(err)
+			}
+			return buffer.Bytes()
+		},
+		MetadataModel: xlsxMetadataModel(),
+		Destination:   []any{},
+		ExpectedResult: []any{
+			map[string]any{
+				"Code":      []float64{7},
+				"Status":    []string{"A"},
+				"Published": []time.Time{time.Date(2024, 3, 5, 12, 0, 0, 0, time.UTC)},
+				"Active":    []bool{true},
+				"Verified":  []bool{false},
+			},
+		},
+	}) {
+		return
+	}
+
+	// -------------------------------------------------------------------------
+	// Case 2: Cell errors
+	// -------------------------------------------------------------------------
+	if !yield(&unflattenXLSXData{
+		TestTitle: "Cell Errors",
+		Workbook: func() []byte {
+			var buffer bytes.Buffer
+			writer, err := xlsx.NewWriter(&buffer, xlsx.SheetOptions{})
+			if err != nil {
+				panic(err)
+			}
+			for _, row := range [][]xlsx.Cell{
+				{{Type: xlsx.CellTypeString, Value: "Code"}, {Type: xlsx.CellTypeString, Value: "Published"}, {Type: xlsx.CellTypeString, Value: "Active"}},
+				{{Type: xlsx.CellTypeNumber, Value: "1"}, {Type: xlsx.CellTypeString, Value: "05/03/2024"}, {Type: xlsx.CellTypeString, Value: "yes"}},
+				{{Type: xlsx.CellTypeString, Value: "two"}, {}, {Type: xlsx.CellTypeString, Value: "maybe"}},
+			} {
+				if err := writer.WriteRow(row); err != nil {
+					panic(err)
+				}
+			}
+			if err := writer.Close(); err != nil {
+				panic(err)
+			}
+			return buffer.Bytes()
+		},
+		MetadataModel:      xlsxMetadataModel(),
+		Destination:        []any{},
+		ExpectedCellErrors: []string{"B2", "A3", "C3"},
+		ExpectedErr:        ErrCellValueInvalid,
+	}) {
+		return
+	}
+
+	// -------------------------------------------------------------------------
+	// Case 3: Unknown header
+	// -------------------------------------------------------------------------
+	if !yield(&unflattenXLSXData{
+		TestTitle: "Unknown Header",
+		Workbook: func() []byte {
+			var buffer bytes.Buffer
+			writer, err := xlsx.NewWriter(&buffer, xlsx.SheetOptions{})
+			if err != nil {
+				panic(err)
+			}
+			if err := writer.WriteRow([]xlsx.Cell{{Type: xlsx.CellTypeString, Value: "Unknown"}}); err != nil {
+				panic(err)
+			}
+			if err := writer.Close(); err != nil {
+				panic(err)
+			}
+			return buffer.Bytes()
+		},
+		MetadataModel: xlsxMetadataModel(),
+		Destination:   []any{},
+		ExpectedErr:   ErrHeaderInvalid,
+	}) {
+		return
+	}
+
+	// -------------------------------------------------------------------------
+	// Case 4: Join symbol in text values
+	// -------------------------------------------------------------------------
+	if !yield(&unflattenXLSXData{
+		TestTitle: "Round Trip Join Symbol in Text Value",
+		Workbook: func() []byte {
+			f := flattener.NewFlattener(roundTripCSVMetadataModel())
+			if err := f.Flatten(object.NewObject().WithSourceInterface([]any{
+				map[string]any{
+					"Name":  []string{"Smith, John"},
+					"Notes": []string{"a, b"},
+				},
+				map[string]any{
+					"Name": []string{"Smith, John", `C:\temp\`, "Doe"},
+				},
+			})); err != nil {
+				panic(err)
+			}
+			var buffer bytes.Buffer
+			if err := f.WriteXLSX(&buffer); err != nil {
+				panic(err)
+			}
+			return buffer.Bytes()
+		},
+		MetadataModel: roundTripCSVMetadataModel(),
+		Destination:   []any{},
+		ExpectedResult: []any{
+			map[string]any{
+				"Name":  []string{"Smith, John"},
+				"Notes": []string{"a, b"},
+			},
+			map[string]any{
+				"Name": []string{"Smith, John", `C:\temp\`, "Doe"},
+			},
+		},
+	}) {
+		return
+	}
+
+	if !yield(&unflattenXLSXData{
+		TestTitle: "Select Option Label with Join Symbol",
+		Workbook: func() []byte {
+			var buffer bytes.Buffer
+			writer, err := xlsx.NewWriter(&buffer, xlsx.SheetOptions{})
+			if err != nil {
+				panic(err)
+			}
+			for _, row := range [][]xlsx.Cell{
+				{{Type: xlsx.CellTypeString, Value: "Status"}},
+				{{Type: xlsx.CellTypeString, Value: "On Hold, Review"}},
+			} {
+				if err := writer.WriteRow(row); err != nil {
+					panic(err)
+				}
+			}
+			if err := writer.Close(); err != nil {
+				panic(err)
+			}
+			return buffer.Bytes()
+		},
+		MetadataModel: xlsxMetadataModel(),
+		Destination:   []any{},
+		ExpectedResult: []any{
+			map[string]any{
+				"Status": []string{"H"},
+			},
+		},
+	}) {
+		return
+	}
+}
+
+func xlsxMetadataModel() gojsoncore.JsonObject {
+	return deep.MustCopy(gojsoncore.JsonObject{
+		core.FieldGroupJsonPathKey: path.JsonpathKeyRoot,
+		core.GroupFields: gojsoncore.JsonArray{
+			gojsoncore.JsonObject{
+				"Code": gojsoncore.JsonObject{
+					core.FieldGroupJsonPathKey:         path.JsonpathKeyRoot + core.GroupJsonPathPrefix + "Code",
+					core.FieldGroupName:                "Code",
+					core.FieldDataType:                 core.FieldTypeNumber,
+					core.FieldUI:                       core.FieldUiNumber,
+					core.FieldGroupViewTableLockColumn: true,
+				},
+				"Status": gojsoncore.JsonObject{
+					core.FieldGroupJsonPathKey: path.JsonpathKeyRoot + core.GroupJsonPathPrefix + "Status",
+					core.FieldGroupName:        "Status",
+					core.FieldDataType:         core.FieldTypeText,
+					core.FieldUI:               core.FieldUiSelect,
+					core.FieldSelectOptions: gojsoncore.JsonArray{
+						gojsoncore.JsonObject{core.Label: "Active", core.Type: core.FieldTypeText, core.Value: "A"},
+						gojsoncore.JsonObject{core.Label: "Inactive", core.Type: core.FieldTypeText, core.Value: "I"},
+						gojsoncore.JsonObject{core.Label: "On Hold, Review", core.Type: core.FieldTypeText, core.Value: "H"},
+					},
+				},
+				"Published": gojsoncore.JsonObject{
+					core.FieldGroupJsonPathKey: path.JsonpathKeyRoot + core.GroupJsonPathPrefix + "Published",
+					core.FieldGroupName:        "Published",
+					core.FieldDataType:         core.FieldTypeTimestamp,
+					core.FieldUI:               core.FieldUiDatetime,
+					core.FieldDatetimeFormat:   core.FieldDatetimeFormatYYYYMMDD,
+				},
+				"Active": gojsoncore.JsonObject{
+					core.FieldGroupJsonPathKey:        path.JsonpathKeyRoot + core.GroupJsonPathPrefix + "Active",
+					core.FieldGroupName:               "Active",
+					core.FieldDataType:                core.FieldTypeBoolean,
+					core.FieldUI:                      core.FieldUiCheckbox,
+					core.FieldCheckboxValuesUseInView: true,
+					core.FieldCheckboxValueIfTrue:     gojsoncore.JsonObject{core.Type: core.FieldTypeText, core.Value: "yes"},
+					core.FieldCheckboxValueIfFalse:    gojsoncore.JsonObject{core.Type: core.FieldTypeText, core.Value: "no"},
+				},
+				"Verified": gojsoncore.JsonObject{
+					core.FieldGroupJsonPathKey: path.JsonpathKeyRoot + core.GroupJsonPathPrefix + "Verified",
+					core.FieldGroupName:        "Verified",
+					core.FieldDataType:         core.FieldTypeBoolean,
+					core.FieldUI:               core.FieldUiCheckbox,
+				},
+			},
+		},
+		core.GroupReadOrderOfFields: gojsoncore.JsonArray{"Code", "Status", "Published", "Active", "Verified"},
+	})
+}
@@ -0,0 +1,121 @@
+package validation
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/rogonion/go-json/path"
+	"github.com/rogonion/go-metadatamodel/core"
+)
+
+/*
+Violation represents a single problem found during validation.
+*/
+type Violation struct {
+	// JsonPath to the offending property in the metadata model or value in the source data.
+	//
+	// Example: `$.GroupFields[0].Address.GroupReadOrderOfFields[2]`
+	JsonPath path.JSONPath
+
+	// core.FieldGroupJsonPathKey of the field/group the violation belongs to if known.
+	FieldGroupJsonPathKey path.JSONPath
+
+	// Err classifies the violation e.g. ErrFieldDataTypeUnknown. Can be checked using errors.Is.
+	Err error
+
+	// Message describes the violation.
+	Message string
+}
+
+// String returns a human-readable representation of the Violation.
+func (n Violation) String() string {
+	return fmt.Sprintf("%s: %s", n.JsonPath, n.Message)
+}
+
+// Violations is a list of Violation in the order they were found.
+type Violations []Violation
+
+/*
+Err returns nil if there are no violations.
+
+Otherwise, returns an error that wraps ErrValidationFailed and the Violation.Err of each violation.
+*/
+func (n Violations) Err() error {
+	if len(n) == 0 {
+		return nil
+	}
+
+	errs := make([]error, 0, len(n)+1)
+	errs = append(errs, ErrValidationFailed)
+	for _, violation := range n {
+		errs = append(errs, fmt.Errorf("%s: %w", violation.String(), violation.Err))
+	}
+	return errors.Join(errs...)
+}
+
+// Filter returns violations whose Violation.Err matches target using errors.Is.
+func (n Violations) Filter(target error) Violations {
+	violations := make(Violations, 0)
+	for _, violation := range n {
+		if errors.Is(violation.Err, target) {
+			violations = append(violations, violation)
+		}
+	}
+	return violations
+}
+
+var (
+	// ErrValidationError default error for validation module.
+	ErrValidationError = errors.New("validation encountered an error")
+
+	// ErrValidationFailed for when one or more violations were found.
+	ErrValidationFailed = errors.New("validation failed")
+
+	// ErrGroupInvalid for when a group is not a JsonObject or its core.GroupFields or core.GroupReadOrderOfFields are not valid structure-wise.
+	ErrGroupInvalid = errors.New("group structure is not valid")
+
+	// ErrFieldGroupNotInGroupFields for when an entry in core.GroupReadOrderOfFields is not in core.GroupFields.
+	ErrFieldGroupNotInGroupFields = errors.New("field/group in read order not found in group fields")
+
+	// ErrFieldGroupNotInGroupReadOrderOfFields for when an entry in core.GroupFields is not in core.GroupReadOrderOfFields.
+	ErrFieldGroupNotInGroupReadOrderOfFields = errors.New("field/group in group fields not found in read order")
+
+	// ErrFieldGroupDuplicateInGroupReadOrderOfFields for when an entry appears more than once in core.GroupReadOrderOfFields.
+	ErrFieldGroupDuplicateInGroupReadOrderOfFields = errors.New("field/group appears more than once in read order")
+
+	// ErrFieldGroupJsonPathKeyMismatch for when core.FieldGroupJsonPathKey is missing or does not match the parent group prefix.
+	ErrFieldGroupJsonPathKeyMismatch = errors.New("field/group json path key does not match parent prefix")
+
+	// ErrFieldDataTypeUnknown for when core.FieldDataType is not one of core.FieldTypes.
+	ErrFieldDataTypeUnknown = errors.New("unknown field data type")
+
+	// ErrFieldUiUnknown for when core.FieldUI is not one of core.FieldUis.
+	ErrFieldUiUnknown = errors.New("unknown field ui")
+
+	// ErrDatabaseFieldColumnNameDuplicate for when core.DatabaseFieldColumnName appears more than once in the same table/collection.
+	ErrDatabaseFieldColumnNameDuplicate = errors.New("duplicate database field column name in table/collection")
+
+	// ErrMaxEntriesExceeded for when the number of entries of a field/group in source data exceeds core.FieldGroupMaxEntries.
+	ErrMaxEntriesExceeded = errors.New("max entries exceeded")
+
+	// ErrValueTypeMismatch for when a value in source data does not match core.FieldDataType.
+	ErrValueTypeMismatch = errors.New("value does not match field data type")
+
+	// ErrValueNotInSelectOptions for when a value in source data is not in core.FieldSelectOptions.
+	ErrValueNotInSelectOptions = errors.New("value not in select options")
+
+	// ErrValueDatetimeFormatMismatch for when a timestamp in source data does not fit core.FieldDatetimeFormat.
+	ErrValueDatetimeFormatMismatch = errors.New("value does not fit datetime format")
+
+	// ErrValueNotCheckboxValue for when a value in source data is neither core.FieldCheckboxValueIfTrue nor core.FieldCheckboxValueIfFalse.
+	ErrValueNotCheckboxValue = errors.New("value is not a checkbox value")
+
+	// ErrViewValuesInSeparateColumnsOnNestedGroup for when core.FieldGroupViewValuesInSeparateColumns is set on a group that contains nested groups.
+	ErrViewValuesInSeparateColumnsOnNestedGroup = errors.New("view values in separate columns set on group with nested groups")
+)
+
+// NewError creates a new core.Error with the default validation error base.
+func NewError() *core.Error {
+	n := core.NewError().WithDefaultBaseError(ErrValidationError)
+	return n
+}
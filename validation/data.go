@@ -0,0 +1,278 @@
+package validation
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+
+	gojsoncore "github.com/rogonion/go-json/core"
+	"github.com/rogonion/go-json/object"
+	"github.com/rogonion/go-json/path"
+	"github.com/rogonion/go-json/schema"
+	"github.com/rogonion/go-metadatamodel/core"
+	"github.com/rogonion/go-metadatamodel/iter"
+)
+
+/*
+Validate checks sourceData against DataValidation.metadataModel and reports every value that does not conform.
+
+Each Violation.JsonPath is the concrete path to the value in sourceData e.g. `$[1].Address[0].City[2]`.
+
+Checks that:
+  - The number of entries of each field/group does not exceed core.FieldGroupMaxEntries if greater than `0`.
+  - Each value matches core.FieldDataType.
+  - Each value of a core.FieldUiSelect field is the Value of one of core.FieldSelectOptions.
+  - Each string value of a core.FieldTypeTimestamp field fits core.FieldDatetimeFormat. time.RFC3339Nano is expected if core.FieldDatetimeFormat is not set.
+  - Each value of a core.FieldUiCheckbox field with core.FieldCheckboxValuesUseInStorage set to `true` is the Value of core.FieldCheckboxValueIfTrue or core.FieldCheckboxValueIfFalse.
+
+Returns an error if the metadata model could not be processed.
+*/
+func (n *DataValidation) Validate(sourceData *object.Object) (Violations, error) {
+	const FunctionName = "Validate"
+
+	violations := make(Violations, 0)
+
+	sourceValue := sourceData.GetSourceReflected()
+	for sourceValue.Kind() == reflect.Interface || sourceValue.Kind() == reflect.Pointer {
+		if sourceValue.IsNil() {
+			return violations, nil
+		}
+		sourceValue = sourceValue.Elem()
+	}
+	sourceOfValueIsAnArray := sourceValue.Kind() == reflect.Slice || sourceValue.Kind() == reflect.Array
+	sourceObject := object.NewObject().WithSourceReflected(sourceValue)
+
+	var forEachError error
+	iter.ForEach(n.metadataModel, func(fieldGroup gojsoncore.JsonObject) (bool, bool) {
+		// iter.ForEach does not propagate termination from nested groups.
+		if forEachError != nil {
+			return true, true
+		}
+
+		fieldGroupJsonPathKey, err := core.AsJSONPath(fieldGroup[core.FieldGroupJsonPathKey])
+		if err != nil {
+			forEachError = NewError().WithFunctionName(FunctionName).WithMessage("get FieldGroupJsonPathKey failed").WithData(gojsoncore.JsonObject{"FieldGroup": fieldGroup}).WithNestedError(err)
+			return true, true
+		}
+
+		jsonPathToValue, err := core.NewJsonPathToValue().WithSourceOfValueIsAnArray(sourceOfValueIsAnArray).WithReplaceArrayPathPlaceholderWithActualIndexes(false).Get(fieldGroupJsonPathKey, nil)
+		if err != nil {
+			forEachError = NewError().WithFunctionName(FunctionName).WithMessage("get json path to value failed").WithData(gojsoncore.JsonObject{"FieldGroupJsonPathKey": fieldGroupJsonPathKey}).WithNestedError(err)
+			return true, true
+		}
+
+		maxEntries := 0
+		if value, ok := fieldGroup[core.FieldGroupMaxEntries]; ok {
+			if err := schema.NewConversion().Convert(value, &schema.DynamicSchemaNode{Type: reflect.TypeOf(0), Kind: reflect.Int}, &maxEntries); err != nil {
+				forEachError = NewError().WithFunctionName(FunctionName).WithMessage(fmt.Sprintf("convert '%s' to int failed", core.FieldGroupMaxEntries)).WithData(gojsoncore.JsonObject{"FieldGroupJsonPathKey": fieldGroupJsonPathKey}).WithNestedError(err)
+				return true, true
+			}
+		}
+
+		isField := !core.IsFieldAGroup(fieldGroup)
+
+		sourceObject.ForEach(jsonPathToValue, func(jsonPath path.RecursiveDescentSegment, value reflect.Value) bool {
+			valueJsonPath := recursiveDescentSegmentToJsonPath(jsonPath)
+
+			for value.Kind() == reflect.Interface || value.Kind() == reflect.Pointer {
+				if value.IsNil() {
+					return false
+				}
+				value = value.Elem()
+			}
+			if !value.IsValid() {
+				return false
+			}
+
+			valueIsAnArray := value.Kind() == reflect.Slice || value.Kind() == reflect.Array
+			if valueIsAnArray && maxEntries > 0 && value.Len() > maxEntries {
+				violations = append(violations, Violation{
+					JsonPath:              path.JSONPath(valueJsonPath),
+					FieldGroupJsonPathKey: fieldGroupJsonPathKey,
+					Err:                   ErrMaxEntriesExceeded,
+					Message:               fmt.Sprintf("found %d entries, '%s' is %d", value.Len(), core.FieldGroupMaxEntries, maxEntries),
+				})
+			}
+
+			if !isField {
+				return false
+			}
+
+			if valueIsAnArray {
+				for i := 0; i < value.Len(); i++ {
+					violations = n.validateFieldValue(violations, fieldGroup, fieldGroupJsonPathKey, fmt.Sprintf("%s[%d]", valueJsonPath, i), value.Index(i))
+				}
+			} else {
+				violations = n.validateFieldValue(violations, fieldGroup, fieldGroupJsonPathKey, valueJsonPath, value)
+			}
+
+			return false
+		})
+
+		return false, false
+	})
+	if forEachError != nil {
+		return violations, forEachError
+	}
+
+	return violations, nil
+}
+
+// validateFieldValue appends violations of a single value of field to violations.
+func (n *DataValidation) validateFieldValue(violations Violations, field gojsoncore.JsonObject, fieldGroupJsonPathKey path.JSONPath, jsonPath string, value reflect.Value) Violations {
+	for value.Kind() == reflect.Interface || value.Kind() == reflect.Pointer {
+		if value.IsNil() {
+			return violations
+		}
+		value = value.Elem()
+	}
+	if !value.IsValid() {
+		return violations
+	}
+
+	addViolation := func(err error, message string) {
+		violations = append(violations, Violation{
+			JsonPath:              path.JSONPath(jsonPath),
+			FieldGroupJsonPathKey: fieldGroupJsonPathKey,
+			Err:                   err,
+			Message:               message,
+		})
+	}
+
+	fieldUi, _ := field[core.FieldUI].(string)
+
+	if fieldUi == core.FieldUiCheckbox {
+		if useInStorage, ok := field[core.FieldCheckboxValuesUseInStorage].(bool); ok && useInStorage {
+			checkboxValues := make([]any, 0)
+			for _, property := range []string{core.FieldCheckboxValueIfTrue, core.FieldCheckboxValueIfFalse} {
+				if checkboxValue, err := core.AsJsonObject(field[property]); err == nil {
+					checkboxValues = append(checkboxValues, checkboxValue[core.Value])
+				}
+			}
+			if len(checkboxValues) > 0 {
+				if !isValueInValues(value.Interface(), checkboxValues) {
+					addViolation(ErrValueNotCheckboxValue, fmt.Sprintf("value '%v' is not one of %v", value.Interface(), checkboxValues))
+				}
+				return violations
+			}
+		}
+	}
+
+	fieldDataType, _ := field[core.FieldDataType].(string)
+	switch fieldDataType {
+	case core.FieldTypeText:
+		if value.Kind() != reflect.String {
+			addViolation(ErrValueTypeMismatch, fmt.Sprintf("value of kind '%s' is not '%s'", value.Kind(), fieldDataType))
+			return violations
+		}
+	case core.FieldTypeNumber:
+		switch value.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+			reflect.Float32, reflect.Float64:
+		default:
+			addViolation(ErrValueTypeMismatch, fmt.Sprintf("value of kind '%s' is not '%s'", value.Kind(), fieldDataType))
+			return violations
+		}
+	case core.FieldTypeBoolean:
+		if value.Kind() != reflect.Bool {
+			addViolation(ErrValueTypeMismatch, fmt.Sprintf("value of kind '%s' is not '%s'", value.Kind(), fieldDataType))
+			return violations
+		}
+	case core.FieldTypeTimestamp:
+		if _, ok := value.Interface().(time.Time); ok {
+			break
+		}
+		if value.Kind() != reflect.String {
+			addViolation(ErrValueTypeMismatch, fmt.Sprintf("value of kind '%s' is not '%s'", value.Kind(), fieldDataType))
+			return violations
+		}
+		fieldDatetimeFormat, _ := field[core.FieldDatetimeFormat].(string)
+		if !isTimestampValid(value.String(), fieldDatetimeFormat) {
+			addViolation(ErrValueDatetimeFormatMismatch, fmt.Sprintf("value '%s' does not fit '%s' '%s'", value.String(), core.FieldDatetimeFormat, fieldDatetimeFormat))
+			return violations
+		}
+	}
+
+	if fieldUi == core.FieldUiSelect {
+		if selectOptions, err := core.AsJsonArray(field[core.FieldSelectOptions]); err == nil {
+			selectOptionValues := make([]any, 0, len(selectOptions))
+			for _, selectOption := range selectOptions {
+				if selectOptionObject, err := core.AsJsonObject(selectOption); err == nil {
+					selectOptionValues = append(selectOptionValues, selectOptionObject[core.Value])
+				}
+			}
+			if !isValueInValues(value.Interface(), selectOptionValues) {
+				addViolation(ErrValueNotInSelectOptions, fmt.Sprintf("value '%v' is not in '%s'", value.Interface(), core.FieldSelectOptions))
+			}
+		}
+	}
+
+	return violations
+}
+
+// recursiveDescentSegmentToJsonPath returns the string representation of jsonPath beginning with path.JsonpathKeyRoot.
+func recursiveDescentSegmentToJsonPath(jsonPath path.RecursiveDescentSegment) string {
+	jsonPathString := jsonPath.String()
+	if strings.HasPrefix(jsonPathString, path.JsonpathKeyRoot) {
+		return jsonPathString
+	}
+	if strings.HasPrefix(jsonPathString, path.JsonpathLeftBracket) || len(jsonPathString) == 0 {
+		return path.JsonpathKeyRoot + jsonPathString
+	}
+	return path.JsonpathKeyRoot + path.JsonpathDotNotation + jsonPathString
+}
+
+// isValueInValues compares the string representation of value with each of values so that numbers of different types can match.
+func isValueInValues(value any, values []any) bool {
+	valueString := fmt.Sprint(value)
+	return slices.ContainsFunc(values, func(v any) bool {
+		return fmt.Sprint(v) == valueString
+	})
+}
+
+// isTimestampValid checks if value can be parsed using the time.Layout of fieldDatetimeFormat or, if fieldDatetimeFormat is not set, time.RFC3339Nano.
+func isTimestampValid(value string, fieldDatetimeFormat string) bool {
+	layout, ok := core.FieldDatetimeFormatLayout(fieldDatetimeFormat)
+	if !ok {
+		layout = time.RFC3339Nano
+	}
+	_, err := time.Parse(layout, value)
+	return err == nil
+}
+
+// WithMetadataModel sets the metadata model and returns the DataValidation.
+func (n *DataValidation) WithMetadataModel(value gojsoncore.JsonObject) *DataValidation {
+	n.SetMetadataModel(value)
+	return n
+}
+
+// SetMetadataModel sets the metadata model.
+func (n *DataValidation) SetMetadataModel(value gojsoncore.JsonObject) {
+	n.metadataModel = value
+}
+
+/*
+NewDataValidation
+
+Parameters:
+  - metadataModel - data model the source data is validated against.
+*/
+func NewDataValidation(metadataModel gojsoncore.JsonObject) *DataValidation {
+	n := new(DataValidation)
+	n.SetMetadataModel(metadataModel)
+	return n
+}
+
+/*
+DataValidation checks that source data conforms to a metadata model.
+
+Usage:
+ 1. Instantiate DataValidation using NewDataValidation.
+ 2. Validate source data using DataValidation.Validate.
+*/
+type DataValidation struct {
+	metadataModel gojsoncore.JsonObject
+}
@@ -0,0 +1,205 @@
+package validation
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/brunoga/deep"
+	gojsoncore "github.com/rogonion/go-json/core"
+	"github.com/rogonion/go-json/object"
+	"github.com/rogonion/go-json/path"
+	"github.com/rogonion/go-metadatamodel/core"
+	"github.com/rogonion/go-metadatamodel/internal"
+	"github.com/rogonion/go-metadatamodel/testdata"
+)
+
+func TestValidation_DataValidation(t *testing.T) {
+	for testData := range dataValidationTestData {
+		res, err := NewDataValidation(testData.MetadataModel).Validate(testData.Object)
+		if err != nil {
+			t.Error(testData.TestTitle, "\n", "validate failed:", err)
+			continue
+		}
+
+		resJsonPaths := make([]path.JSONPath, len(res))
+		for i, violation := range res {
+			resJsonPaths[i] = violation.JsonPath
+			if i < len(testData.ExpectedErrs) && !errors.Is(violation.Err, testData.ExpectedErrs[i]) {
+				t.Error(
+					testData.TestTitle, "\n",
+					"violation error not as expected\n",
+					"violation=", violation.String(), "\n",
+					"Err=", violation.Err, "\n",
+					"ExpectedErr=", testData.ExpectedErrs[i],
+				)
+			}
+		}
+
+		if !slices.Equal(resJsonPaths, testData.ExpectedJsonPaths) {
+			t.Error(
+				testData.TestTitle, "\n",
+				"expected violation json paths to be equal to testData.ExpectedJsonPaths\n",
+				"ExpectedJsonPaths=", gojsoncore.JsonStringifyMust(testData.ExpectedJsonPaths), "\n",
+				"res=", gojsoncore.JsonStringifyMust(resJsonPaths),
+			)
+		}
+	}
+}
+
+type dataValidationData struct {
+	internal.TestData
+	Object            *object.Object
+	MetadataModel     gojsoncore.JsonObject
+	ExpectedJsonPaths []path.JSONPath
+	ExpectedErrs      []error
+}
+
+func dataValidationTestData(yield func(data *dataValidationData) bool) {
+	testCaseIndex := 1
+	metadataModel := testdata.UserProfileMetadataModel(nil)
+	metadataModel[core.GroupFields].(gojsoncore.JsonArray)[0].(gojsoncore.JsonObject)["Address"].(gojsoncore.JsonObject)[core.FieldGroupMaxEntries] = 1
+	if !yield(
+		&dataValidationData{
+			TestData: internal.TestData{
+				TestTitle: "User Profile - Address exceeds max entries",
+			},
+			Object: object.NewObject().WithSourceInterface([]*testdata.UserProfile{
+				{
+					Name:    []string{"User 0"},
+					Address: []testdata.Address{{City: []string{"City 0"}}},
+				},
+				{
+					Name:    []string{"User 1"},
+					Address: []testdata.Address{{City: []string{"City 1"}}, {City: []string{"City 2"}}},
+				},
+			}),
+			MetadataModel:     metadataModel,
+			ExpectedJsonPaths: []path.JSONPath{"$[1].Address"},
+			ExpectedErrs:      []error{ErrMaxEntriesExceeded},
+		},
+	) {
+		return
+	}
+
+	testCaseIndex++
+	if !yield(
+		&dataValidationData{
+			TestData: internal.TestData{
+				TestTitle: "Sample Metadata Model - Type, select, timestamp, and checkbox violations",
+			},
+			Object: object.NewObject().WithSourceInterface(gojsoncore.JsonArray{
+				gojsoncore.JsonObject{
+					"Count":     gojsoncore.JsonArray{1, 2.5},
+					"Country":   gojsoncore.JsonArray{"kenya"},
+					"Published": gojsoncore.JsonArray{"2024-01-31 10:00"},
+					"Bio":       gojsoncore.JsonArray{"yes"},
+				},
+				gojsoncore.JsonObject{
+					"Count":     gojsoncore.JsonArray{"one"},
+					"Country":   gojsoncore.JsonArray{"kenya", "narnia"},
+					"Published": gojsoncore.JsonArray{"2024-01-31T10:00:00Z", "31/01/2024"},
+					"Bio":       gojsoncore.JsonArray{true},
+				},
+			}),
+			MetadataModel: sampleMetadataModel(),
+			ExpectedJsonPaths: []path.JSONPath{
+				"$[1].Count[0]",
+				"$[1].Country",
+				"$[1].Country[1]",
+				"$[1].Published[0]",
+				"$[1].Published[1]",
+				"$[1].Bio[0]",
+			},
+			ExpectedErrs: []error{
+				ErrValueTypeMismatch,
+				ErrMaxEntriesExceeded,
+				ErrValueNotInSelectOptions,
+				ErrValueDatetimeFormatMismatch,
+				ErrValueDatetimeFormatMismatch,
+				ErrValueNotCheckboxValue,
+			},
+		},
+	) {
+		return
+	}
+
+	metadataModel = sampleMetadataModel()
+	delete(metadataModel[core.GroupFields].(gojsoncore.JsonArray)[0].(gojsoncore.JsonObject)["Published"].(gojsoncore.JsonObject), core.FieldDatetimeFormat)
+
+	testCaseIndex++
+	if !yield(
+		&dataValidationData{
+			TestData: internal.TestData{
+				TestTitle: "Sample Metadata Model - RFC3339 timestamps without datetime format",
+			},
+			Object: object.NewObject().WithSourceInterface(gojsoncore.JsonArray{
+				gojsoncore.JsonObject{
+					"Published": gojsoncore.JsonArray{"2024-01-31T10:00:00Z", "2024-01-31T10:00:00.5+03:00", "2024-01-31 10:00"},
+				},
+			}),
+			MetadataModel:     metadataModel,
+			ExpectedJsonPaths: []path.JSONPath{"$[0].Published[2]"},
+			ExpectedErrs:      []error{ErrValueDatetimeFormatMismatch},
+		},
+	) {
+		return
+	}
+
+	testCaseIndex++
+	if !yield(
+		&dataValidationData{
+			TestData: internal.TestData{
+				TestTitle: "Sample Metadata Model - Single object source",
+			},
+			Object: object.NewObject().WithSourceInterface(gojsoncore.JsonObject{
+				"Count": gojsoncore.JsonArray{true},
+			}),
+			MetadataModel:     sampleMetadataModel(),
+			ExpectedJsonPaths: []path.JSONPath{"$.Count[0]"},
+			ExpectedErrs:      []error{ErrValueTypeMismatch},
+		},
+	) {
+		return
+	}
+}
+
+func sampleMetadataModel() gojsoncore.JsonObject {
+	return deep.MustCopy(gojsoncore.JsonObject{
+		core.FieldGroupJsonPathKey: path.JsonpathKeyRoot,
+		core.GroupFields: gojsoncore.JsonArray{
+			gojsoncore.JsonObject{
+				"Count": gojsoncore.JsonObject{
+					core.FieldGroupJsonPathKey: path.JsonpathKeyRoot + core.GroupJsonPathPrefix + "Count",
+					core.FieldDataType:         core.FieldTypeNumber,
+					core.FieldUI:               core.FieldUiNumber,
+				},
+				"Country": gojsoncore.JsonObject{
+					core.FieldGroupJsonPathKey: path.JsonpathKeyRoot + core.GroupJsonPathPrefix + "Country",
+					core.FieldDataType:         core.FieldTypeText,
+					core.FieldUI:               core.FieldUiSelect,
+					core.FieldGroupMaxEntries:  1,
+					core.FieldSelectOptions: gojsoncore.JsonArray{
+						gojsoncore.JsonObject{core.Label: "Kenya", core.Type: core.FieldTypeText, core.Value: "kenya"},
+						gojsoncore.JsonObject{core.Label: "Uganda", core.Type: core.FieldTypeText, core.Value: "uganda"},
+					},
+				},
+				"Published": gojsoncore.JsonObject{
+					core.FieldGroupJsonPathKey: path.JsonpathKeyRoot + core.GroupJsonPathPrefix + "Published",
+					core.FieldDataType:         core.FieldTypeTimestamp,
+					core.FieldUI:               core.FieldUiDatetime,
+					core.FieldDatetimeFormat:   core.FieldDatetimeFormatYYYYMMDDHHMM,
+				},
+				"Bio": gojsoncore.JsonObject{
+					core.FieldGroupJsonPathKey:           path.JsonpathKeyRoot + core.GroupJsonPathPrefix + "Bio",
+					core.FieldDataType:                   core.FieldTypeBoolean,
+					core.FieldUI:                         core.FieldUiCheckbox,
+					core.FieldCheckboxValuesUseInStorage: true,
+					core.FieldCheckboxValueIfTrue:        gojsoncore.JsonObject{core.Type: core.FieldTypeText, core.Value: "yes"},
+					core.FieldCheckboxValueIfFalse:       gojsoncore.JsonObject{core.Type: core.FieldTypeText, core.Value: "no"},
+				},
+			},
+		},
+		core.GroupReadOrderOfFields: gojsoncore.JsonArray{"Count", "Country", "Published", "Bio"},
+	})
+}
@@ -0,0 +1,48 @@
+/*
+Package validation checks metadata models for structural problems and source data for conformance to a metadata model.
+
+Unlike the helpers in core which fail on the first problem encountered deep inside modules like fieldcolumns or flattener, every problem found is reported at once as a Violation with the JsonPath to the offending property.
+
+It can perform the following tasks:
+  - Check that core.GroupReadOrderOfFields and core.GroupFields of each group match.
+  - Check that core.FieldGroupJsonPathKey of each field/group matches the prefix of its parent group.
+  - Check that core.FieldDataType and core.FieldUI values are known.
+  - Check that core.DatabaseFieldColumnName is unique within a table/collection.
+  - Check that core.FieldGroupViewValuesInSeparateColumns is not set on groups that contain nested groups.
+  - Check that values in source data match core.FieldDataType, core.FieldGroupMaxEntries, core.FieldSelectOptions, core.FieldDatetimeFormat, and checkbox storage values.
+
+# Usage
+
+	import (
+		gojsoncore "github.com/rogonion/go-json/core"
+		"github.com/rogonion/go-json/object"
+		"github.com/rogonion/go-metadatamodel/validation"
+	)
+
+## Validating a Metadata Model
+
+	var metadataModel gojsoncore.JsonObject // ... load metadata model
+
+	violations := validation.Validate(metadataModel)
+	for _, violation := range violations {
+		fmt.Println(violation.JsonPath, violation.Message)
+	}
+
+	// Or as a single error which is nil if metadataModel is valid.
+	err := violations.Err()
+
+## Validating Source Data
+
+Each Violation.JsonPath is the concrete path to the value in source data e.g. `$[1].Address[0].City[2]`.
+
+	sourceData := object.NewObject().WithSourceInterface(records)
+
+	violations, err := validation.NewDataValidation(metadataModel).Validate(sourceData)
+
+## Checking Violation Types
+
+Each Violation.Err can be checked using errors.Is. Use Violations.Filter to retrieve violations of a particular type.
+
+	duplicateColumns := violations.Filter(validation.ErrDatabaseFieldColumnNameDuplicate)
+*/
+package validation
@@ -0,0 +1,209 @@
+package validation
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+
+	gojsoncore "github.com/rogonion/go-json/core"
+	"github.com/rogonion/go-json/path"
+	"github.com/rogonion/go-metadatamodel/core"
+)
+
+/*
+Validate checks the structure of metadataModel and reports every problem found.
+
+Unlike helpers such as core.GetGroupFields which fail on the first problem encountered, all violations are collected, each with the JsonPath to the offending property in metadataModel.
+
+Checks that:
+  - Each group is a JsonObject with valid core.GroupFields and core.GroupReadOrderOfFields.
+  - Entries in core.GroupReadOrderOfFields are in core.GroupFields and vice versa.
+  - core.FieldGroupJsonPathKey of each field/group matches the prefix of its parent group.
+  - core.FieldDataType and core.FieldUI of each field are one of core.FieldTypes and core.FieldUis respectively.
+  - core.DatabaseFieldColumnName is unique within a table/collection identified by core.DatabaseTableCollectionUid or core.DatabaseTableCollectionName and core.DatabaseJoinDepth.
+  - core.FieldGroupViewValuesInSeparateColumns is not set on groups that contain nested groups.
+
+Returns an empty Violations if metadataModel is valid.
+*/
+func Validate(metadataModel any) Violations {
+	n := &metadataModelValidation{
+		violations:           make(Violations, 0),
+		tableCollectionNames: make(map[string]map[string]path.JSONPath),
+	}
+
+	n.validateGroup(metadataModel, path.JsonpathKeyRoot, "")
+
+	return n.violations
+}
+
+/*
+validateGroup
+
+Parameters:
+  - group - Current group in metadata model.
+  - jsonPath - Path to group in metadata model.
+  - expectedFieldGroupJsonPathKey - core.FieldGroupJsonPathKey group is expected to have. Empty for the root group.
+*/
+func (n *metadataModelValidation) validateGroup(group any, jsonPath string, expectedFieldGroupJsonPathKey path.JSONPath) {
+	groupProperty, err := core.AsJsonObject(group)
+	if err != nil {
+		n.addViolation(jsonPath, expectedFieldGroupJsonPathKey, ErrGroupInvalid, "group is not a JsonObject")
+		return
+	}
+
+	fieldGroupJsonPathKey := n.validateFieldGroupJsonPathKey(groupProperty, jsonPath, expectedFieldGroupJsonPathKey)
+
+	var groupFields gojsoncore.JsonObject
+	if groupFieldsArray, err := core.AsJsonArray(groupProperty[core.GroupFields]); err != nil {
+		n.addViolation(jsonPath+path.JsonpathDotNotation+core.GroupFields, fieldGroupJsonPathKey, ErrGroupInvalid, fmt.Sprintf("'%s' is not a JsonArray", core.GroupFields))
+	} else if len(groupFieldsArray) != 1 {
+		n.addViolation(jsonPath+path.JsonpathDotNotation+core.GroupFields, fieldGroupJsonPathKey, ErrGroupInvalid, fmt.Sprintf("'%s' should contain exactly one JsonObject, found %d entries", core.GroupFields, len(groupFieldsArray)))
+	} else if groupFields, err = core.AsJsonObject(groupFieldsArray[0]); err != nil {
+		n.addViolation(jsonPath+path.JsonpathDotNotation+core.GroupFields+"[0]", fieldGroupJsonPathKey, ErrGroupInvalid, fmt.Sprintf("'%s' entry is not a JsonObject", core.GroupFields))
+	}
+
+	groupReadOrderOfFields, err := core.AsGroupReadOrderOfFields(groupProperty[core.GroupReadOrderOfFields])
+	if err != nil {
+		n.addViolation(jsonPath+path.JsonpathDotNotation+core.GroupReadOrderOfFields, fieldGroupJsonPathKey, ErrGroupInvalid, fmt.Sprintf("'%s' is not an array of strings", core.GroupReadOrderOfFields))
+	}
+
+	if value, ok := groupProperty[core.FieldGroupViewValuesInSeparateColumns].(bool); ok && value && groupFields != nil {
+		if core.DoesFieldGroupFieldsContainNestedGroupFields(groupProperty) {
+			n.addViolation(jsonPath+path.JsonpathDotNotation+core.FieldGroupViewValuesInSeparateColumns, fieldGroupJsonPathKey, ErrViewValuesInSeparateColumnsOnNestedGroup, fmt.Sprintf("'%s' cannot be set on a group that contains nested groups", core.FieldGroupViewValuesInSeparateColumns))
+		}
+	}
+
+	// Fields/groups are validated in read order followed by those missing from it.
+	fieldGroupKeySuffixes := make([]string, 0)
+	for index, fieldGroupKeySuffix := range groupReadOrderOfFields {
+		readOrderJsonPath := fmt.Sprintf("%s.%s[%d]", jsonPath, core.GroupReadOrderOfFields, index)
+		if slices.Contains(groupReadOrderOfFields[:index], fieldGroupKeySuffix) {
+			n.addViolation(readOrderJsonPath, fieldGroupJsonPathKey, ErrFieldGroupDuplicateInGroupReadOrderOfFields, fmt.Sprintf("'%s' appears more than once in '%s'", fieldGroupKeySuffix, core.GroupReadOrderOfFields))
+			continue
+		}
+		if groupFields == nil {
+			continue
+		}
+		if _, ok := groupFields[fieldGroupKeySuffix]; !ok {
+			n.addViolation(readOrderJsonPath, fieldGroupJsonPathKey, ErrFieldGroupNotInGroupFields, fmt.Sprintf("'%s' in '%s' not found in '%s'", fieldGroupKeySuffix, core.GroupReadOrderOfFields, core.GroupFields))
+			continue
+		}
+		fieldGroupKeySuffixes = append(fieldGroupKeySuffixes, fieldGroupKeySuffix)
+	}
+
+	if groupFields == nil {
+		return
+	}
+
+	notInReadOrder := make([]string, 0)
+	for fieldGroupKeySuffix := range groupFields {
+		if !slices.Contains(fieldGroupKeySuffixes, fieldGroupKeySuffix) {
+			notInReadOrder = append(notInReadOrder, fieldGroupKeySuffix)
+		}
+	}
+	sort.Strings(notInReadOrder)
+	for _, fieldGroupKeySuffix := range notInReadOrder {
+		if groupReadOrderOfFields != nil {
+			n.addViolation(n.groupFieldJsonPath(jsonPath, fieldGroupKeySuffix), fieldGroupJsonPathKey, ErrFieldGroupNotInGroupReadOrderOfFields, fmt.Sprintf("'%s' in '%s' not found in '%s'", fieldGroupKeySuffix, core.GroupFields, core.GroupReadOrderOfFields))
+		}
+		fieldGroupKeySuffixes = append(fieldGroupKeySuffixes, fieldGroupKeySuffix)
+	}
+
+	for _, fieldGroupKeySuffix := range fieldGroupKeySuffixes {
+		fieldGroupJsonPath := n.groupFieldJsonPath(jsonPath, fieldGroupKeySuffix)
+		expectedChildFieldGroupJsonPathKey := path.JSONPath(string(fieldGroupJsonPathKey) + core.GroupJsonPathPrefix + fieldGroupKeySuffix)
+
+		fieldGroup, err := core.AsJsonObject(groupFields[fieldGroupKeySuffix])
+		if err != nil {
+			n.addViolation(fieldGroupJsonPath, expectedChildFieldGroupJsonPathKey, ErrGroupInvalid, "field/group is not a JsonObject")
+			continue
+		}
+
+		_, hasGroupFields := fieldGroup[core.GroupFields]
+		_, hasGroupReadOrderOfFields := fieldGroup[core.GroupReadOrderOfFields]
+		if hasGroupFields || hasGroupReadOrderOfFields {
+			n.validateGroup(fieldGroup, fieldGroupJsonPath, expectedChildFieldGroupJsonPathKey)
+		} else {
+			n.validateField(fieldGroup, fieldGroupJsonPath, expectedChildFieldGroupJsonPathKey)
+		}
+	}
+}
+
+func (n *metadataModelValidation) validateField(field gojsoncore.JsonObject, jsonPath string, expectedFieldGroupJsonPathKey path.JSONPath) {
+	fieldGroupJsonPathKey := n.validateFieldGroupJsonPathKey(field, jsonPath, expectedFieldGroupJsonPathKey)
+
+	if value, ok := field[core.FieldDataType]; ok {
+		if fieldDataType, ok := value.(string); !ok || !slices.Contains(core.FieldTypes(), fieldDataType) {
+			n.addViolation(jsonPath+path.JsonpathDotNotation+core.FieldDataType, fieldGroupJsonPathKey, ErrFieldDataTypeUnknown, fmt.Sprintf("'%s' value '%v' is not one of %v", core.FieldDataType, value, core.FieldTypes()))
+		}
+	}
+
+	if value, ok := field[core.FieldUI]; ok {
+		if fieldUi, ok := value.(string); !ok || !slices.Contains(core.FieldUis(), fieldUi) {
+			n.addViolation(jsonPath+path.JsonpathDotNotation+core.FieldUI, fieldGroupJsonPathKey, ErrFieldUiUnknown, fmt.Sprintf("'%s' value '%v' is not one of %v", core.FieldUI, value, core.FieldUis()))
+		}
+	}
+
+	if databaseFieldColumnName, ok := field[core.DatabaseFieldColumnName].(string); ok && len(databaseFieldColumnName) > 0 {
+		var tableCollection string
+		if value, ok := field[core.DatabaseTableCollectionUid].(string); ok && len(value) > 0 {
+			tableCollection = value
+		} else if value, ok := field[core.DatabaseTableCollectionName].(string); ok && len(value) > 0 {
+			tableCollection = fmt.Sprintf("%s/%v", value, field[core.DatabaseJoinDepth])
+		} else {
+			return
+		}
+
+		if _, ok := n.tableCollectionNames[tableCollection]; !ok {
+			n.tableCollectionNames[tableCollection] = make(map[string]path.JSONPath)
+		}
+		if existingFieldGroupJsonPathKey, ok := n.tableCollectionNames[tableCollection][databaseFieldColumnName]; ok {
+			n.addViolation(jsonPath+path.JsonpathDotNotation+core.DatabaseFieldColumnName, fieldGroupJsonPathKey, ErrDatabaseFieldColumnNameDuplicate, fmt.Sprintf("'%s' value '%s' already used by '%s' in table/collection '%s'", core.DatabaseFieldColumnName, databaseFieldColumnName, existingFieldGroupJsonPathKey, tableCollection))
+		} else {
+			n.tableCollectionNames[tableCollection][databaseFieldColumnName] = fieldGroupJsonPathKey
+		}
+	}
+}
+
+/*
+validateFieldGroupJsonPathKey checks that core.FieldGroupJsonPathKey of fieldGroup is expectedFieldGroupJsonPathKey.
+
+Returns the core.FieldGroupJsonPathKey that descendants of fieldGroup are checked against.
+*/
+func (n *metadataModelValidation) validateFieldGroupJsonPathKey(fieldGroup gojsoncore.JsonObject, jsonPath string, expectedFieldGroupJsonPathKey path.JSONPath) path.JSONPath {
+	fieldGroupJsonPathKey, err := core.AsJSONPath(fieldGroup[core.FieldGroupJsonPathKey])
+	if err != nil || len(fieldGroupJsonPathKey) == 0 {
+		n.addViolation(jsonPath+path.JsonpathDotNotation+core.FieldGroupJsonPathKey, expectedFieldGroupJsonPathKey, ErrFieldGroupJsonPathKeyMismatch, fmt.Sprintf("'%s' is missing or not a string", core.FieldGroupJsonPathKey))
+		if len(expectedFieldGroupJsonPathKey) == 0 {
+			return path.JSONPath(path.JsonpathKeyRoot)
+		}
+		return expectedFieldGroupJsonPathKey
+	}
+
+	if len(expectedFieldGroupJsonPathKey) > 0 && fieldGroupJsonPathKey != expectedFieldGroupJsonPathKey {
+		n.addViolation(jsonPath+path.JsonpathDotNotation+core.FieldGroupJsonPathKey, expectedFieldGroupJsonPathKey, ErrFieldGroupJsonPathKeyMismatch, fmt.Sprintf("'%s' value '%s' should be '%s'", core.FieldGroupJsonPathKey, fieldGroupJsonPathKey, expectedFieldGroupJsonPathKey))
+	}
+
+	return fieldGroupJsonPathKey
+}
+
+// groupFieldJsonPath returns the path to the field/group with fieldGroupKeySuffix in the group at groupJsonPath.
+func (n *metadataModelValidation) groupFieldJsonPath(groupJsonPath string, fieldGroupKeySuffix string) string {
+	return groupJsonPath + path.JsonpathDotNotation + core.GroupFields + "[0]" + path.JsonpathDotNotation + fieldGroupKeySuffix
+}
+
+func (n *metadataModelValidation) addViolation(jsonPath string, fieldGroupJsonPathKey path.JSONPath, err error, message string) {
+	n.violations = append(n.violations, Violation{
+		JsonPath:              path.JSONPath(jsonPath),
+		FieldGroupJsonPathKey: fieldGroupJsonPathKey,
+		Err:                   err,
+		Message:               message,
+	})
+}
+
+type metadataModelValidation struct {
+	violations Violations
+
+	// Table/collection -> core.DatabaseFieldColumnName -> core.FieldGroupJsonPathKey of first field with column name.
+	tableCollectionNames map[string]map[string]path.JSONPath
+}
@@ -0,0 +1,152 @@
+package validation
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	gojsoncore "github.com/rogonion/go-json/core"
+	"github.com/rogonion/go-json/path"
+	"github.com/rogonion/go-metadatamodel/core"
+	"github.com/rogonion/go-metadatamodel/internal"
+	"github.com/rogonion/go-metadatamodel/testdata"
+)
+
+func TestValidation_Validate(t *testing.T) {
+	for testData := range validateTestData {
+		res := Validate(testData.MetadataModel)
+
+		resJsonPaths := make([]path.JSONPath, len(res))
+		for i, violation := range res {
+			resJsonPaths[i] = violation.JsonPath
+			if !errors.Is(violation.Err, testData.ExpectedErrs[min(i, len(testData.ExpectedErrs)-1)]) {
+				t.Error(
+					testData.TestTitle, "\n",
+					"violation error not as expected\n",
+					"violation=", violation.String(), "\n",
+					"Err=", violation.Err,
+				)
+			}
+		}
+
+		if !slices.Equal(resJsonPaths, testData.ExpectedJsonPaths) {
+			t.Error(
+				testData.TestTitle, "\n",
+				"expected violation json paths to be equal to testData.ExpectedJsonPaths\n",
+				"ExpectedJsonPaths=", gojsoncore.JsonStringifyMust(testData.ExpectedJsonPaths), "\n",
+				"res=", gojsoncore.JsonStringifyMust(resJsonPaths),
+			)
+		}
+
+		if testData.LogErrorsIfExpectedNotOk {
+			for _, violation := range res {
+				t.Log(testData.TestTitle, "\n", violation.String())
+			}
+		}
+	}
+}
+
+type validateData struct {
+	internal.TestData
+	MetadataModel     any
+	ExpectedJsonPaths []path.JSONPath
+	ExpectedErrs      []error
+}
+
+func validateTestData(yield func(data *validateData) bool) {
+	testCaseIndex := 1
+	for _, metadataModel := range []gojsoncore.JsonObject{
+		testdata.UserMetadataModel(nil),
+		testdata.ProductMetadataModel(nil),
+		testdata.CompanyMetadataModel(nil),
+		testdata.AddressMetadataModel(nil),
+		testdata.UserProfileMetadataModel(nil),
+		testdata.EmployeeMetadataModel(nil),
+	} {
+		if !yield(
+			&validateData{
+				TestData: internal.TestData{
+					TestTitle: "Valid Metadata Model " + core.GetFieldGroupName(metadataModel, ""),
+				},
+				MetadataModel:     metadataModel,
+				ExpectedJsonPaths: []path.JSONPath{},
+			},
+		) {
+			return
+		}
+		testCaseIndex++
+	}
+
+	metadataModel := testdata.UserProfileMetadataModel(nil)
+	groupFields := metadataModel[core.GroupFields].(gojsoncore.JsonArray)[0].(gojsoncore.JsonObject)
+	metadataModel[core.GroupReadOrderOfFields] = gojsoncore.JsonArray{"Name", "Address", "Missing", "Name"}
+	groupFields["Name"].(gojsoncore.JsonObject)[core.FieldDataType] = "Txt"
+	groupFields["Name"].(gojsoncore.JsonObject)[core.FieldUI] = 1
+	address := groupFields["Address"].(gojsoncore.JsonObject)
+	address[core.FieldGroupViewValuesInSeparateColumns] = true
+	addressGroupFields := address[core.GroupFields].(gojsoncore.JsonArray)[0].(gojsoncore.JsonObject)
+	addressGroupFields["City"].(gojsoncore.JsonObject)[core.FieldGroupJsonPathKey] = "$.GroupFields[*].City"
+	addressGroupFields["City"].(gojsoncore.JsonObject)[core.DatabaseFieldColumnName] = "Street"
+
+	if !yield(
+		&validateData{
+			TestData: internal.TestData{
+				TestTitle: "User Profile Metadata Model with multiple problems",
+			},
+			MetadataModel: metadataModel,
+			ExpectedJsonPaths: []path.JSONPath{
+				"$.GroupReadOrderOfFields[2]",
+				"$.GroupReadOrderOfFields[3]",
+				"$.GroupFields[0].Age",
+				"$.GroupFields[0].Name.FieldDataType",
+				"$.GroupFields[0].Name.FieldUi",
+				"$.GroupFields[0].Address.GroupFields[0].City.FieldGroupJsonPathKey",
+				"$.GroupFields[0].Address.GroupFields[0].City.DatabaseFieldColumnName",
+			},
+			ExpectedErrs: []error{
+				ErrFieldGroupNotInGroupFields,
+				ErrFieldGroupDuplicateInGroupReadOrderOfFields,
+				ErrFieldGroupNotInGroupReadOrderOfFields,
+				ErrFieldDataTypeUnknown,
+				ErrFieldUiUnknown,
+				ErrFieldGroupJsonPathKeyMismatch,
+				ErrDatabaseFieldColumnNameDuplicate,
+			},
+		},
+	) {
+		return
+	}
+
+	testCaseIndex++
+	metadataModel = testdata.CompanyMetadataModel(nil)
+	metadataModel[core.FieldGroupViewValuesInSeparateColumns] = true
+	metadataModel[core.GroupFields] = gojsoncore.JsonArray{}
+	if !yield(
+		&validateData{
+			TestData: internal.TestData{
+				TestTitle: "Company Metadata Model with empty GroupFields",
+			},
+			MetadataModel:     metadataModel,
+			ExpectedJsonPaths: []path.JSONPath{"$.GroupFields"},
+			ExpectedErrs:      []error{ErrGroupInvalid},
+		},
+	) {
+		return
+	}
+
+	testCaseIndex++
+	metadataModel = testdata.EmployeeMetadataModel(nil)
+	metadataModel[core.FieldGroupViewValuesInSeparateColumns] = true
+	if !yield(
+		&validateData{
+			TestData: internal.TestData{
+				TestTitle: "Employee Metadata Model with FieldGroupViewValuesInSeparateColumns on group with nested groups",
+			},
+			MetadataModel:     metadataModel,
+			ExpectedJsonPaths: []path.JSONPath{"$.FieldGroupViewValuesInSeparateColumns"},
+			ExpectedErrs:      []error{ErrViewValuesInSeparateColumnsOnNestedGroup},
+		},
+	) {
+		return
+	}
+}